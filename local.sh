@@ -1,3 +1,5 @@
 export APP_PORT=":8080"
 export DB_LOCATION="$PWD/posts.db"
+export ADMIN_TOKEN="local-admin-token"
+export GRPC_PORT=":9090"
 cd src; go run cmd/api/main.go
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE blog_posts ADD COLUMN status TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE blog_posts ADD COLUMN publish_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_blog_posts_status_publish_at ON blog_posts (status, publish_at);
@@ -0,0 +1,4 @@
+ALTER TABLE comments ADD COLUMN parent_comment_id INTEGER REFERENCES comments(id);
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_comment_id ON comments (parent_comment_id);
+CREATE INDEX IF NOT EXISTS idx_blog_posts_comments_comment_id ON blog_posts_comments (comment_id);
@@ -0,0 +1,4 @@
+ALTER TABLE comments ADD COLUMN moderation_status TEXT NOT NULL DEFAULT 'approved';
+ALTER TABLE blog_posts ADD COLUMN comment_moderation TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_comments_moderation_status ON comments (moderation_status);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS blog_posts_tags (
+    blog_post_id INTEGER,
+    tag_id INTEGER,
+    PRIMARY KEY (blog_post_id, tag_id)
+    FOREIGN KEY (blog_post_id) REFERENCES blog_posts(id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_posts_tags_tag_id ON blog_posts_tags (tag_id);
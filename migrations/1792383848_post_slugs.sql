@@ -0,0 +1,20 @@
+ALTER TABLE blog_posts ADD COLUMN slug TEXT;
+
+-- Every slug a blog post ever had, used to redirect renamed posts.
+CREATE TABLE IF NOT EXISTS blog_post_slugs (
+    slug TEXT PRIMARY KEY,
+    blog_post_id INTEGER NOT NULL,
+    FOREIGN KEY (blog_post_id) REFERENCES blog_posts(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_post_slugs_blog_post_id ON blog_post_slugs (blog_post_id);
+
+-- Existing posts get an approximate slug made unique with their ID.
+UPDATE blog_posts
+SET slug = trim(lower(replace(replace(trim(title), ' ', '-'), '/', '-')), '-') || '-' || id
+WHERE slug IS NULL;
+
+INSERT INTO blog_post_slugs (slug, blog_post_id)
+SELECT slug, id FROM blog_posts;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blog_posts_slug ON blog_posts (slug);
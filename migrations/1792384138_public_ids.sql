@@ -0,0 +1,27 @@
+-- Public identifiers exposed by the API, internal keys are kept for relationships.
+ALTER TABLE blog_posts ADD COLUMN public_id TEXT;
+ALTER TABLE comments ADD COLUMN public_id TEXT;
+
+-- Existing rows get UUIDv7 identifiers, with timestamps spaced by one millisecond to keep their creation order.
+UPDATE blog_posts
+SET public_id = lower(
+    substr(k.ts, 1, 8) || '-' || substr(k.ts, 9, 4) || '-7' || substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', 1 + abs(random()) % 4, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))
+FROM (
+    SELECT id, printf('%012x', CAST(unixepoch('subsec') * 1000 AS INTEGER) - (SELECT MAX(id) FROM blog_posts) + id) AS ts
+    FROM blog_posts
+) k
+WHERE blog_posts.id = k.id AND blog_posts.public_id IS NULL;
+
+UPDATE comments
+SET public_id = lower(
+    substr(k.ts, 1, 8) || '-' || substr(k.ts, 9, 4) || '-7' || substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', 1 + abs(random()) % 4, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))
+FROM (
+    SELECT id, printf('%012x', CAST(unixepoch('subsec') * 1000 AS INTEGER) - (SELECT MAX(id) FROM comments) + id) AS ts
+    FROM comments
+) k
+WHERE comments.id = k.id AND comments.public_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blog_posts_public_id ON blog_posts (public_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_comments_public_id ON comments (public_id);
@@ -0,0 +1,8 @@
+ALTER TABLE blog_posts ADD COLUMN content_format TEXT NOT NULL DEFAULT 'text';
+ALTER TABLE blog_posts ADD COLUMN content_html TEXT;
+
+-- Existing posts are plain text, rendered as a single escaped paragraph.
+UPDATE blog_posts
+SET content_html = '<p>' || replace(replace(replace(replace(replace(content,
+    '&', '&amp;'), '''', '&#39;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;') || '</p>'
+WHERE content_html IS NULL;
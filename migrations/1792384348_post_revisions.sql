@@ -0,0 +1,18 @@
+-- Snapshot of blog post content after every write, numbered per post.
+CREATE TABLE IF NOT EXISTS blog_post_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    blog_post_id INTEGER NOT NULL,
+    revision INTEGER NOT NULL,
+    title TEXT,
+    content TEXT,
+    content_format TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (blog_post_id, revision),
+    FOREIGN KEY (blog_post_id) REFERENCES blog_posts(id)
+);
+
+-- Current content of existing posts becomes their first revision.
+INSERT INTO blog_post_revisions (blog_post_id, revision, title, content, content_format, created_at)
+SELECT id, 1, title, content, content_format, strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+FROM blog_posts
+WHERE id NOT IN (SELECT blog_post_id FROM blog_post_revisions);
@@ -0,0 +1,5 @@
+ALTER TABLE blog_posts ADD COLUMN deleted_at DATETIME;
+ALTER TABLE comments ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_blog_posts_deleted_at ON blog_posts (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments (deleted_at);
@@ -0,0 +1,9 @@
+ALTER TABLE blog_posts ADD COLUMN excerpt TEXT;
+
+-- Existing posts are plain text, their excerpt is the first 200 characters.
+UPDATE blog_posts
+SET excerpt = CASE
+    WHEN length(trim(content)) <= 200 THEN trim(content)
+    ELSE rtrim(substr(trim(content), 1, 200)) || '…'
+END
+WHERE excerpt IS NULL;
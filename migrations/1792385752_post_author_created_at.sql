@@ -0,0 +1,13 @@
+ALTER TABLE blog_posts ADD COLUMN author TEXT;
+ALTER TABLE blog_posts ADD COLUMN created_at DATETIME;
+
+-- Existing posts were created along with their first revision.
+UPDATE blog_posts
+SET created_at = COALESCE(
+    (SELECT MIN(r.created_at) FROM blog_post_revisions r WHERE r.blog_post_id = blog_posts.id),
+    strftime('%Y-%m-%d %H:%M:%S+00:00', 'now')
+)
+WHERE created_at IS NULL;
+
+CREATE INDEX idx_blog_posts_created_at ON blog_posts (created_at);
+CREATE INDEX idx_blog_posts_author ON blog_posts (author);
@@ -0,0 +1,6 @@
+ALTER TABLE comments ADD COLUMN created_at DATETIME;
+
+-- Creation dates of existing comments are unknown, the migration date is used instead.
+UPDATE comments
+SET created_at = strftime('%Y-%m-%d %H:%M:%S+00:00', 'now')
+WHERE created_at IS NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL UNIQUE,
+    event_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error TEXT,
+    dispatched_at DATETIME
+);
+CREATE INDEX idx_outbox_dispatched_at_next_attempt_at ON outbox (dispatched_at, next_attempt_at);
+CREATE INDEX idx_outbox_aggregate_id ON outbox (aggregate_id, id);
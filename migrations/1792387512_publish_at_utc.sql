@@ -0,0 +1,4 @@
+-- Publish dates used to be stored with the offset they were sent with, while they are compared as text in UTC.
+UPDATE blog_posts
+SET publish_at = strftime('%Y-%m-%d %H:%M:%f', publish_at) || '+00:00'
+WHERE publish_at IS NOT NULL AND publish_at NOT LIKE '%+00:00' AND publish_at NOT LIKE '%Z';
@@ -0,0 +1,4 @@
+-- Comment events are only public while their blog post is published, so the outbox keeps the status it had.
+ALTER TABLE outbox ADD COLUMN post_status TEXT;
+UPDATE outbox
+SET post_status = (SELECT a.status FROM blog_posts a WHERE a.public_id = outbox.aggregate_id AND a.deleted_at IS NULL);
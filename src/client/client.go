@@ -0,0 +1,448 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// client Productive posts API client implementation
+type client struct {
+	BaseURL    *url.URL
+	AdminToken string
+	HTTPClient *http.Client
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// NewClient Returns new productive posts API client implementation.
+func NewClient(cfg Config) (Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("base URL (%s) must be absolute", cfg.BaseURL)
+	}
+	if cfg.Backoff < 0 || cfg.MaxBackoff < 0 {
+		return nil, errors.New("backoff must not be negative")
+	}
+
+	c := &client{
+		BaseURL:    baseURL,
+		AdminToken: cfg.AdminToken,
+		HTTPClient: cfg.HTTPClient,
+		MaxRetries: cfg.MaxRetries,
+		Backoff:    cfg.Backoff,
+		MaxBackoff: cfg.MaxBackoff,
+	}
+	if c.HTTPClient == nil {
+		c.HTTPClient = http.DefaultClient
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = DefaultMaxRetries
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	if c.Backoff == 0 {
+		c.Backoff = DefaultBackoff
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	return c, nil
+}
+
+// ListPosts Returns a page of posts.
+func (c *client) ListPosts(ctx context.Context, opts ListOptions) (*posts.GetAllResponse, error) {
+	query := paginationValues(opts.Page, opts.Limit)
+	for _, tag := range opts.Tags {
+		query.Add("tag", tag)
+	}
+	if opts.MatchAllTags {
+		query.Set("tag_match", "all")
+	}
+	if opts.IncludeComments {
+		query.Set("include", "comments")
+	}
+	if opts.CommentsLimit > 0 {
+		query.Set("comments_limit", strconv.Itoa(opts.CommentsLimit))
+	}
+	filterValues(opts.Filter, query)
+
+	var response posts.GetAllResponse
+	if err := c.do(ctx, http.MethodGet, "/api/posts", query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Posts Iterates over every post from the page in opts on, reading pages as they are needed.
+func (c *client) Posts(ctx context.Context, opts ListOptions) iter.Seq2[posts.BlogPost, error] {
+	return func(yield func(posts.BlogPost, error) bool) {
+		for {
+			response, err := c.ListPosts(ctx, opts)
+			if err != nil {
+				yield(posts.BlogPost{}, err)
+				return
+			}
+
+			for _, post := range response.BlogPosts {
+				if !yield(post, nil) {
+					return
+				}
+			}
+
+			// A short page is the last one.
+			if len(response.BlogPosts) < response.Pagination.Limit {
+				return
+			}
+			opts.Page = response.Pagination.Page + 1
+		}
+	}
+}
+
+// GetPost Returns single post, found by ID or slug, old slugs included.
+func (c *client) GetPost(ctx context.Context, id string) (*posts.BlogPost, error) {
+	var post posts.BlogPost
+	if err := c.do(ctx, http.MethodGet, "/api/posts/"+url.PathEscape(id), nil, nil, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// CreatePost Creates new post and returns its ID.
+func (c *client) CreatePost(ctx context.Context, request posts.CreatePostRequest) (string, error) {
+	var response struct {
+		BlogPostID string `json:"blog_post_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/posts", nil, request, &response); err != nil {
+		return "", err
+	}
+	return response.BlogPostID, nil
+}
+
+// UpdatePost Updates title and content of a post.
+func (c *client) UpdatePost(ctx context.Context, id string, request posts.UpdatePostRequest) error {
+	return c.do(ctx, http.MethodPut, "/api/posts/"+url.PathEscape(id), nil, request, nil)
+}
+
+// PublishPost Publishes a post, or schedules it when publishAt is in the future.
+func (c *client) PublishPost(ctx context.Context, id string, publishAt *time.Time) error {
+	request := posts.PublishPostRequest{PublishAt: publishAt}
+	return c.do(ctx, http.MethodPost, "/api/posts/"+url.PathEscape(id)+"/publish", nil, request, nil)
+}
+
+// ArchivePost Archives a post.
+func (c *client) ArchivePost(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/api/posts/"+url.PathEscape(id)+"/archive", nil, nil, nil)
+}
+
+// DeletePost Moves a post to trash.
+func (c *client) DeletePost(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/api/posts/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetTags Returns all tags in use with their post counts.
+func (c *client) GetTags(ctx context.Context) ([]posts.Tag, error) {
+	var response posts.GetTagsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/tags", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Tags, nil
+}
+
+// GetComments Returns a page of the comment thread of a post, or of the replies of one of its comments.
+func (c *client) GetComments(ctx context.Context, postID string, opts CommentsOptions) (*posts.GetCommentsResponse, error) {
+	path := "/api/posts/" + url.PathEscape(postID) + "/comments"
+	if opts.ParentID != "" {
+		path += "/" + url.PathEscape(opts.ParentID) + "/replies"
+	}
+
+	query := paginationValues(opts.Page, opts.Limit)
+	if opts.MaxDepth != nil {
+		query.Set("max_depth", strconv.Itoa(*opts.MaxDepth))
+	}
+	if opts.Flat {
+		query.Set("format", "flat")
+	}
+
+	var response posts.GetCommentsResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Comments Iterates over every comment of a post from the page in opts on, reading pages as they are needed.
+func (c *client) Comments(ctx context.Context, postID string, opts CommentsOptions) iter.Seq2[posts.Comment, error] {
+	return func(yield func(posts.Comment, error) bool) {
+		for {
+			response, err := c.GetComments(ctx, postID, opts)
+			if err != nil {
+				yield(posts.Comment{}, err)
+				return
+			}
+
+			// Pages are made of the comments replying to the parent, flat lists also hold their replies.
+			paginated := 0
+			for _, comment := range response.Comments {
+				if comment.ParentID == opts.ParentID {
+					paginated++
+				}
+				if !yield(comment, nil) {
+					return
+				}
+			}
+
+			// A short page is the last one.
+			if paginated < response.Pagination.Limit {
+				return
+			}
+			opts.Page = response.Pagination.Page + 1
+		}
+	}
+}
+
+// CreateComment Creates new comment for a post and returns its ID.
+func (c *client) CreateComment(ctx context.Context, postID, text string) (string, error) {
+	var response struct {
+		CommentID string `json:"comment_id"`
+	}
+	request := posts.CreateCommentRequest{Text: text}
+	if err := c.do(ctx, http.MethodPost, "/api/posts/"+url.PathEscape(postID)+"/comments", nil, request, &response); err != nil {
+		return "", err
+	}
+	return response.CommentID, nil
+}
+
+// CreateReply Creates new reply to a comment of a post and returns its ID.
+func (c *client) CreateReply(ctx context.Context, postID, parentCommentID, text string) (string, error) {
+	var response struct {
+		CommentID string `json:"comment_id"`
+	}
+	request := posts.CreateCommentRequest{Text: text}
+	path := "/api/posts/" + url.PathEscape(postID) + "/comments/" + url.PathEscape(parentCommentID) + "/replies"
+	if err := c.do(ctx, http.MethodPost, path, nil, request, &response); err != nil {
+		return "", err
+	}
+	return response.CommentID, nil
+}
+
+// DeleteComment Moves a comment and its replies to trash.
+func (c *client) DeleteComment(ctx context.Context, postID, commentID string) error {
+	path := "/api/posts/" + url.PathEscape(postID) + "/comments/" + url.PathEscape(commentID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// ExportContent Writes every post followed by its comments to w as NDJSON, in the format ImportContent reads.
+func (c *client) ExportContent(ctx context.Context, w io.Writer) error {
+	response, err := c.send(ctx, http.MethodGet, "/api/admin/export", nil, "", nil)
+	if err != nil {
+		return err
+	}
+	defer drain(response)
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return decodeError(response)
+	}
+	if _, err := io.Copy(w, response.Body); err != nil {
+		return fmt.Errorf("unexpected error reading export: %w", err)
+	}
+	return nil
+}
+
+// ImportContent Imports posts and comments from NDJSON read from r, in the format ExportContent writes.
+func (c *client) ImportContent(ctx context.Context, r io.Reader, dryRun bool) (*posts.ImportResult, error) {
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error reading import: %w", err)
+	}
+
+	query := url.Values{}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	response, err := c.send(ctx, http.MethodPost, "/api/admin/import", query, "application/x-ndjson", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var result posts.ImportResult
+	if err := decodeResponse(response, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// do Sends a request to the API and decodes its response into out, unless it is nil.
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var (
+		payload     []byte
+		contentType string
+	)
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("unexpected error encoding request: %w", err)
+		}
+		contentType = "application/json"
+	}
+
+	response, err := c.send(ctx, method, path, query, contentType, payload)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(response, out)
+}
+
+// send Sends a request to the API with payload as body, when contentType is set, and returns its response.
+// Idempotent requests failing with network errors or temporary statuses are retried with exponential backoff.
+func (c *client) send(ctx context.Context, method, path string, query url.Values, contentType string, payload []byte) (*http.Response, error) {
+	endpoint := c.BaseURL.JoinPath(path)
+	endpoint.RawQuery = query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, method, endpoint.String(), bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected error building request: %w", err)
+		}
+		request.Header.Set("Accept", "application/json")
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		if c.AdminToken != "" {
+			request.Header.Set("Authorization", "Bearer "+c.AdminToken)
+		}
+
+		response, err := c.HTTPClient.Do(request)
+		retry := attempt < c.MaxRetries && idempotent(method)
+		if err != nil {
+			if !retry || ctx.Err() != nil {
+				return nil, err
+			}
+			if err := c.wait(ctx, attempt, ""); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if retry && temporaryStatus(response.StatusCode) {
+			retryAfter := response.Header.Get("Retry-After")
+			drain(response)
+			if err := c.wait(ctx, attempt, retryAfter); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return response, nil
+	}
+}
+
+// wait Blocks until the backoff of provided attempt is over, or the context is done.
+// The wait asked by a Retry-After header in seconds is honored when longer, up to the max backoff.
+func (c *client) wait(ctx context.Context, attempt int, retryAfter string) error {
+	delay := c.Backoff << attempt
+	if delay <= 0 || delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		delay = max(delay, min(time.Duration(seconds)*time.Second, c.MaxBackoff))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// decodeResponse Decodes a successful response into out, or its problem details into an error.
+func decodeResponse(response *http.Response, out any) error {
+	defer drain(response)
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return decodeError(response)
+	}
+	if out == nil || response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("unexpected error decoding response: %w", err)
+	}
+	return nil
+}
+
+// drain Discards what is left of a response body and closes it, so its connection can be reused.
+func drain(response *http.Response) {
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+}
+
+// idempotent Reports whether requests with provided method can be safely sent again.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// paginationValues Builds pagination query params, leaving unset ones to the API defaults.
+func paginationValues(page, limit int) url.Values {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return query
+}
+
+// filterValues Adds the set fields of a filter to query params, named after their `query` tags.
+func filterValues(filter any, query url.Values) {
+	value := reflect.ValueOf(filter)
+	for i := range value.NumField() {
+		name := value.Type().Field(i).Tag.Get("query")
+		if name == "" {
+			continue
+		}
+
+		switch field := value.Field(i).Interface().(type) {
+		case *time.Time:
+			if field != nil {
+				query.Set(name, field.Format(time.RFC3339Nano))
+			}
+		case *bool:
+			if field != nil {
+				query.Set(name, strconv.FormatBool(*field))
+			}
+		default:
+			if s := value.Field(i).String(); value.Field(i).Kind() == reflect.String && s != "" {
+				query.Set(name, s)
+			}
+		}
+	}
+}
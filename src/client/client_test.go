@@ -0,0 +1,905 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/internal/app"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	postsv1 "github.com/MatiasKopp/prosig-code-challenge/proto/posts/v1"
+	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testAdminToken = "test-token"
+
+// testServer Serves the app over HTTP on a database migrated from scratch, wrapping its router when wrap is set.
+func testServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+
+	var handler http.Handler = testApp(t).Router
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testApp Returns the app on a database migrated from scratch, stopped once the test ends.
+func testApp(t *testing.T) *app.App {
+	t.Helper()
+
+	dbLocation := filepath.Join(t.TempDir(), "posts.db")
+	db, err := sql.Open("sqlite3", dbLocation)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	migrations, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.sql"))
+	if err != nil || len(migrations) == 0 {
+		t.Fatalf("failed to find migrations: %v", err)
+	}
+	slices.Sort(migrations)
+	for _, migration := range migrations {
+		script, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatalf("failed to read migration %s: %v", migration, err)
+		}
+		if _, err := db.Exec(string(script)); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", migration, err)
+		}
+	}
+
+	t.Setenv("DB_LOCATION", dbLocation)
+	t.Setenv("ADMIN_TOKEN", testAdminToken)
+	t.Setenv("FILTER_BLOCKED_WORDS", "casino")
+
+	a := app.New()
+	t.Cleanup(a.Stop)
+	return a
+}
+
+// testClient Returns a client of server retrying without noticeable waits.
+func testClient(t *testing.T, server *httptest.Server, adminToken string) Client {
+	t.Helper()
+
+	c, err := NewClient(Config{
+		BaseURL:    server.URL,
+		AdminToken: adminToken,
+		HTTPClient: server.Client(),
+		Backoff:    time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c
+}
+
+// checkAPIError Fails the test when err does not match want nor carries the wanted status.
+func checkAPIError(t *testing.T, err error, want error, wantStatus int) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got error %T, want *Error", err)
+	}
+	if apiErr.StatusCode != wantStatus {
+		t.Errorf("got status %d, want %d", apiErr.StatusCode, wantStatus)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "ok", cfg: Config{BaseURL: "http://localhost:8080/"}},
+		{name: "relative_url_error", cfg: Config{BaseURL: "/api"}, wantErr: true},
+		{name: "invalid_url_error", cfg: Config{BaseURL: "http://local host:%zz"}, wantErr: true},
+		{name: "negative_backoff_error", cfg: Config{BaseURL: "http://localhost", Backoff: -time.Second}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Posts(t *testing.T) {
+	server := testServer(t, nil)
+	admin := testClient(t, server, testAdminToken)
+	ctx := t.Context()
+
+	titles := []string{"First", "Second", "Third", "Fourth", "Fifth"}
+	for i, title := range titles {
+		request := posts.CreatePostRequest{Title: title, Content: "Content of " + title}
+		if i%2 == 0 {
+			request.Tags = []string{"even"}
+		}
+		if _, err := admin.CreatePost(ctx, request); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		opts   ListOptions
+		stopAt int
+		want   []string
+	}{
+		{name: "every_page", opts: ListOptions{Limit: 2}, want: titles},
+		{name: "exact_pages", opts: ListOptions{Limit: 5}, want: titles},
+		{name: "from_page", opts: ListOptions{Page: 2, Limit: 2}, want: titles[2:]},
+		{name: "stop_early", opts: ListOptions{Limit: 2}, stopAt: 3, want: titles[:3]},
+		{name: "by_tag", opts: ListOptions{Limit: 2, Tags: []string{"even"}}, want: []string{"First", "Third", "Fifth"}},
+		{name: "by_filter", opts: ListOptions{Filter: posts.ListFilter{TitleContains: "f"}}, want: []string{"First", "Fourth", "Fifth"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for post, err := range admin.Posts(ctx, tt.opts) {
+				if err != nil {
+					t.Fatalf("Posts() error = %v", err)
+				}
+				got = append(got, post.Title)
+				if len(got) == tt.stopAt {
+					break
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Posts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("error_stops_iteration", func(t *testing.T) {
+		opts := ListOptions{Filter: posts.ListFilter{Status: "unknown"}}
+		calls := 0
+		for _, err := range admin.Posts(ctx, opts) {
+			calls++
+			checkAPIError(t, err, posts.ErrorBadRequest, http.StatusBadRequest)
+		}
+		if calls != 1 {
+			t.Errorf("got %d iterations, want 1", calls)
+		}
+	})
+
+	t.Run("tag_without_slug", func(t *testing.T) {
+		for _, err := range admin.Posts(ctx, ListOptions{Tags: []string{"even", "!!"}}) {
+			checkAPIError(t, err, posts.ErrorBadRequest, http.StatusBadRequest)
+			break
+		}
+	})
+}
+
+func TestClient_GetPost(t *testing.T) {
+	server := testServer(t, nil)
+	admin := testClient(t, server, testAdminToken)
+	c := testClient(t, server, "")
+	ctx := t.Context()
+
+	postID, err := admin.CreatePost(ctx, posts.CreatePostRequest{Title: "Hello world", Content: "Hi", Tags: []string{"go"}, Status: posts.StatusPublished})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		wantErr    error
+		wantStatus int
+	}{
+		{name: "by_id_ok", id: postID},
+		{name: "by_slug_ok", id: "hello-world"},
+		{name: "not_found_error", id: "missing", wantErr: posts.ErrBlogPostNotFound, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetPost(ctx, tt.id)
+			if tt.wantErr != nil {
+				checkAPIError(t, err, tt.wantErr, tt.wantStatus)
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPost() error = %v", err)
+			}
+			if got.ID != postID || got.Title != "Hello world" || !slices.Equal(got.Tags, []string{"go"}) {
+				t.Errorf("GetPost() = %+v", got)
+			}
+		})
+	}
+
+	tags, err := c.GetTags(ctx)
+	if err != nil {
+		t.Fatalf("GetTags() error = %v", err)
+	}
+	if want := []posts.Tag{{Slug: "go", PostCount: 1}}; !slices.Equal(tags, want) {
+		t.Errorf("GetTags() = %v, want %v", tags, want)
+	}
+}
+
+func TestClient_AdminCalls(t *testing.T) {
+	server := testServer(t, nil)
+	admin := testClient(t, server, testAdminToken)
+	anonymous := testClient(t, server, "")
+	ctx := t.Context()
+
+	postID, err := admin.CreatePost(ctx, posts.CreatePostRequest{Title: "Draft", Content: "Soon", Status: posts.StatusDraft})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	if _, err := anonymous.GetPost(ctx, postID); !errors.Is(err, posts.ErrBlogPostNotFound) {
+		t.Errorf("got draft read error %v, want %v", err, posts.ErrBlogPostNotFound)
+	}
+
+	calls := []struct {
+		name   string
+		call   func(c Client) error
+		status posts.Status
+	}{
+		{
+			name: "update",
+			call: func(c Client) error {
+				return c.UpdatePost(ctx, postID, posts.UpdatePostRequest{Title: "Draft", Content: "Now"})
+			},
+			status: posts.StatusDraft,
+		},
+		{
+			name:   "publish",
+			call:   func(c Client) error { return c.PublishPost(ctx, postID, nil) },
+			status: posts.StatusPublished,
+		},
+		{
+			name:   "archive",
+			call:   func(c Client) error { return c.ArchivePost(ctx, postID) },
+			status: posts.StatusArchived,
+		},
+	}
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			checkAPIError(t, tt.call(anonymous), httputil.ErrUnauthorized, http.StatusUnauthorized)
+
+			if err := tt.call(admin); err != nil {
+				t.Fatalf("%s error = %v", tt.name, err)
+			}
+			post, err := admin.GetPost(ctx, postID)
+			if err != nil {
+				t.Fatalf("GetPost() error = %v", err)
+			}
+			if post.Status != tt.status {
+				t.Errorf("got status %s, want %s", post.Status, tt.status)
+			}
+		})
+	}
+
+	// Posts are dated by their latest revision, written by the update.
+	post, err := admin.GetPost(ctx, postID)
+	if err != nil {
+		t.Fatalf("GetPost() error = %v", err)
+	}
+	if !post.UpdatedAt.After(post.CreatedAt) {
+		t.Errorf("got updated at %s, want after created at %s", post.UpdatedAt, post.CreatedAt)
+	}
+
+	err = admin.ArchivePost(ctx, postID)
+	checkAPIError(t, err, posts.ErrInvalidStatusTransition, http.StatusConflict)
+
+	if err := admin.DeletePost(ctx, postID); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+	_, err = admin.GetPost(ctx, postID)
+	checkAPIError(t, err, posts.ErrBlogPostNotFound, http.StatusNotFound)
+}
+
+func TestClient_Comments(t *testing.T) {
+	server := testServer(t, nil)
+	admin := testClient(t, server, testAdminToken)
+	c := testClient(t, server, "")
+	ctx := t.Context()
+
+	postID, err := admin.CreatePost(ctx, posts.CreatePostRequest{Title: "Discussed", Content: "Talk", Status: posts.StatusPublished})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	var commentIDs []string
+	for _, text := range []string{"one", "two", "three"} {
+		commentID, err := c.CreateComment(ctx, postID, text)
+		if err != nil {
+			t.Fatalf("CreateComment() error = %v", err)
+		}
+		commentIDs = append(commentIDs, commentID)
+	}
+	for _, text := range []string{"one.a", "one.b"} {
+		if _, err := c.CreateReply(ctx, postID, commentIDs[0], text); err != nil {
+			t.Fatalf("CreateReply() error = %v", err)
+		}
+	}
+
+	texts := func(comments []posts.Comment) []string {
+		var got []string
+		for _, comment := range comments {
+			got = append(got, comment.CommentText)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name        string
+		opts        CommentsOptions
+		want        []string
+		wantReplies []string
+	}{
+		{name: "tree", opts: CommentsOptions{Limit: 1}, want: []string{"one", "two", "three"}, wantReplies: []string{"one.a", "one.b"}},
+		{name: "flat", opts: CommentsOptions{Limit: 2, Flat: true}, want: []string{"one", "one.a", "one.b", "two", "three"}},
+		{name: "replies", opts: CommentsOptions{ParentID: commentIDs[0], Limit: 1}, want: []string{"one.a", "one.b"}},
+		{name: "no_depth", opts: CommentsOptions{MaxDepth: new(int)}, want: []string{"one", "two", "three"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []posts.Comment
+			for comment, err := range c.Comments(ctx, postID, tt.opts) {
+				if err != nil {
+					t.Fatalf("Comments() error = %v", err)
+				}
+				got = append(got, comment)
+			}
+			if !slices.Equal(texts(got), tt.want) {
+				t.Errorf("Comments() = %v, want %v", texts(got), tt.want)
+			}
+			if !slices.Equal(texts(got[0].Replies), tt.wantReplies) {
+				t.Errorf("got replies %v, want %v", texts(got[0].Replies), tt.wantReplies)
+			}
+		})
+	}
+
+	if err := admin.DeleteComment(ctx, postID, commentIDs[1]); err != nil {
+		t.Fatalf("DeleteComment() error = %v", err)
+	}
+	response, err := c.GetComments(ctx, postID, CommentsOptions{MaxDepth: new(int)})
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if got, want := texts(response.Comments), []string{"one", "three"}; !slices.Equal(got, want) {
+		t.Errorf("GetComments() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_CommentRepliesCapped(t *testing.T) {
+	server := testServer(t, nil)
+	admin := testClient(t, server, testAdminToken)
+	c := testClient(t, server, "")
+	ctx := t.Context()
+
+	postID, err := admin.CreatePost(ctx, posts.CreatePostRequest{Title: "Busy", Content: "Talk", Status: posts.StatusPublished})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	commentID, err := c.CreateComment(ctx, postID, "popular")
+	if err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+	replies := posts.MaxCommentReplies + 5
+	for i := range replies {
+		if _, err := c.CreateReply(ctx, postID, commentID, fmt.Sprintf("reply %d", i)); err != nil {
+			t.Fatalf("CreateReply() error = %v", err)
+		}
+	}
+
+	response, err := c.GetComments(ctx, postID, CommentsOptions{})
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	comment := response.Comments[0]
+	if len(comment.Replies) != posts.MaxCommentReplies || comment.ReplyCount != replies {
+		t.Errorf("got %d replies of %d, want %d of %d", len(comment.Replies), comment.ReplyCount, posts.MaxCommentReplies, replies)
+	}
+
+	// Replies left out of the thread are read from the replies of their comment.
+	var got int
+	for _, err := range c.Comments(ctx, postID, CommentsOptions{ParentID: commentID, Limit: 10}) {
+		if err != nil {
+			t.Fatalf("Comments() error = %v", err)
+		}
+		got++
+	}
+	if got != replies {
+		t.Errorf("got %d replies, want %d", got, replies)
+	}
+}
+
+func TestClient_GRPCComments(t *testing.T) {
+	a := testApp(t)
+	server := httptest.NewServer(a.Router)
+	t.Cleanup(server.Close)
+	c := testClient(t, server, testAdminToken)
+	ctx := t.Context()
+
+	postID, err := c.CreatePost(ctx, posts.CreatePostRequest{Title: "Over gRPC", Content: "Talk", Status: posts.StatusPublished})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	commentID, err := c.CreateComment(ctx, postID, "one")
+	if err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+	if _, err := c.CreateReply(ctx, postID, commentID, "one.a"); err != nil {
+		t.Fatalf("CreateReply() error = %v", err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	postsv1.RegisterPostsServiceServer(grpcServer, a.PostsGRPCAdapter)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial gRPC API: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	// Comments are read by slug, and still carry the public ID of their post.
+	response, err := postsv1.NewPostsServiceClient(conn).ListComments(ctx, &postsv1.ListCommentsRequest{PostId: "over-grpc"})
+	if err != nil {
+		t.Fatalf("ListComments() error = %v", err)
+	}
+	if len(response.GetComments()) != 1 || len(response.GetComments()[0].GetReplies()) != 1 {
+		t.Fatalf("ListComments() = %v, want a comment and its reply", response.GetComments())
+	}
+	for _, comment := range []*postsv1.Comment{response.GetComments()[0], response.GetComments()[0].GetReplies()[0]} {
+		if comment.GetPostId() != postID {
+			t.Errorf("got comment %s of post %q, want %q", comment.GetId(), comment.GetPostId(), postID)
+		}
+	}
+}
+
+func TestClient_ExportImport(t *testing.T) {
+	source := testClient(t, testServer(t, nil), testAdminToken)
+	target := testClient(t, testServer(t, nil), testAdminToken)
+	ctx := t.Context()
+
+	postID, err := source.CreatePost(ctx, posts.CreatePostRequest{Title: "Moved", Content: "Around", Status: posts.StatusPublished})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if _, err := source.CreateComment(ctx, postID, "along"); err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	var export bytes.Buffer
+	if err := source.ExportContent(ctx, &export); err != nil {
+		t.Fatalf("ExportContent() error = %v", err)
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		result, err := target.ImportContent(ctx, bytes.NewReader(export.Bytes()), dryRun)
+		if err != nil {
+			t.Fatalf("ImportContent() error = %v", err)
+		}
+		want := posts.ImportResult{DryRun: dryRun, Posts: 1, Comments: 1, Errors: []posts.ImportError{}}
+		if result.DryRun != want.DryRun || result.Posts != want.Posts || result.Comments != want.Comments || len(result.Errors) != 0 {
+			t.Errorf("ImportContent() = %+v, want %+v", result, want)
+		}
+	}
+
+	post, err := target.GetPost(ctx, "moved")
+	if err != nil {
+		t.Fatalf("GetPost() error = %v", err)
+	}
+	if post.Content != "Around" || post.CommentCount != 1 {
+		t.Errorf("got imported post %+v", post)
+	}
+
+	anonymous := testClient(t, testServer(t, nil), "")
+	err = anonymous.ExportContent(ctx, &export)
+	checkAPIError(t, err, httputil.ErrUnauthorized, http.StatusUnauthorized)
+	_, err = anonymous.ImportContent(ctx, &export, true)
+	checkAPIError(t, err, httputil.ErrUnauthorized, http.StatusUnauthorized)
+}
+
+func TestClient_ImportBatches(t *testing.T) {
+	c := testClient(t, testServer(t, nil), testAdminToken)
+	ctx := t.Context()
+
+	// The comments of the first post are stored in the batch after it.
+	var input bytes.Buffer
+	for i := range posts.ImportBatchSize {
+		fmt.Fprintf(&input, `{"type":"post","post":{"id":"p%d","title":"Post %d","content":"C","status":"published"}}`+"\n", i, i)
+	}
+	input.WriteString(`{"type":"comment","comment":{"id":"c1","blog_post_id":"p0","comment_text":"first"}}` + "\n")
+	input.WriteString(`{"type":"comment","comment":{"id":"c2","blog_post_id":"p0","parent_id":"c1","comment_text":"reply"}}` + "\n")
+
+	for _, dryRun := range []bool{true, false} {
+		result, err := c.ImportContent(ctx, bytes.NewReader(input.Bytes()), dryRun)
+		if err != nil {
+			t.Fatalf("ImportContent() error = %v", err)
+		}
+		if result.DryRun != dryRun || result.Posts != posts.ImportBatchSize || result.Comments != 2 || len(result.Errors) != 0 {
+			t.Errorf("ImportContent(dryRun = %t) = %+v", dryRun, result)
+		}
+	}
+
+	post, err := c.GetPost(ctx, "post-0")
+	if err != nil {
+		t.Fatalf("GetPost() error = %v", err)
+	}
+	if post.CommentCount != 2 {
+		t.Errorf("got %d comments, want 2", post.CommentCount)
+	}
+	if _, err := c.GetPost(ctx, "post-0-2"); !errors.Is(err, posts.ErrBlogPostNotFound) {
+		t.Errorf("got error %v, want dry run to store nothing", err)
+	}
+}
+
+func TestClient_Webhooks(t *testing.T) {
+	t.Setenv("COMMENT_MODERATION", string(posts.ModerationPending))
+	t.Setenv("OUTBOX_DISPATCH_INTERVAL", "10ms")
+	t.Setenv("WEBHOOK_DELIVERY_INTERVAL", "10ms")
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_TARGETS", "true")
+	server := testServer(t, nil)
+	admin := testClient(t, server, testAdminToken)
+	ctx := t.Context()
+
+	received := make(chan string, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		received <- r.Header.Get("X-Webhook-Event") + " " + payload.Data.ID
+	}))
+	t.Cleanup(receiver.Close)
+
+	adminPost := func(path, body string) {
+		t.Helper()
+		request, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+testAdminToken)
+		res, err := server.Client().Do(request)
+		if err != nil {
+			t.Fatalf("POST %s error = %v", path, err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode >= 300 {
+			body, _ := io.ReadAll(res.Body)
+			t.Fatalf("POST %s status = %d: %s", path, res.StatusCode, body)
+		}
+	}
+	adminPost("/api/admin/webhooks", `{"url":"`+receiver.URL+`","events":["post.created","post.published","comment.created","comment.approved"]}`)
+
+	draftID, err := admin.CreatePost(ctx, posts.CreatePostRequest{Title: "Draft", Content: "Soon", Status: posts.StatusDraft})
+	if err != nil {
+		t.Fatalf("failed to create draft: %v", err)
+	}
+	publishedID, err := admin.CreatePost(ctx, posts.CreatePostRequest{Title: "Published", Content: "Now", Status: posts.StatusPublished})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if err := admin.PublishPost(ctx, draftID, nil); err != nil {
+		t.Fatalf("failed to publish draft: %v", err)
+	}
+	commentID, err := admin.CreateComment(ctx, publishedID, "Pending")
+	if err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+	for range 2 {
+		adminPost("/api/admin/comments/moderation", `{"comment_ids":["`+commentID+`"],"status":"approved"}`)
+	}
+	archivedCommentID, err := admin.CreateComment(ctx, draftID, "Pending on archived")
+	if err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+	if err := admin.ArchivePost(ctx, draftID); err != nil {
+		t.Fatalf("failed to archive post: %v", err)
+	}
+	adminPost("/api/admin/comments/moderation", `{"comment_ids":["`+archivedCommentID+`"],"status":"approved"}`)
+
+	// Neither the draft nor the pending comments are sent when created, approving twice is sent once
+	// and approving a comment of an archived post is not sent.
+	want := []string{
+		"comment.approved " + commentID,
+		"post.created " + publishedID,
+		"post.published " + draftID,
+	}
+	var got []string
+	timeout := time.After(5 * time.Second)
+	for len(got) < len(want) {
+		select {
+		case event := <-received:
+			got = append(got, event)
+		case <-timeout:
+			t.Fatalf("got events %v, want %v", got, want)
+		}
+	}
+	select {
+	case event := <-received:
+		got = append(got, event)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := testServer(t, nil)
+	c := testClient(t, server, "")
+	ctx := t.Context()
+
+	postID, err := testClient(t, server, testAdminToken).CreatePost(ctx, posts.CreatePostRequest{Title: "Target", Content: "Of errors", Status: posts.StatusPublished})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		call        func() error
+		wantErr     error
+		wantStatus  int
+		wantDetails []string
+	}{
+		{
+			name: "missing_content",
+			call: func() error {
+				_, err := c.CreatePost(ctx, posts.CreatePostRequest{Title: "Empty"})
+				return err
+			},
+			wantErr:    posts.ErrorBadRequest,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing_post",
+			call: func() error {
+				_, err := c.CreateComment(ctx, "missing", "hello")
+				return err
+			},
+			wantErr:    posts.ErrBlogPostNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "missing_comment",
+			call: func() error {
+				_, err := c.CreateReply(ctx, postID, "missing", "hello")
+				return err
+			},
+			wantErr:    posts.ErrCommentNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "rejected_content",
+			call: func() error {
+				_, err := c.CreateComment(ctx, postID, "visit my casino")
+				return err
+			},
+			wantErr:     posts.ErrContentRejected,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantDetails: []string{"contains blocked word \"casino\""},
+		},
+		{
+			name: "unauthorized",
+			call: func() error {
+				return c.DeletePost(ctx, postID)
+			},
+			wantErr:    httputil.ErrUnauthorized,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "unauthorized_publish",
+			call: func() error {
+				_, err := c.CreatePost(ctx, posts.CreatePostRequest{Title: "Sneaky", Content: "Post", Status: posts.StatusPublished})
+				return err
+			},
+			wantErr:    httputil.ErrUnauthorized,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			checkAPIError(t, err, tt.wantErr, tt.wantStatus)
+
+			var apiErr *Error
+			errors.As(err, &apiErr)
+			if !slices.Equal(apiErr.Details, tt.wantDetails) {
+				t.Errorf("got details %q, want %q", apiErr.Details, tt.wantDetails)
+			}
+			if IsTemporary(err) {
+				t.Errorf("got temporary error %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		failStatus   int
+		maxRetries   int
+		call         func(ctx context.Context, c Client) error
+		wantAttempts int32
+		wantStatus   int
+	}{
+		{
+			name:       "get_recovers",
+			failures:   2,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.ListPosts(ctx, ListOptions{})
+				return err
+			},
+			wantAttempts: 3,
+		},
+		{
+			name:       "rate_limited_recovers",
+			failures:   1,
+			failStatus: http.StatusTooManyRequests,
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.GetTags(ctx)
+				return err
+			},
+			wantAttempts: 2,
+		},
+		{
+			name:       "retries_exhausted",
+			failures:   5,
+			failStatus: http.StatusBadGateway,
+			maxRetries: 1,
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.ListPosts(ctx, ListOptions{})
+				return err
+			},
+			wantAttempts: 2,
+			wantStatus:   http.StatusBadGateway,
+		},
+		{
+			name:       "retries_disabled",
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			maxRetries: -1,
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.ListPosts(ctx, ListOptions{})
+				return err
+			},
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:       "post_not_retried",
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.CreatePost(ctx, posts.CreatePostRequest{Title: "Once", Content: "Only"})
+				return err
+			},
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:       "internal_error_not_retried",
+			failures:   1,
+			failStatus: http.StatusInternalServerError,
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.GetPost(ctx, "any")
+				return err
+			},
+			wantAttempts: 1,
+			wantStatus:   http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts, failures atomic.Int32
+			failures.Store(tt.failures)
+			server := testServer(t, func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					attempts.Add(1)
+					if failures.Add(-1) >= 0 {
+						w.Header().Set("Retry-After", "0")
+						w.WriteHeader(tt.failStatus)
+						return
+					}
+					next.ServeHTTP(w, r)
+				})
+			})
+
+			c, err := NewClient(Config{
+				BaseURL:    server.URL,
+				HTTPClient: server.Client(),
+				MaxRetries: tt.maxRetries,
+				Backoff:    time.Millisecond,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			err = tt.call(t.Context(), c)
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if tt.wantStatus != 0 {
+				var apiErr *Error
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+				}
+				if IsTemporary(err) != temporaryStatus(tt.wantStatus) {
+					t.Errorf("IsTemporary() = %v", IsTemporary(err))
+				}
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+
+	t.Run("canceled_while_waiting", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+
+		c, err := NewClient(Config{BaseURL: server.URL, Backoff: time.Hour, MaxBackoff: time.Hour})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := c.ListPosts(ctx, ListOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("network_error_retried", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		c, err := NewClient(Config{BaseURL: server.URL, MaxRetries: 2, Backoff: time.Millisecond})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		var apiErr *Error
+		if _, err := c.GetTags(t.Context()); err == nil || errors.As(err, &apiErr) {
+			t.Errorf("got error %v, want network error", err)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+var (
+	// domainErrors Errors of the API that failed calls can be matched against with errors.Is.
+	domainErrors = []error{
+		posts.ErrBlogPostNotFound,
+		posts.ErrCommentNotFound,
+		posts.ErrRevisionNotFound,
+		posts.ErrorBadRequest,
+		posts.ErrInvalidStatusTransition,
+		posts.ErrParentCommentDeleted,
+		posts.ErrContentRejected,
+		httputil.ErrUnauthorized,
+	}
+)
+
+// Error Failed call, decoded from the problem details returned by the API.
+// It matches the domain error it was caused by, such as posts.ErrBlogPostNotFound, with errors.Is.
+type Error struct {
+	// StatusCode HTTP status of the response.
+	StatusCode int
+	// Message What went wrong.
+	Message string
+	// Cause Error that caused it.
+	Cause string
+	// Details Reasons content was rejected for.
+	Details []string
+
+	// err Domain error Cause matches, nil when it matches none.
+	err error
+}
+
+// Error Returns error message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s (status %d)", e.Message, e.Cause, e.StatusCode)
+}
+
+// Unwrap Returns the domain error the call failed with, if known.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// decodeError Builds the error of a failed response, from its problem details when it has them.
+func decodeError(response *http.Response) error {
+	apiErr := &Error{StatusCode: response.StatusCode}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("unexpected error reading error response with status (%d): %w", response.StatusCode, err)
+	}
+
+	var problem httputil.HTTPErrorResponse
+	if strings.HasPrefix(response.Header.Get("Content-Type"), "application/problem+json") && json.Unmarshal(body, &problem) == nil {
+		apiErr.Message = problem.Message
+		apiErr.Cause = problem.Cause
+		apiErr.Details = problem.Details
+	} else {
+		// Responses of the router itself, such as unknown routes, are plain text.
+		apiErr.Message = strings.TrimSpace(string(body))
+		apiErr.Cause = http.StatusText(response.StatusCode)
+	}
+
+	for _, domainErr := range domainErrors {
+		if apiErr.Cause == domainErr.Error() || strings.HasPrefix(apiErr.Cause, domainErr.Error()+": ") {
+			apiErr.err = domainErr
+			break
+		}
+	}
+	return apiErr
+}
+
+// IsTemporary Reports whether a call failed in a way that may succeed when retried.
+func IsTemporary(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return temporaryStatus(apiErr.StatusCode)
+}
+
+// temporaryStatus Reports whether responses with provided status are worth retrying.
+func temporaryStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+const (
+	// DefaultMaxRetries Retries of failed idempotent calls when none are configured.
+	DefaultMaxRetries = 3
+	// DefaultBackoff Wait before the first retry when none is configured.
+	DefaultBackoff = 200 * time.Millisecond
+	// DefaultMaxBackoff Longest wait between retries when none is configured.
+	DefaultMaxBackoff = 5 * time.Second
+)
+
+// Client Posts API client interface.
+type Client interface {
+	// ListPosts Returns a page of posts.
+	ListPosts(ctx context.Context, opts ListOptions) (*posts.GetAllResponse, error)
+	// Posts Iterates over every post from the page in opts on, reading pages as they are needed.
+	// Iteration stops after the first error.
+	Posts(ctx context.Context, opts ListOptions) iter.Seq2[posts.BlogPost, error]
+	// GetPost Returns single post, found by ID or slug, old slugs included.
+	GetPost(ctx context.Context, id string) (*posts.BlogPost, error)
+	// CreatePost Creates new post and returns its ID.
+	CreatePost(ctx context.Context, request posts.CreatePostRequest) (string, error)
+	// UpdatePost Updates title and content of a post. Admin only.
+	UpdatePost(ctx context.Context, id string, request posts.UpdatePostRequest) error
+	// PublishPost Publishes a post, or schedules it when publishAt is in the future. Admin only.
+	PublishPost(ctx context.Context, id string, publishAt *time.Time) error
+	// ArchivePost Archives a post. Admin only.
+	ArchivePost(ctx context.Context, id string) error
+	// DeletePost Moves a post to trash. Admin only.
+	DeletePost(ctx context.Context, id string) error
+	// GetTags Returns all tags in use with their post counts.
+	GetTags(ctx context.Context) ([]posts.Tag, error)
+	// GetComments Returns a page of the comment thread of a post, or of the replies of one of its comments.
+	GetComments(ctx context.Context, postID string, opts CommentsOptions) (*posts.GetCommentsResponse, error)
+	// Comments Iterates over every comment of a post from the page in opts on, reading pages as they are needed.
+	// Iteration stops after the first error.
+	Comments(ctx context.Context, postID string, opts CommentsOptions) iter.Seq2[posts.Comment, error]
+	// CreateComment Creates new comment for a post and returns its ID.
+	CreateComment(ctx context.Context, postID, text string) (string, error)
+	// CreateReply Creates new reply to a comment of a post and returns its ID.
+	CreateReply(ctx context.Context, postID, parentCommentID, text string) (string, error)
+	// DeleteComment Moves a comment and its replies to trash. Admin only.
+	DeleteComment(ctx context.Context, postID, commentID string) error
+	// ExportContent Writes every post followed by its comments to w as NDJSON. Admin only.
+	// Export is streamed as it is read, so w may hold part of it when it fails.
+	ExportContent(ctx context.Context, w io.Writer) error
+	// ImportContent Imports posts and comments from NDJSON read from r, in the format ExportContent writes.
+	// Nothing is stored on dry runs. Admin only.
+	ImportContent(ctx context.Context, r io.Reader, dryRun bool) (*posts.ImportResult, error)
+}
+
+// Config Client configuration.
+type Config struct {
+	// BaseURL Absolute URL the API is reachable at.
+	BaseURL string
+	// AdminToken Bearer token sent with every request, needed by admin calls. Calls are anonymous when empty.
+	AdminToken string
+	// HTTPClient Client requests are sent with, http.DefaultClient when nil.
+	HTTPClient *http.Client
+	// MaxRetries Retries of idempotent calls failing with network errors or temporary statuses,
+	// DefaultMaxRetries when zero and none when negative.
+	MaxRetries int
+	// Backoff Wait before the first retry, doubled on every retry. DefaultBackoff when zero.
+	Backoff time.Duration
+	// MaxBackoff Longest wait between retries. DefaultMaxBackoff when zero.
+	MaxBackoff time.Duration
+}
+
+// ListOptions Options used when listing posts.
+type ListOptions struct {
+	// Page Page to read, the first one when zero.
+	Page int
+	// Limit Posts per page, the API default when zero.
+	Limit int
+	// Tags Only returns posts tagged with any of these slugs.
+	Tags []string
+	// MatchAllTags Requires posts to be tagged with every slug in Tags.
+	MatchAllTags bool
+	// Filter Only returns posts matching every criteria set.
+	Filter posts.ListFilter
+	// IncludeComments Embeds the comments of every post.
+	IncludeComments bool
+	// CommentsLimit Only embeds the latest comments of every post up to this amount, every comment when zero.
+	CommentsLimit int
+}
+
+// CommentsOptions Options used when reading comments.
+type CommentsOptions struct {
+	// ParentID Comment whose replies are read, empty for top level comments.
+	ParentID string
+	// Page Page to read, the first one when zero.
+	Page int
+	// Limit Comments per page, the API default when zero.
+	Limit int
+	// MaxDepth Levels of replies read below the paginated comments, the API default when nil.
+	MaxDepth *int
+	// Flat Returns comments as a flat list ordered depth first instead of nesting replies.
+	Flat bool
+}
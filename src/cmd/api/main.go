@@ -1,8 +1,21 @@
 package main
 
-import "github.com/MatiasKopp/prosig-code-challenge/internal/app"
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/MatiasKopp/prosig-code-challenge/internal/app"
+)
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	api := app.New()
-	api.Start()
+	if err := api.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
 }
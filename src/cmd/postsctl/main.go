@@ -0,0 +1,16 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/MatiasKopp/prosig-code-challenge/internal/postsctl"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := postsctl.Run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,252 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// AtomContentType Content type of Atom feeds.
+	AtomContentType = "application/atom+xml; charset=utf-8"
+	// RSSContentType Content type of RSS feeds.
+	RSSContentType = "application/rss+xml; charset=utf-8"
+)
+
+var (
+	errMapper = map[error]int{
+		posts.ErrBlogPostNotFound: http.StatusNotFound,
+		posts.ErrorBadRequest:     http.StatusBadRequest,
+	}
+
+	// filterParams Query params of feeds other than posts.ListFilter ones.
+	filterParams = []string{"tag", "tag_match"}
+)
+
+// httpAdapter Productive feeds http adapter implementation
+type httpAdapter struct {
+	Service posts.Service
+	Config  Config
+}
+
+// NewHTTPAdapter Returns new productive feeds HTTP adapter implementation.
+func NewHTTPAdapter(service posts.Service, cfg Config) (HTTPAdapter, error) {
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil || !baseURL.IsAbs() {
+		return nil, fmt.Errorf("invalid base URL (%s)", cfg.BaseURL)
+	}
+	if cfg.Size < 1 {
+		return nil, fmt.Errorf("invalid feed size (%d)", cfg.Size)
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+
+	return &httpAdapter{
+		Service: service,
+		Config:  cfg,
+	}, nil
+}
+
+// GetPostsAtom Returns the latest published posts as an Atom feed.
+// Posts can be narrowed by the same `tag`, `tag_match` and filter params as the posts listing.
+func (a *httpAdapter) GetPostsAtom(w http.ResponseWriter, r *http.Request) {
+	blogPosts, ok := a.readPosts(w, r)
+	if !ok {
+		return
+	}
+
+	feed := atomFeed{
+		ID:     a.Config.BaseURL + r.URL.RequestURI(),
+		Title:  a.Config.Title,
+		Author: &atomPerson{Name: a.Config.Title},
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: a.Config.BaseURL + r.URL.RequestURI()},
+			{Rel: "alternate", Type: "application/json", Href: a.Config.BaseURL + "/api/posts"},
+		},
+		Entries: []atomEntry{},
+	}
+
+	var updated time.Time
+	for _, post := range blogPosts {
+		postUpdated := postUpdated(post)
+		updated = latest(updated, postUpdated)
+
+		entry := atomEntry{
+			ID:      "urn:uuid:" + post.ID,
+			Title:   post.Title,
+			Updated: postUpdated.Format(time.RFC3339),
+			Links:   []atomLink{{Rel: "alternate", Type: "application/json", Href: a.postURL(post)}},
+			Summary: &atomText{Type: "text", Body: post.Excerpt},
+			Content: &atomText{Type: "html", Body: post.ContentHTML},
+		}
+		if post.PublishAt != nil {
+			entry.Published = post.PublishAt.UTC().Format(time.RFC3339)
+		}
+		if post.Author != "" {
+			entry.Author = &atomPerson{Name: post.Author}
+		}
+		for _, tag := range post.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	// Atom requires feeds to be dated, so empty ones are dated when served, without a Last-Modified header.
+	feed.Updated = updated.Format(time.RFC3339)
+	if updated.IsZero() {
+		feed.Updated = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	a.serveFeed(w, r, AtomContentType, updated, feed)
+}
+
+// GetPostsRSS Returns the latest published posts as an RSS feed.
+// Posts can be narrowed by the same `tag`, `tag_match` and filter params as the posts listing.
+func (a *httpAdapter) GetPostsRSS(w http.ResponseWriter, r *http.Request) {
+	blogPosts, ok := a.readPosts(w, r)
+	if !ok {
+		return
+	}
+
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       a.Config.Title,
+			Link:        a.Config.BaseURL + "/api/posts",
+			Description: "Latest posts of " + a.Config.Title,
+		},
+	}
+
+	var updated time.Time
+	for _, post := range blogPosts {
+		updated = latest(updated, postUpdated(post))
+
+		item := rssItem{
+			Title:       post.Title,
+			Link:        a.postURL(post),
+			GUID:        rssGUID{Value: "urn:uuid:" + post.ID},
+			Categories:  post.Tags,
+			Description: post.ContentHTML,
+		}
+		if post.PublishAt != nil {
+			item.PubDate = post.PublishAt.UTC().Format(time.RFC1123Z)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+	if !updated.IsZero() {
+		feed.Channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+
+	a.serveFeed(w, r, RSSContentType, updated, feed)
+}
+
+// GetCommentsAtom Returns the latest approved comments of specific published post as an Atom feed.
+func (a *httpAdapter) GetCommentsAtom(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	post, err := a.Service.GetBlogPost(id, posts.ReadOptions{IncludeComments: true, CommentsLimit: a.Config.Size})
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error getting post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	feed := atomFeed{
+		ID:    a.Config.BaseURL + r.URL.Path,
+		Title: "Comments on " + post.Title,
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: a.Config.BaseURL + r.URL.RequestURI()},
+			{Rel: "alternate", Type: "application/json", Href: a.postURL(*post) + "/comments"},
+		},
+		Entries: []atomEntry{},
+	}
+	if post.Author != "" {
+		feed.Author = &atomPerson{Name: post.Author}
+	} else {
+		feed.Author = &atomPerson{Name: a.Config.Title}
+	}
+
+	// Comments are read oldest first, feeds list the newest first.
+	updated := postUpdated(*post)
+	for _, comment := range slices.Backward(post.Comments) {
+		updated = latest(updated, comment.CreatedAt)
+
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:        "urn:uuid:" + comment.ID,
+			Title:     "Comment on " + post.Title,
+			Updated:   comment.CreatedAt.UTC().Format(time.RFC3339),
+			Published: comment.CreatedAt.UTC().Format(time.RFC3339),
+			Links:     []atomLink{{Rel: "alternate", Type: "application/json", Href: a.postURL(*post) + "/comments"}},
+			Content:   &atomText{Type: "text", Body: comment.CommentText},
+		})
+	}
+	feed.Updated = updated.Format(time.RFC3339)
+
+	a.serveFeed(w, r, AtomContentType, updated, feed)
+}
+
+// readPosts Reads the latest published posts matching the request filters, responding the error when it fails.
+func (a *httpAdapter) readPosts(w http.ResponseWriter, r *http.Request) ([]posts.BlogPost, bool) {
+	var filter posts.ListFilter
+	if err := httputil.GetFilterParams(r, &filter, filterParams...); err != nil {
+		httputil.HandlerHTTPError(w, err.Error(), posts.ErrorBadRequest, errMapper)
+		return nil, false
+	}
+
+	tagMatch := r.URL.Query().Get("tag_match")
+	if tagMatch != "" && tagMatch != "any" && tagMatch != "all" {
+		httputil.HandlerHTTPError(w, "tag_match must be either any or all", posts.ErrorBadRequest, errMapper)
+		return nil, false
+	}
+
+	blogPosts, err := a.Service.GetAllBlogPosts(a.Config.Size, 0, posts.ReadOptions{
+		Tags:         r.URL.Query()["tag"],
+		MatchAllTags: tagMatch == "all",
+		Filter:       filter,
+		NewestFirst:  true,
+	})
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error getting all blog posts", err, errMapper)
+		return nil, false
+	}
+	return blogPosts, true
+}
+
+// serveFeed Responds feed encoded as XML, answering conditional requests.
+func (a *httpAdapter) serveFeed(w http.ResponseWriter, r *http.Request, contentType string, updated time.Time, feed any) {
+	body, err := xml.Marshal(feed)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error encoding feed", err, errMapper)
+		return
+	}
+
+	httputil.ServeCacheable(w, r, contentType, updated, append([]byte(xml.Header), body...))
+}
+
+// postURL Returns the absolute URL of a blog post.
+func (a *httpAdapter) postURL(post posts.BlogPost) string {
+	return a.Config.BaseURL + "/api/posts/" + url.PathEscape(post.Slug)
+}
+
+// postUpdated Returns when a blog post was last changed, either written or published.
+func postUpdated(post posts.BlogPost) time.Time {
+	updated := latest(post.CreatedAt, post.UpdatedAt)
+	if post.PublishAt != nil {
+		updated = latest(updated, *post.PublishAt)
+	}
+	return updated.UTC()
+}
+
+// latest Returns the latest of two times.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,274 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testConfig = Config{BaseURL: "https://blog.example.com/", Title: "Blog", Size: 20}
+
+	created   = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	published = time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	updated   = time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC)
+
+	testPost = posts.BlogPost{
+		ID:          "0192e4a8-0000-7000-8000-000000000001",
+		Slug:        "first-post",
+		Title:       "First <Post>",
+		Author:      "ada",
+		ContentHTML: "<p>Body</p>",
+		Excerpt:     "Body",
+		Status:      posts.StatusPublished,
+		PublishAt:   &published,
+		CreatedAt:   created,
+		UpdatedAt:   updated,
+		Tags:        []string{"go"},
+	}
+)
+
+// newTestAdapter Returns an adapter serving feeds from a mocked service.
+func newTestAdapter(t *testing.T, setup func(m *posts.MocksService)) HTTPAdapter {
+	service := posts.NewMocksService(t)
+	setup(service)
+
+	adapter, err := NewHTTPAdapter(service, testConfig)
+	if err != nil {
+		t.Fatalf("NewHTTPAdapter() error = %v", err)
+	}
+	return adapter
+}
+
+func TestNewHTTPAdapter(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "valid", cfg: testConfig},
+		{name: "relative_base_url", cfg: Config{BaseURL: "/blog", Size: 20}, wantErr: true},
+		{name: "invalid_size", cfg: Config{BaseURL: "https://blog.example.com", Size: 0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHTTPAdapter(posts.NewMocksService(t), tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHTTPAdapter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetPostsAtom(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(m *posts.MocksService)
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200",
+			setup: func(m *posts.MocksService) {
+				m.EXPECT().GetAllBlogPosts(20, 0, posts.ReadOptions{
+					Tags:        []string{"go"},
+					Filter:      posts.ListFilter{Author: "ada"},
+					NewestFirst: true,
+				}).Return([]posts.BlogPost{testPost}, nil)
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/feeds/posts.atom?tag=go&author=ada", nil),
+			wantStatus: http.StatusOK,
+			wantBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<feed xmlns="http://www.w3.org/2005/Atom"><id>https://blog.example.com/feeds/posts.atom?tag=go&amp;author=ada</id>` +
+				`<title>Blog</title><updated>2026-01-03T10:00:00Z</updated><author><name>Blog</name></author>` +
+				`<link rel="self" type="application/atom+xml" href="https://blog.example.com/feeds/posts.atom?tag=go&amp;author=ada"></link>` +
+				`<link rel="alternate" type="application/json" href="https://blog.example.com/api/posts"></link>` +
+				`<entry><id>urn:uuid:0192e4a8-0000-7000-8000-000000000001</id><title>First &lt;Post&gt;</title>` +
+				`<updated>2026-01-03T10:00:00Z</updated><published>2026-01-02T10:00:00Z</published><author><name>ada</name></author>` +
+				`<link rel="alternate" type="application/json" href="https://blog.example.com/api/posts/first-post"></link>` +
+				`<category term="go"></category><summary type="text">Body</summary><content type="html">&lt;p&gt;Body&lt;/p&gt;</content></entry></feed>`,
+		},
+		{
+			name:       "unknown_filter_400",
+			setup:      func(m *posts.MocksService) {},
+			request:    httptest.NewRequest(http.MethodGet, "/feeds/posts.atom?page=2", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"unknown filter (page)\",\"cause\":\"bad request\"}",
+		},
+		{
+			name:       "invalid_tag_match_400",
+			setup:      func(m *posts.MocksService) {},
+			request:    httptest.NewRequest(http.MethodGet, "/feeds/posts.atom?tag_match=some", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"tag_match must be either any or all\",\"cause\":\"bad request\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAdapter(t, tt.setup)
+			recorder := httptest.NewRecorder()
+			a.GetPostsAtom(recorder, tt.request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if body := recorder.Body.String(); body != tt.wantBody {
+				t.Errorf("got body %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetPostsAtom_empty(t *testing.T) {
+	a := newTestAdapter(t, func(m *posts.MocksService) {
+		m.EXPECT().GetAllBlogPosts(20, 0, posts.ReadOptions{NewestFirst: true}).Return([]posts.BlogPost{}, nil)
+	})
+
+	before := time.Now().Truncate(time.Second)
+	recorder := httptest.NewRecorder()
+	a.GetPostsAtom(recorder, httptest.NewRequest(http.MethodGet, "/feeds/posts.atom", nil))
+
+	var feed atomFeed
+	if err := xml.Unmarshal(recorder.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	if updated, err := time.Parse(time.RFC3339, feed.Updated); err != nil || updated.Before(before) {
+		t.Errorf("got updated %q, want the time it was served", feed.Updated)
+	}
+	if lastModified := recorder.Header().Get("Last-Modified"); lastModified != "" {
+		t.Errorf("got Last-Modified %q, want none", lastModified)
+	}
+}
+
+func Test_httpAdapter_GetPostsRSS(t *testing.T) {
+	a := newTestAdapter(t, func(m *posts.MocksService) {
+		m.EXPECT().GetAllBlogPosts(20, 0, posts.ReadOptions{NewestFirst: true}).Return([]posts.BlogPost{testPost}, nil)
+	})
+
+	recorder := httptest.NewRecorder()
+	a.GetPostsRSS(recorder, httptest.NewRequest(http.MethodGet, "/feeds/posts.rss", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != RSSContentType {
+		t.Errorf("got content type %q, want %q", contentType, RSSContentType)
+	}
+	wantBody := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<rss version="2.0"><channel><title>Blog</title><link>https://blog.example.com/api/posts</link>` +
+		`<description>Latest posts of Blog</description><lastBuildDate>Sat, 03 Jan 2026 10:00:00 +0000</lastBuildDate>` +
+		`<item><title>First &lt;Post&gt;</title><link>https://blog.example.com/api/posts/first-post</link>` +
+		`<guid isPermaLink="false">urn:uuid:0192e4a8-0000-7000-8000-000000000001</guid><pubDate>Fri, 02 Jan 2026 10:00:00 +0000</pubDate>` +
+		`<category>go</category><description>&lt;p&gt;Body&lt;/p&gt;</description></item></channel></rss>`
+	if body := recorder.Body.String(); body != wantBody {
+		t.Errorf("got body %q, want %q", body, wantBody)
+	}
+}
+
+func Test_httpAdapter_conditionalGet(t *testing.T) {
+	a := newTestAdapter(t, func(m *posts.MocksService) {
+		m.EXPECT().GetAllBlogPosts(20, 0, posts.ReadOptions{NewestFirst: true}).Return([]posts.BlogPost{testPost}, nil)
+	})
+
+	recorder := httptest.NewRecorder()
+	a.GetPostsRSS(recorder, httptest.NewRequest(http.MethodGet, "/feeds/posts.rss", nil))
+
+	etag := recorder.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+	if lastModified := recorder.Header().Get("Last-Modified"); lastModified != "Sat, 03 Jan 2026 10:00:00 GMT" {
+		t.Errorf("got Last-Modified %q, want %q", lastModified, "Sat, 03 Jan 2026 10:00:00 GMT")
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		wantStatus int
+	}{
+		{name: "etag_matches_304", header: "If-None-Match", value: etag, wantStatus: http.StatusNotModified},
+		{name: "etag_differs_200", header: "If-None-Match", value: `"stale"`, wantStatus: http.StatusOK},
+		{name: "not_modified_since_304", header: "If-Modified-Since", value: "Sat, 03 Jan 2026 10:00:00 GMT", wantStatus: http.StatusNotModified},
+		{name: "modified_since_200", header: "If-Modified-Since", value: "Fri, 02 Jan 2026 10:00:00 GMT", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/feeds/posts.rss", nil)
+			request.Header.Set(tt.header, tt.value)
+
+			recorder := httptest.NewRecorder()
+			a.GetPostsRSS(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetCommentsAtom(t *testing.T) {
+	commented := time.Date(2026, 1, 4, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		setup      func(m *posts.MocksService)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200_newest_first",
+			setup: func(m *posts.MocksService) {
+				post := testPost
+				post.Comments = []posts.Comment{
+					{ID: "c1", CommentText: "First", CreatedAt: created},
+					{ID: "c2", CommentText: "Second", CreatedAt: commented},
+				}
+				m.EXPECT().GetBlogPost("first-post", posts.ReadOptions{IncludeComments: true, CommentsLimit: 20}).Return(&post, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<feed xmlns="http://www.w3.org/2005/Atom"><id>https://blog.example.com/feeds/posts/first-post/comments.atom</id>` +
+				`<title>Comments on First &lt;Post&gt;</title><updated>2026-01-04T10:00:00Z</updated><author><name>ada</name></author>` +
+				`<link rel="self" type="application/atom+xml" href="https://blog.example.com/feeds/posts/first-post/comments.atom"></link>` +
+				`<link rel="alternate" type="application/json" href="https://blog.example.com/api/posts/first-post/comments"></link>` +
+				`<entry><id>urn:uuid:c2</id><title>Comment on First &lt;Post&gt;</title><updated>2026-01-04T10:00:00Z</updated>` +
+				`<published>2026-01-04T10:00:00Z</published><link rel="alternate" type="application/json" href="https://blog.example.com/api/posts/first-post/comments"></link>` +
+				`<content type="text">Second</content></entry>` +
+				`<entry><id>urn:uuid:c1</id><title>Comment on First &lt;Post&gt;</title><updated>2026-01-01T10:00:00Z</updated>` +
+				`<published>2026-01-01T10:00:00Z</published><link rel="alternate" type="application/json" href="https://blog.example.com/api/posts/first-post/comments"></link>` +
+				`<content type="text">First</content></entry></feed>`,
+		},
+		{
+			name: "not_found_404",
+			setup: func(m *posts.MocksService) {
+				m.EXPECT().GetBlogPost("first-post", mock.Anything).Return(nil, posts.ErrBlogPostNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error getting post with ID (first-post)\",\"cause\":\"blog post not found\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAdapter(t, tt.setup)
+			router := chi.NewRouter()
+			router.Get("/feeds/posts/{id}/comments.atom", a.GetCommentsAtom)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/feeds/posts/first-post/comments.atom", nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if body := recorder.Body.String(); body != tt.wantBody {
+				t.Errorf("got body %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package feeds
+
+import "net/http"
+
+// HTTPAdapter Feeds http adapter interface.
+type HTTPAdapter interface {
+	// GetPostsAtom Returns the latest published posts as an Atom feed.
+	GetPostsAtom(http.ResponseWriter, *http.Request)
+	// GetPostsRSS Returns the latest published posts as an RSS feed.
+	GetPostsRSS(http.ResponseWriter, *http.Request)
+	// GetCommentsAtom Returns the latest comments of specific post as an Atom feed.
+	GetCommentsAtom(http.ResponseWriter, *http.Request)
+}
+
+// Config Feeds configuration.
+type Config struct {
+	// BaseURL Absolute URL the API is reachable at, feed links are built from it.
+	BaseURL string
+	// Title Title of the posts feeds.
+	Title string
+	// Size Maximum entries per feed.
+	Size int
+}
@@ -0,0 +1,141 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+var (
+	errMapper = map[error]int{
+		posts.ErrBlogPostNotFound:        http.StatusNotFound,
+		posts.ErrCommentNotFound:         http.StatusNotFound,
+		posts.ErrorBadRequest:            http.StatusBadRequest,
+		posts.ErrInvalidStatusTransition: http.StatusConflict,
+		posts.ErrParentCommentDeleted:    http.StatusConflict,
+		posts.ErrContentRejected:         http.StatusUnprocessableEntity,
+		httputil.ErrUnauthorized:         http.StatusUnauthorized,
+	}
+)
+
+// httpAdapter Productive GraphQL http adapter implementation
+type httpAdapter struct {
+	Service posts.Service
+	Schema  graphql.Schema
+	Config  Config
+}
+
+// NewHTTPAdapter Returns new productive GraphQL HTTP adapter implementation.
+func NewHTTPAdapter(service posts.Service, cfg Config) (HTTPAdapter, error) {
+	if cfg.MaxDepth < 1 || cfg.MaxComplexity < 1 {
+		return nil, fmt.Errorf("invalid max depth (%d) or complexity (%d)", cfg.MaxDepth, cfg.MaxComplexity)
+	}
+
+	schema, err := newSchema(service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build schema: %w", err)
+	}
+
+	return &httpAdapter{
+		Service: service,
+		Schema:  schema,
+		Config:  cfg,
+	}, nil
+}
+
+// Query Executes a GraphQL operation, sent either as a JSON body or, for queries only, as GET params.
+// Operations that cannot be parsed, are invalid or exceed the depth or complexity limits are rejected with 400
+// before anything is resolved. Otherwise the result is returned with 200, along with any field errors.
+func (a *httpAdapter) Query(w http.ResponseWriter, r *http.Request) {
+	var request Request
+	if r.Method == http.MethodGet {
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				httputil.HandlerHTTPError(w, "unexpected error reading variables", posts.ErrorBadRequest, errMapper)
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error reading GraphQL request body", posts.ErrorBadRequest, errMapper)
+		return
+	}
+
+	if request.Query == "" {
+		httputil.HandlerHTTPError(w, "missing query", posts.ErrorBadRequest, errMapper)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(request.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		httputil.HandlerHTTPResponse(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	validation := graphql.ValidateDocument(&a.Schema, doc, nil)
+	if !validation.IsValid {
+		httputil.HandlerHTTPResponse(w, http.StatusBadRequest, &graphql.Result{Errors: validation.Errors})
+		return
+	}
+
+	if r.Method == http.MethodGet && isMutation(doc, request.OperationName) {
+		w.Header().Set("Allow", http.MethodPost)
+		httputil.HandlerHTTPError(w, "mutations must be sent with POST", posts.ErrorBadRequest, map[error]int{
+			posts.ErrorBadRequest: http.StatusMethodNotAllowed,
+		})
+		return
+	}
+
+	depth, complexity := measure(doc, request.OperationName, request.Variables)
+	var limitErrs []error
+	if depth > a.Config.MaxDepth {
+		limitErrs = append(limitErrs, fmt.Errorf("query depth %d exceeds the limit of %d", depth, a.Config.MaxDepth))
+	}
+	if complexity > a.Config.MaxComplexity {
+		limitErrs = append(limitErrs, fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, a.Config.MaxComplexity))
+	}
+	if len(limitErrs) > 0 {
+		httputil.HandlerHTTPResponse(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(limitErrs...)})
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), adminContextKey{}, httputil.IsAdmin(r))
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        a.Schema,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       withLoaders(ctx, a.Service),
+	})
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, result)
+}
+
+// isMutation Reports whether the operation of doc named operationName is a mutation.
+func isMutation(doc *ast.Document, operationName string) bool {
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || operation.Name != nil && operation.Name.Value == operationName {
+			if operation.Operation == ast.OperationTypeMutation {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package gql
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// testConfig Limits used by tests.
+var testConfig = Config{MaxDepth: 10, MaxComplexity: 200}
+
+func TestNewHTTPAdapter(t *testing.T) {
+	if _, err := NewHTTPAdapter(posts.NewMocksService(t), testConfig); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewHTTPAdapter(posts.NewMocksService(t), Config{MaxDepth: 1}); err == nil {
+		t.Error("expected error for zero max complexity")
+	}
+}
+
+func Test_httpAdapter_Query(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		setup      func(s *posts.MocksService)
+		admin      bool
+		method     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "posts_with_batched_comments_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetAllBlogPosts(3, 0, posts.ReadOptions{}).
+					Return([]posts.BlogPost{{ID: "p1", Title: "one", CreatedAt: createdAt}, {ID: "p2", Title: "two"}}, nil)
+				// Comments of every post, and replies of every comment, are read with a single call each.
+				s.EXPECT().GetCommentsByBlogPosts([]string{"p1", "p2"}, posts.CommentsQuery{Limit: 3}).
+					Return(map[string][]posts.Comment{
+						"p1": {{ID: "c1", ReplyCount: 1}, {ID: "c2"}, {ID: "c5"}},
+						"p2": {{ID: "c3"}},
+					}, nil).Once()
+				s.EXPECT().GetRepliesByComments([]string{"c1", "c2", "c3"}, posts.CommentsQuery{Limit: 11}).
+					Return(map[string][]posts.Comment{"c1": {{ID: "c4", ParentID: "c1"}}}, nil).Once()
+			},
+			body:       `{"query":"{ posts(first: 2) { edges { node { id createdAt comments(first: 2) { edges { cursor node { id replies { edges { node { parentId } } } } } pageInfo { hasNextPage endCursor } } } } pageInfo { hasNextPage } } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"data":{"posts":{"edges":[` +
+				`{"node":{"comments":{"edges":[{"cursor":"b2Zmc2V0OjA","node":{"id":"c1","replies":{"edges":[{"node":{"parentId":"c1"}}]}}},{"cursor":"b2Zmc2V0OjE","node":{"id":"c2","replies":{"edges":[]}}}],"pageInfo":{"endCursor":"b2Zmc2V0OjE","hasNextPage":true}},"createdAt":"2026-01-02T03:04:05Z","id":"p1"}},` +
+				`{"node":{"comments":{"edges":[{"cursor":"b2Zmc2V0OjA","node":{"id":"c3","replies":{"edges":[]}}}],"pageInfo":{"endCursor":"b2Zmc2V0OjA","hasNextPage":false}},"createdAt":null,"id":"p2"}}` +
+				`],"pageInfo":{"hasNextPage":false}}}}`,
+		},
+		{
+			name: "admin_reads_unpublished_page_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetAllBlogPosts(2, 3, posts.ReadOptions{IncludeUnpublished: true, IncludeUnapproved: true, Tags: []string{"go"}}).
+					Return([]posts.BlogPost{{ID: "p4", Status: posts.StatusDraft}}, nil)
+			},
+			admin:      true,
+			body:       `{"query":"query($after: String) { posts(first: 1, after: $after, tags: [\"go\"]) { edges { node { id status tags } } } }","variables":{"after":"b2Zmc2V0OjI"}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"posts":{"edges":[{"node":{"id":"p4","status":"DRAFT","tags":[]}}]}}}`,
+		},
+		{
+			name: "unknown_post_null_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("nope", posts.ReadOptions{}).Return(nil, posts.ErrBlogPostNotFound)
+			},
+			body:       `{"query":"{ post(id: \"nope\") { id } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"post":null}}`,
+		},
+		{
+			name:       "invalid_cursor_200",
+			body:       `{"query":"{ posts(after: \"zzz\") { edges { cursor } } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":null,"errors":[{"message":"bad request: invalid cursor (zzz)","locations":[{"line":1,"column":3}],"path":["posts"],"extensions":{"status":400}}]}`,
+		},
+		{
+			name: "create_reply_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().CreateReply("p1", "c1", "hi").Return("c2", nil)
+			},
+			body:       `{"query":"mutation { createComment(postId: \"p1\", parentId: \"c1\", text: \"hi\") }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"createComment":"c2"}}`,
+		},
+		{
+			name: "rejected_comment_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().CreateComment("p1", "casino").Return("", posts.ErrContentRejected)
+			},
+			body:       `{"query":"mutation { createComment(postId: \"p1\", text: \"casino\") }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":null,"errors":[{"message":"content rejected","locations":[{"line":1,"column":12}],"path":["createComment"],"extensions":{"status":422}}]}`,
+		},
+		{
+			name: "create_post_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().CreateBlogPost(posts.CreatePostRequest{Title: "t", Content: "c", Status: posts.StatusDraft, Tags: []string{"go"}}).Return("p9", nil)
+				s.EXPECT().GetBlogPost("p9", posts.ReadOptions{IncludeUnpublished: true}).Return(&posts.BlogPost{ID: "p9", Status: posts.StatusDraft}, nil)
+			},
+			body:       `{"query":"mutation { createPost(input: {title: \"t\", content: \"c\", status: DRAFT, tags: [\"go\"]}) { id status } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"createPost":{"id":"p9","status":"DRAFT"}}}`,
+		},
+		{
+			name:       "create_published_post_anonymous_200",
+			body:       `{"query":"mutation { createPost(input: {title: \"t\", content: \"c\", status: PUBLISHED}) { id } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":null,"errors":[{"message":"unauthorized: admin credentials required to publish or schedule posts","locations":[{"line":1,"column":12}],"path":["createPost"],"extensions":{"status":401}}]}`,
+		},
+		{
+			name:       "create_scheduled_post_anonymous_200",
+			body:       `{"query":"mutation { createPost(input: {title: \"t\", content: \"c\", status: SCHEDULED, publishAt: \"2030-01-01T00:00:00Z\"}) { id } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":null,"errors":[{"message":"unauthorized: admin credentials required to publish or schedule posts","locations":[{"line":1,"column":12}],"path":["createPost"],"extensions":{"status":401}}]}`,
+		},
+		{
+			name:       "create_post_publish_at_anonymous_200",
+			body:       `{"query":"mutation { createPost(input: {title: \"t\", content: \"c\", publishAt: \"2000-01-01T00:00:00Z\"}) { id } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":null,"errors":[{"message":"unauthorized: admin credentials required to publish or schedule posts","locations":[{"line":1,"column":12}],"path":["createPost"],"extensions":{"status":401}}]}`,
+		},
+		{
+			name: "create_published_post_admin_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().CreateBlogPost(posts.CreatePostRequest{Title: "t", Content: "c", Status: posts.StatusPublished}).Return("p9", nil)
+				s.EXPECT().GetBlogPost("p9", posts.ReadOptions{IncludeUnpublished: true}).Return(&posts.BlogPost{ID: "p9", Status: posts.StatusPublished}, nil)
+			},
+			admin:      true,
+			body:       `{"query":"mutation { createPost(input: {title: \"t\", content: \"c\", status: PUBLISHED}) { status } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"createPost":{"status":"PUBLISHED"}}}`,
+		},
+		{
+			name: "archive_post_admin_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().ArchiveBlogPost("p1").Return(nil)
+				s.EXPECT().GetBlogPost("p1", posts.ReadOptions{IncludeUnpublished: true}).Return(&posts.BlogPost{ID: "p1", Status: posts.StatusArchived}, nil)
+			},
+			admin:      true,
+			body:       `{"query":"mutation { archivePost(id: \"p1\") { status } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"archivePost":{"status":"ARCHIVED"}}}`,
+		},
+		{
+			name:       "archive_post_anonymous_200",
+			body:       `{"query":"mutation { archivePost(id: \"p1\") { status } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":null,"errors":[{"message":"unauthorized","locations":[{"line":1,"column":12}],"path":["archivePost"],"extensions":{"status":401}}]}`,
+		},
+		{
+			name:       "too_deep_400",
+			body:       `{"query":"{ post(id: \"p1\") { comments { edges { node { replies { edges { node { replies { edges { node { id } } } } } } } } } } }"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"data":null,"errors":[{"message":"query depth 11 exceeds the limit of 10","locations":[]},{"message":"query complexity 3332 exceeds the limit of 200","locations":[]}]}`,
+		},
+		{
+			name:       "too_complex_400",
+			body:       `{"query":"{ posts(first: 100) { edges { node { id } } } }"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"data":null,"errors":[{"message":"query complexity 301 exceeds the limit of 200","locations":[]}]}`,
+		},
+		{
+			name:       "invalid_query_400",
+			body:       `{"query":"{ nope }"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"data":null,"errors":[{"message":"Cannot query field \"nope\" on type \"Query\".","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:       "missing_query_400",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"missing query","cause":"bad request"}`,
+		},
+		{
+			name: "get_query_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("p1", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1", Author: "ana"}, nil)
+			},
+			method:     http.MethodGet,
+			body:       `query($id: ID!) { post(id: $id) { author } }`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"post":{"author":"ana"}}}`,
+		},
+		{
+			name:       "get_mutation_405",
+			method:     http.MethodGet,
+			body:       `mutation { deletePost(id: "p1") }`,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   `{"message":"mutations must be sent with POST","cause":"bad request"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := posts.NewMocksService(t)
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+			adapter, err := NewHTTPAdapter(service, testConfig)
+			if err != nil {
+				t.Fatalf("failed to create adapter: %v", err)
+			}
+
+			var request *http.Request
+			if tt.method == http.MethodGet {
+				params := url.Values{"query": {tt.body}, "variables": {`{"id":"p1"}`}}
+				request = httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+			} else {
+				request = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+			}
+			if tt.admin {
+				request.Header.Set("Authorization", "Bearer tok")
+			}
+			recorder := httptest.NewRecorder()
+			httputil.Authenticate("tok")(http.HandlerFunc(adapter.Query)).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package gql
+
+import (
+	"net/http"
+)
+
+const (
+	// DefaultPageSize Nodes returned by connections when `first` is not provided.
+	DefaultPageSize = 10
+	// MaxPageSize Most nodes a single connection can return.
+	MaxPageSize = 100
+)
+
+// HTTPAdapter GraphQL HTTP adapter interface.
+type HTTPAdapter interface {
+	// Query Executes a GraphQL operation, sent either as a JSON body or, for queries only, as GET params.
+	Query(http.ResponseWriter, *http.Request)
+}
+
+// Config GraphQL configuration.
+type Config struct {
+	// MaxDepth How deep queries can nest fields.
+	MaxDepth int
+	// MaxComplexity How many fields resolving a query may take, counting selections of connections once per node.
+	MaxComplexity int
+}
+
+// Request GraphQL over HTTP request body.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
@@ -0,0 +1,106 @@
+package gql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// connectionFields Fields returning connections, whose selections are resolved once per node requested.
+var connectionFields = map[string]bool{
+	"posts":    true,
+	"comments": true,
+	"replies":  true,
+}
+
+// queryCost Measures queries before they are executed, so limits are enforced without touching the service.
+type queryCost struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// measure Returns how deep the operation of doc named operationName nests fields, and how many fields resolving
+// it may take. Documents are expected to be valid, so fragments are known and never cyclic.
+func measure(doc *ast.Document, operationName string, variables map[string]any) (depth, complexity int) {
+	c := queryCost{fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || definition.Name != nil && definition.Name.Value == operationName {
+				operations = append(operations, definition)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		d, n := c.selections(operation.SelectionSet)
+		depth, complexity = max(depth, d), max(complexity, n)
+	}
+	return depth, complexity
+}
+
+// selections Returns how deep a selection set nests fields and how many fields resolving it may take.
+// Selections of connections count once per node requested, and introspection is free.
+func (c queryCost) selections(set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, n int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			d, n = c.selections(selection.SelectionSet)
+			d++
+			if connectionFields[selection.Name.Value] {
+				n *= c.first(selection)
+			}
+			n++
+		case *ast.InlineFragment:
+			d, n = c.selections(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := c.fragments[selection.Name.Value]; ok {
+				d, n = c.selections(fragment.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity += n
+	}
+	return depth, complexity
+}
+
+// first Returns how many nodes a connection field requests, either as a literal or through a variable.
+// Values out of range are counted as the closest valid page size, so they never lower the cost of a query.
+func (c queryCost) first(field *ast.Field) int {
+	return min(max(c.requested(field), 1), MaxPageSize)
+}
+
+// requested Returns the first argument of a connection field as given, DefaultPageSize when missing.
+func (c queryCost) requested(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if first, err := strconv.Atoi(value.Value); err == nil {
+				return first
+			}
+		case *ast.Variable:
+			switch first := c.variables[value.Name.Value].(type) {
+			case int:
+				return first
+			case float64:
+				return int(first)
+			}
+		}
+	}
+	return DefaultPageSize
+}
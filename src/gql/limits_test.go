@@ -0,0 +1,89 @@
+package gql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func Test_measure(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		operationName  string
+		variables      map[string]any
+		wantDepth      int
+		wantComplexity int
+	}{
+		{
+			name:           "flat",
+			query:          `{ post(id: "p1") { id title } }`,
+			wantDepth:      2,
+			wantComplexity: 3,
+		},
+		{
+			name:           "connections_multiply_by_first",
+			query:          `{ posts(first: 5) { edges { node { id comments(first: 2) { edges { node { id } } } } } } }`,
+			wantDepth:      7,
+			wantComplexity: 1 + 5*(1+1+1+(1+2*(1+1+1))),
+		},
+		{
+			name:           "default_page_size",
+			query:          `{ posts { edges { node { id } } } }`,
+			wantDepth:      4,
+			wantComplexity: 1 + DefaultPageSize*3,
+		},
+		{
+			name:           "first_from_variable",
+			query:          `query($n: Int) { posts(first: $n) { edges { node { id } } } }`,
+			variables:      map[string]any{"n": float64(2)},
+			wantDepth:      4,
+			wantComplexity: 1 + 2*3,
+		},
+		{
+			name:           "fragments",
+			query:          `{ post(id: "p1") { ...f ... on BlogPost { title } } } fragment f on BlogPost { comments(first: 3) { edges { node { id } } } }`,
+			wantDepth:      5,
+			wantComplexity: 1 + 1 + (1 + 3*3),
+		},
+		{
+			name:           "introspection_is_free",
+			query:          `{ __schema { types { fields { type { ofType { ofType { name } } } } } } post(id: "p1") { id } }`,
+			wantDepth:      2,
+			wantComplexity: 2,
+		},
+		{
+			name: "first_out_of_range_clamped",
+			query: `{ a: posts(first: -10000000) { edges { node { id } } }
+				b: posts(first: 1000) { edges { node { id comments(first: 100) { edges { node { id replies(first: 100) { edges { node { id } } } } } } } } } }`,
+			wantDepth:      10,
+			wantComplexity: (1 + 1*3) + (1 + MaxPageSize*(1+1+1+(1+100*(1+1+1+(1+100*3))))),
+		},
+		{
+			name:           "first_variable_out_of_range_clamped",
+			query:          `query($n: Int) { posts(first: $n) { edges { node { id } } } }`,
+			variables:      map[string]any{"n": float64(-5)},
+			wantDepth:      4,
+			wantComplexity: 1 + 1*3,
+		},
+		{
+			name:           "selected_operation_only",
+			query:          `query small { post(id: "p1") { id } } query big { posts(first: 50) { edges { node { id } } } }`,
+			operationName:  "small",
+			wantDepth:      2,
+			wantComplexity: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("failed to parse query: %v", err)
+			}
+			depth, complexity := measure(doc, tt.operationName, tt.variables)
+			if depth != tt.wantDepth || complexity != tt.wantComplexity {
+				t.Errorf("measure() = %d, %d, want %d, %d", depth, complexity, tt.wantDepth, tt.wantComplexity)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// loaderContextKey Context key of the loaders of a request.
+type loaderContextKey struct{}
+
+// pageKind What comment pages are grouped by.
+type pageKind int
+
+const (
+	// pageComments Top level comments grouped by blog post.
+	pageComments pageKind = iota
+	// pageReplies Direct replies grouped by parent comment.
+	pageReplies
+)
+
+// pageKey Identifies comment pages that can be read together, which are those requested with the same arguments.
+type pageKey struct {
+	Kind  pageKind
+	Query posts.CommentsQuery
+}
+
+// loaders Batches comment reads of a single request, so resolving the comments of many posts, or the replies
+// of many comments, takes a single call to the service per nesting level instead of one per post or comment.
+type loaders struct {
+	service posts.Service
+
+	mu      sync.Mutex
+	batches map[pageKey]*batch
+}
+
+// batch Comment pages requested by sibling fields, read together once the first of them is needed.
+type batch struct {
+	ids     []string
+	done    bool
+	results map[string][]posts.Comment
+	err     error
+}
+
+// withLoaders Returns a copy of ctx carrying new loaders.
+func withLoaders(ctx context.Context, service posts.Service) context.Context {
+	return context.WithValue(ctx, loaderContextKey{}, &loaders{service: service, batches: map[pageKey]*batch{}})
+}
+
+// loadComments Queues reading a comment page of the blog post or comment with provided ID, and returns a thunk
+// resolving it. The executor resolves thunks breadth first, so by the time any of them runs every sibling
+// page is queued and all of them are read at once.
+func loadComments(ctx context.Context, key pageKey, id string) func() (any, error) {
+	l := ctx.Value(loaderContextKey{}).(*loaders)
+
+	l.mu.Lock()
+	b, ok := l.batches[key]
+	if !ok || b.done {
+		b = &batch{}
+		l.batches[key] = b
+	}
+	b.ids = append(b.ids, id)
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !b.done {
+			b.done = true
+			if key.Kind == pageReplies {
+				b.results, b.err = l.service.GetRepliesByComments(b.ids, key.Query)
+			} else {
+				b.results, b.err = l.service.GetCommentsByBlogPosts(b.ids, key.Query)
+			}
+		}
+		if b.err != nil {
+			return nil, b.err
+		}
+		return b.results[id], nil
+	}
+}
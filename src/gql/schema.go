@@ -0,0 +1,495 @@
+package gql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/graphql-go/graphql"
+)
+
+// adminContextKey Context key flagging requests authenticated as admin.
+type adminContextKey struct{}
+
+// cursorPrefix Prefix of decoded cursors, which hold the offset of the node they point to.
+const cursorPrefix = "offset:"
+
+// resolverError Error reported by resolvers along with the HTTP status the same REST request would get.
+type resolverError struct {
+	err error
+}
+
+// Error Returns the message of the wrapped error.
+func (e resolverError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap Returns the wrapped error.
+func (e resolverError) Unwrap() error {
+	return e.err
+}
+
+// Extensions Exposes the status of the error in the GraphQL response.
+func (e resolverError) Extensions() map[string]any {
+	return map[string]any{"status": httputil.StatusCode(e.err, errMapper)}
+}
+
+// isAdmin Reports whether the request resolved with ctx is authenticated as admin.
+func isAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminContextKey{}).(bool)
+	return admin
+}
+
+// encodeCursor Returns the opaque cursor of the node at offset.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor Returns the offset of the node a cursor points to.
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if offset, ok := strings.CutPrefix(string(decoded), cursorPrefix); ok {
+			if n, err := strconv.Atoi(offset); err == nil && n >= 0 {
+				return n, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid cursor (%s)", posts.ErrorBadRequest, cursor)
+}
+
+// pageArgs Returns how many nodes a connection requests and how many it skips, from its `first` and `after` args.
+func pageArgs(args map[string]any) (first, offset int, err error) {
+	first, _ = args["first"].(int)
+	if first < 1 || first > MaxPageSize {
+		return 0, 0, fmt.Errorf("%w: first must be between 1 and %d", posts.ErrorBadRequest, MaxPageSize)
+	}
+	if after, ok := args["after"].(string); ok {
+		offset, err = decodeCursor(after)
+		if err != nil {
+			return 0, 0, err
+		}
+		offset++
+	}
+	return first, offset, nil
+}
+
+// newConnection Returns a connection out of a page of nodes starting at offset, read with one extra node
+// to tell whether there is a next page.
+func newConnection[T any](nodes []T, first, offset int) map[string]any {
+	hasNextPage := len(nodes) > first
+	if hasNextPage {
+		nodes = nodes[:first]
+	}
+
+	edges := make([]map[string]any, 0, len(nodes))
+	for i := range nodes {
+		edges = append(edges, map[string]any{"cursor": encodeCursor(offset + i), "node": &nodes[i]})
+	}
+
+	pageInfo := map[string]any{"hasNextPage": hasNextPage}
+	if len(edges) > 0 {
+		pageInfo["endCursor"] = edges[len(edges)-1]["cursor"]
+	}
+	return map[string]any{"edges": edges, "pageInfo": pageInfo}
+}
+
+// connectionArgs Pagination arguments of connections.
+var connectionArgs = graphql.FieldConfigArgument{
+	"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: DefaultPageSize},
+	"after": &graphql.ArgumentConfig{Type: graphql.String},
+}
+
+// newConnectionType Returns the connection type paginating nodes of provided type.
+func newConnectionType(node *graphql.Object, pageInfo *graphql.Object) *graphql.Object {
+	edge := graphql.NewObject(graphql.ObjectConfig{
+		Name: node.Name() + "Edge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(node)},
+		},
+	})
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: node.Name() + "Connection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfo)},
+		},
+	})
+}
+
+// timeField Returns a nullable DateTime field read from its source, null when the time is not set.
+func timeField[T any](get func(*T) time.Time) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.DateTime,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if t := get(p.Source.(*T)); !t.IsZero() {
+				return t, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+// optionalField Returns a nullable field of provided type read from its source, null when the value is empty.
+func optionalField[T any](t graphql.Output, get func(*T) string) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if value := get(p.Source.(*T)); value != "" {
+				return value, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+// commentsField Returns a field paginating comments grouped by the ID of its source, read in batches with
+// every sibling page through the request loaders.
+func commentsField[T any](connection *graphql.Object, kind pageKind, id func(*T) string) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(connection),
+		Args: connectionArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			first, offset, err := pageArgs(p.Args)
+			if err != nil {
+				return nil, resolverError{err}
+			}
+
+			key := pageKey{Kind: kind, Query: posts.CommentsQuery{
+				Limit:             first + 1,
+				Offset:            offset,
+				IncludeUnapproved: isAdmin(p.Context),
+			}}
+			load := loadComments(p.Context, key, id(p.Source.(*T)))
+			return func() (any, error) {
+				comments, err := load()
+				if err != nil {
+					return nil, err
+				}
+				page, _ := comments.([]posts.Comment)
+				return newConnection(page, first, offset), nil
+			}, nil
+		},
+	}
+}
+
+// newSchema Returns the GraphQL schema of the posts domain, resolved with provided service.
+func newSchema(service posts.Service) (graphql.Schema, error) {
+	postStatus := graphql.NewEnum(graphql.EnumConfig{
+		Name: "PostStatus",
+		Values: graphql.EnumValueConfigMap{
+			"DRAFT":     &graphql.EnumValueConfig{Value: posts.StatusDraft},
+			"SCHEDULED": &graphql.EnumValueConfig{Value: posts.StatusScheduled},
+			"PUBLISHED": &graphql.EnumValueConfig{Value: posts.StatusPublished},
+			"ARCHIVED":  &graphql.EnumValueConfig{Value: posts.StatusArchived},
+		},
+	})
+	contentFormat := graphql.NewEnum(graphql.EnumConfig{
+		Name: "ContentFormat",
+		Values: graphql.EnumValueConfigMap{
+			"TEXT":     &graphql.EnumValueConfig{Value: posts.ContentFormatText},
+			"MARKDOWN": &graphql.EnumValueConfig{Value: posts.ContentFormatMarkdown},
+		},
+	})
+	moderationStatus := graphql.NewEnum(graphql.EnumConfig{
+		Name: "ModerationStatus",
+		Values: graphql.EnumValueConfigMap{
+			"PENDING":  &graphql.EnumValueConfig{Value: posts.ModerationPending},
+			"APPROVED": &graphql.EnumValueConfig{Value: posts.ModerationApproved},
+			"REJECTED": &graphql.EnumValueConfig{Value: posts.ModerationRejected},
+			"SPAM":     &graphql.EnumValueConfig{Value: posts.ModerationSpam},
+		},
+	})
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	comment := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Comment",
+		Fields: graphql.Fields{
+			"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"blogPostId":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"parentId":         optionalField(graphql.ID, func(c *posts.Comment) string { return c.ParentID }),
+			"commentText":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"moderationStatus": &graphql.Field{Type: graphql.NewNonNull(moderationStatus)},
+			"replyCount":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt":        timeField(func(c *posts.Comment) time.Time { return c.CreatedAt }),
+		},
+	})
+	commentConnection := newConnectionType(comment, pageInfo)
+	comment.AddFieldConfig("replies", commentsField(commentConnection, pageReplies, func(c *posts.Comment) string { return c.ID }))
+
+	blogPost := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BlogPost",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"slug":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"title":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"content":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"contentFormat": &graphql.Field{Type: graphql.NewNonNull(contentFormat)},
+			"contentHtml":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"excerpt":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"author":        optionalField(graphql.String, func(b *posts.BlogPost) string { return b.Author }),
+			"status":        &graphql.Field{Type: graphql.NewNonNull(postStatus)},
+			"publishAt":     &graphql.Field{Type: graphql.DateTime},
+			"tags": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if tags := p.Source.(*posts.BlogPost).Tags; tags != nil {
+						return tags, nil
+					}
+					return []string{}, nil
+				},
+			},
+			"commentCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt":    timeField(func(b *posts.BlogPost) time.Time { return b.CreatedAt }),
+			"updatedAt":    timeField(func(b *posts.BlogPost) time.Time { return b.UpdatedAt }),
+			"comments":     commentsField(commentConnection, pageComments, func(b *posts.BlogPost) string { return b.ID }),
+		},
+	})
+	blogPostConnection := newConnectionType(blogPost, pageInfo)
+
+	// getBlogPost Reads a blog post as visible to the request, unpublished ones included for admins.
+	getBlogPost := func(ctx context.Context, id string) (*posts.BlogPost, error) {
+		post, err := service.GetBlogPost(id, posts.ReadOptions{IncludeUnpublished: isAdmin(ctx), IncludeUnapproved: isAdmin(ctx)})
+		if err != nil {
+			return nil, resolverError{err}
+		}
+		return post, nil
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"posts": &graphql.Field{
+				Type: graphql.NewNonNull(blogPostConnection),
+				Args: graphql.FieldConfigArgument{
+					"first": connectionArgs["first"],
+					"after": connectionArgs["after"],
+					"tags":  &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					first, offset, err := pageArgs(p.Args)
+					if err != nil {
+						return nil, resolverError{err}
+					}
+
+					opts := posts.ReadOptions{IncludeUnpublished: isAdmin(p.Context), IncludeUnapproved: isAdmin(p.Context)}
+					tags, _ := p.Args["tags"].([]any)
+					for _, tag := range tags {
+						opts.Tags = append(opts.Tags, tag.(string))
+					}
+
+					page, err := service.GetAllBlogPosts(first+1, offset, opts)
+					if err != nil {
+						return nil, resolverError{err}
+					}
+					return newConnection(page, first, offset), nil
+				},
+			},
+			"post": &graphql.Field{
+				Type: blogPost,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID), Description: "Public ID or slug."},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					post, err := getBlogPost(p.Context, p.Args["id"].(string))
+					if errors.Is(err, posts.ErrBlogPostNotFound) {
+						return nil, nil
+					}
+					return post, err
+				},
+			},
+		},
+	})
+
+	createPostInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreatePostInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"content":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"contentFormat": &graphql.InputObjectFieldConfig{Type: contentFormat},
+			"author":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"status":        &graphql.InputObjectFieldConfig{Type: postStatus},
+			"publishAt":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"tags":          &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		},
+	})
+	updatePostInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UpdatePostInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"content":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"contentFormat": &graphql.InputObjectFieldConfig{Type: contentFormat},
+		},
+	})
+
+	// adminOnly Wraps a resolver so it rejects requests not authenticated as admin.
+	adminOnly := func(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (any, error) {
+			if !isAdmin(p.Context) {
+				return nil, resolverError{httputil.ErrUnauthorized}
+			}
+			return resolve(p)
+		}
+	}
+	// readBack Reads a blog post just written, whatever its status.
+	readBack := func(id string, err error) (any, error) {
+		if err != nil {
+			return nil, resolverError{err}
+		}
+		post, err := service.GetBlogPost(id, posts.ReadOptions{IncludeUnpublished: true})
+		if err != nil {
+			return nil, resolverError{err}
+		}
+		return post, nil
+	}
+	idArgs := graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+	}
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPost": &graphql.Field{
+				Type: graphql.NewNonNull(blogPost),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createPostInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					input := p.Args["input"].(map[string]any)
+					request := posts.CreatePostRequest{
+						Title:   input["title"].(string),
+						Content: input["content"].(string),
+					}
+					if request.Title == "" || request.Content == "" {
+						return nil, resolverError{fmt.Errorf("%w: missing title or content", posts.ErrorBadRequest)}
+					}
+					request.Author, _ = input["author"].(string)
+					request.Status, _ = input["status"].(posts.Status)
+					request.ContentFormat, _ = input["contentFormat"].(posts.ContentFormat)
+					if publishAt, ok := input["publishAt"].(time.Time); ok {
+						request.PublishAt = &publishAt
+					}
+					tags, _ := input["tags"].([]any)
+					for _, tag := range tags {
+						request.Tags = append(request.Tags, tag.(string))
+					}
+					if request.RequiresAdmin() && !isAdmin(p.Context) {
+						return nil, resolverError{fmt.Errorf("%w: admin credentials required to publish or schedule posts", httputil.ErrUnauthorized)}
+					}
+
+					return readBack(service.CreateBlogPost(request))
+				},
+			},
+			"updatePost": &graphql.Field{
+				Type: graphql.NewNonNull(blogPost),
+				Args: graphql.FieldConfigArgument{
+					"id":    idArgs["id"],
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updatePostInput)},
+				},
+				Resolve: adminOnly(func(p graphql.ResolveParams) (any, error) {
+					id, input := p.Args["id"].(string), p.Args["input"].(map[string]any)
+					request := posts.UpdatePostRequest{
+						Title:   input["title"].(string),
+						Content: input["content"].(string),
+					}
+					if request.Title == "" || request.Content == "" {
+						return nil, resolverError{fmt.Errorf("%w: missing title or content", posts.ErrorBadRequest)}
+					}
+					request.ContentFormat, _ = input["contentFormat"].(posts.ContentFormat)
+
+					return readBack(id, service.UpdateBlogPost(id, request))
+				}),
+			},
+			"publishPost": &graphql.Field{
+				Type: graphql.NewNonNull(blogPost),
+				Args: graphql.FieldConfigArgument{
+					"id":        idArgs["id"],
+					"publishAt": &graphql.ArgumentConfig{Type: graphql.DateTime},
+				},
+				Resolve: adminOnly(func(p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					var publishAt *time.Time
+					if at, ok := p.Args["publishAt"].(time.Time); ok {
+						publishAt = &at
+					}
+					return readBack(id, service.PublishBlogPost(id, publishAt))
+				}),
+			},
+			"archivePost": &graphql.Field{
+				Type: graphql.NewNonNull(blogPost),
+				Args: idArgs,
+				Resolve: adminOnly(func(p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					return readBack(id, service.ArchiveBlogPost(id))
+				}),
+			},
+			"deletePost": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: adminOnly(func(p graphql.ResolveParams) (any, error) {
+					if err := service.DeleteBlogPost(p.Args["id"].(string)); err != nil {
+						return nil, resolverError{err}
+					}
+					return true, nil
+				}),
+			},
+			"createComment": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Creates a comment, or a reply when parentId is provided, and returns its ID.",
+				Args: graphql.FieldConfigArgument{
+					"postId":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"parentId": &graphql.ArgumentConfig{Type: graphql.ID},
+					"text":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					postID, text := p.Args["postId"].(string), p.Args["text"].(string)
+					if text == "" {
+						return nil, resolverError{fmt.Errorf("%w: missing comment text", posts.ErrorBadRequest)}
+					}
+
+					var (
+						id  string
+						err error
+					)
+					if parentID, ok := p.Args["parentId"].(string); ok {
+						id, err = service.CreateReply(postID, parentID, text)
+					} else {
+						id, err = service.CreateComment(postID, text)
+					}
+					if err != nil {
+						return nil, resolverError{err}
+					}
+					return id, nil
+				},
+			},
+			"deleteComment": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"postId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"id":     idArgs["id"],
+				},
+				Resolve: adminOnly(func(p graphql.ResolveParams) (any, error) {
+					if err := service.DeleteComment(p.Args["postId"].(string), p.Args["id"].(string)); err != nil {
+						return nil, resolverError{err}
+					}
+					return true, nil
+				}),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
@@ -0,0 +1,53 @@
+package httputil
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnauthorized Request lacks valid admin credentials.
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// contextKey Type for request context keys owned by this package.
+type contextKey string
+
+const (
+	// adminContextKey Marks requests authenticated with the admin token.
+	adminContextKey contextKey = "admin"
+)
+
+// Authenticate Returns a middleware marking requests that carry the admin bearer token as admin requests.
+// An empty token disables admin access altogether.
+func Authenticate(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if found && adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+				r = r.WithContext(context.WithValue(r.Context(), adminContextKey, true))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IsAdmin Reports whether the request was authenticated as admin.
+func IsAdmin(r *http.Request) bool {
+	isAdmin, _ := r.Context().Value(adminContextKey).(bool)
+	return isAdmin
+}
+
+// RequireAdmin Middleware rejecting requests not authenticated as admin.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsAdmin(r) {
+			HandlerHTTPError(w, "admin credentials required", ErrUnauthorized, map[error]int{ErrUnauthorized: http.StatusUnauthorized})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
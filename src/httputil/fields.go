@@ -0,0 +1,77 @@
+package httputil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// GetFieldsParam Parses the comma separated `fields` query param, nil when every field is requested.
+// Fields not in allowed are rejected.
+func GetFieldsParam(r *http.Request, allowed []string) ([]string, error) {
+	param := r.URL.Query().Get("fields")
+	if param == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for field := range strings.SplitSeq(param, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(allowed, field) {
+			return nil, fmt.Errorf("unknown field (%s), fields must be any of %s", field, strings.Join(allowed, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// JSONFieldNames Returns the JSON names of the exported fields of a struct value.
+func JSONFieldNames(v any) []string {
+	t := reflect.TypeOf(v)
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// PickFields Returns v encoded as a JSON object holding only provided fields, or v itself when fields is nil.
+func PickFields(v any, fields []string) (any, error) {
+	if fields == nil {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	for name := range object {
+		if !slices.Contains(fields, name) {
+			delete(object, name)
+		}
+	}
+	return object, nil
+}
@@ -0,0 +1,70 @@
+package httputil
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// dateLayout Layout of dates accepted by time filters, along with RFC 3339 timestamps.
+const dateLayout = "2006-01-02"
+
+// GetFilterParams Parses query params into filter, a pointer to a struct whose fields are tagged with their `query` param.
+// String, *bool and *time.Time fields are supported. Params neither tagged in filter nor in known are rejected by name,
+// and so are filter params given more than once.
+func GetFilterParams(r *http.Request, filter any, known ...string) error {
+	v := reflect.ValueOf(filter).Elem()
+	t := v.Type()
+
+	fields := map[string]reflect.Value{}
+	for i := range t.NumField() {
+		if name := t.Field(i).Tag.Get("query"); name != "" {
+			fields[name] = v.Field(i)
+		}
+	}
+
+	for key, values := range r.URL.Query() {
+		field, ok := fields[key]
+		if !ok {
+			if slices.Contains(known, key) {
+				continue
+			}
+			return fmt.Errorf("unknown filter (%s)", key)
+		}
+
+		if len(values) > 1 {
+			return fmt.Errorf("%s must be given once", key)
+		}
+		value := values[0]
+		if value == "" {
+			continue
+		}
+
+		switch field.Interface().(type) {
+		case *time.Time:
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				parsed, err = time.Parse(dateLayout, value)
+			}
+			if err != nil {
+				return fmt.Errorf("%s must be a date or an RFC 3339 timestamp", key)
+			}
+			field.Set(reflect.ValueOf(&parsed))
+		case *bool:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s must be either true or false", key)
+			}
+			field.Set(reflect.ValueOf(&parsed))
+		default:
+			if field.Kind() != reflect.String {
+				return fmt.Errorf("unsupported filter type (%s)", field.Type())
+			}
+			field.SetString(value)
+		}
+	}
+	return nil
+}
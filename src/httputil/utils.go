@@ -8,8 +8,22 @@ import (
 
 // HTTPErrorResponse
 type HTTPErrorResponse struct {
-	Message string `json:"message"`
-	Cause   string `json:"cause"`
+	Message string   `json:"message"`
+	Cause   string   `json:"cause"`
+	Details []string `json:"details,omitempty"`
+	Index   *int     `json:"index,omitempty"`
+}
+
+// DetailedError Error carrying details to be exposed in HTTP error responses.
+type DetailedError interface {
+	error
+	Details() []string
+}
+
+// IndexedError Error of a single item of a batch request, whose index is exposed in HTTP error responses.
+type IndexedError interface {
+	error
+	ItemIndex() int
 }
 
 // handlerHTTPError Translates service errors into HTTP errors.
@@ -19,37 +33,54 @@ func HandlerHTTPError(w http.ResponseWriter, msg string, serviceErr error, errMa
 		Cause:   serviceErr.Error(),
 	}
 
+	var detailedErr DetailedError
+	if errors.As(serviceErr, &detailedErr) {
+		httpErr.Details = detailedErr.Details()
+	}
+
+	var indexedErr IndexedError
+	if errors.As(serviceErr, &indexedErr) {
+		index := indexedErr.ItemIndex()
+		httpErr.Index = &index
+	}
+
 	data, err := json.Marshal(httpErr)
 	if err != nil {
 		// TODO: Log warning here.
 		return
 	}
 
+	w.Header().Add("Content-Type", "application/problem+json")
+	w.WriteHeader(StatusCode(serviceErr, errMapper))
+	w.Write(data)
+}
+
+// StatusCode Returns the HTTP status of a service error, internal server error when it is not mapped.
+func StatusCode(serviceErr error, errMapper map[error]int) int {
 	statusCode := http.StatusInternalServerError
 	for errMap, status := range errMapper {
 		if errors.Is(serviceErr, errMap) {
 			statusCode = status
 		}
 	}
-
-	w.Header().Add("Content-Type", "application/problem+json")
-	w.WriteHeader(statusCode)
-	w.Write(data)
+	return statusCode
 }
 
 // handlerHTTPResponse Translates service errors into HTTP errors.
 func HandlerHTTPResponse(w http.ResponseWriter, statusCode int, response any) {
-	w.WriteHeader(statusCode)
-
-	if response != nil {
-		data, err := json.Marshal(response)
-		if err != nil {
-			// TODO: Log warning here.
-			return
-		}
-		w.Header().Add("Content-Type", "application/json")
-		w.Write(data)
+	if response == nil {
+		w.WriteHeader(statusCode)
 		return
 	}
 
+	data, err := json.Marshal(response)
+	if err != nil {
+		// TODO: Log warning here.
+		w.WriteHeader(statusCode)
+		return
+	}
+	// Headers are sent along with the status, so the content type must be set before writing it.
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(data)
 }
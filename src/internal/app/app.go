@@ -1,21 +1,99 @@
 package app
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/MatiasKopp/prosig-code-challenge/feeds"
+	"github.com/MatiasKopp/prosig-code-challenge/gql"
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/live"
+	"github.com/MatiasKopp/prosig-code-challenge/openapi"
 	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	postsv1 "github.com/MatiasKopp/prosig-code-challenge/proto/posts/v1"
+	"github.com/MatiasKopp/prosig-code-challenge/streams"
+	"github.com/MatiasKopp/prosig-code-challenge/webhooks"
 	"github.com/caarlos0/env/v11"
 	"github.com/go-chi/chi/v5"
 	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc"
 )
 
 // Config App configuration structure.
 type Config struct {
 	Port       string `env:"APP_PORT"`
 	DBLocation string `env:"DB_LOCATION"`
+	AdminToken string `env:"ADMIN_TOKEN"`
+	// GRPCPort Address the gRPC API listens at, the gRPC API is disabled when empty.
+	GRPCPort string `env:"GRPC_PORT"`
+	// ShutdownTimeout How long requests in flight are waited for once the app is stopping.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
+
+	// SchedulerInterval How often scheduled posts are checked for publication. Background jobs are disabled
+	// when their interval is zero.
+	SchedulerInterval time.Duration `env:"SCHEDULER_INTERVAL" envDefault:"1m"`
+	// CommentModeration Moderation status of new comments on posts without their own default.
+	CommentModeration string `env:"COMMENT_MODERATION" envDefault:"approved"`
+
+	// Content filters, actions are either allow, moderate or reject.
+	FilterBlockedWords       []string `env:"FILTER_BLOCKED_WORDS" envSeparator:","`
+	FilterBlockedWordsAction string   `env:"FILTER_BLOCKED_WORDS_ACTION" envDefault:"reject"`
+	FilterMaxLinks           int      `env:"FILTER_MAX_LINKS" envDefault:"3"`
+	FilterMaxLinksAction     string   `env:"FILTER_MAX_LINKS_ACTION" envDefault:"moderate"`
+	FilterDuplicatesAction   string   `env:"FILTER_DUPLICATES_ACTION" envDefault:"reject"`
+
+	// TrashRetention How long deleted posts and comments are kept before being purged.
+	TrashRetention time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+	// TrashPurgeInterval How often trash is checked for items past their retention.
+	TrashPurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+
+	// Feeds, links are built from the URL the API is reachable at.
+	FeedBaseURL string `env:"FEED_BASE_URL" envDefault:"http://localhost:8080"`
+	FeedTitle   string `env:"FEED_TITLE" envDefault:"Blog"`
+	FeedSize    int    `env:"FEED_SIZE" envDefault:"20"`
+
+	// Webhooks, failed deliveries are retried with exponential backoff until running out of attempts.
+	WebhookDeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"5s"`
+	WebhookTimeout          time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	WebhookMaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	WebhookBackoff          time.Duration `env:"WEBHOOK_BACKOFF" envDefault:"30s"`
+	WebhookMaxBackoff       time.Duration `env:"WEBHOOK_MAX_BACKOFF" envDefault:"6h"`
+	// Receivers on loopback, link-local and private addresses are refused unless allowed.
+	WebhookAllowPrivateTargets bool `env:"WEBHOOK_ALLOW_PRIVATE_TARGETS" envDefault:"false"`
+
+	// Outbox, events are published to every sink, either webhook, log or bus, and retried until they all succeed.
+	OutboxSinks            []string      `env:"OUTBOX_SINKS" envSeparator:"," envDefault:"webhook,bus"`
+	OutboxDispatchInterval time.Duration `env:"OUTBOX_DISPATCH_INTERVAL" envDefault:"1s"`
+	OutboxBatchSize        int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	OutboxBackoff          time.Duration `env:"OUTBOX_BACKOFF" envDefault:"5s"`
+	OutboxMaxBackoff       time.Duration `env:"OUTBOX_MAX_BACKOFF" envDefault:"5m"`
+	OutboxRetention        time.Duration `env:"OUTBOX_RETENTION" envDefault:"168h"`
+	OutboxPruneInterval    time.Duration `env:"OUTBOX_PRUNE_INTERVAL" envDefault:"1h"`
+
+	// Comment streams, fed by the bus event sink.
+	StreamHeartbeat time.Duration `env:"STREAM_HEARTBEAT" envDefault:"15s"`
+	StreamBuffer    int           `env:"STREAM_BUFFER" envDefault:"32"`
+
+	// Live updates over WebSocket, fed by the bus event sink. Origins default to the request host only.
+	WSOrigins          []string      `env:"WS_ORIGINS" envSeparator:","`
+	WSHeartbeat        time.Duration `env:"WS_HEARTBEAT" envDefault:"30s"`
+	WSWriteTimeout     time.Duration `env:"WS_WRITE_TIMEOUT" envDefault:"10s"`
+	WSSendBuffer       int           `env:"WS_SEND_BUFFER" envDefault:"64"`
+	WSMaxSubscriptions int           `env:"WS_MAX_SUBSCRIPTIONS" envDefault:"50"`
+	WSMaxMessageSize   int64         `env:"WS_MAX_MESSAGE_SIZE" envDefault:"16384"`
+	WSMessageRate      int           `env:"WS_MESSAGE_RATE" envDefault:"10"`
+
+	// GraphQL query limits, checked before anything is resolved.
+	GraphQLMaxDepth      int `env:"GRAPHQL_MAX_DEPTH" envDefault:"12"`
+	GraphQLMaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" envDefault:"1000"`
 }
 
 // App Represents productive app.
@@ -23,17 +101,82 @@ type App struct {
 	Config Config
 	Router chi.Router
 
+	// Services
+	PostsService    posts.Service
+	WebhooksService webhooks.Service
+
+	// Events
+	EventBus         *posts.EventBus
+	EventsDispatcher *posts.Dispatcher
+
 	// Handlers
-	PostsHTTPAdapter posts.HTTPAdapter
+	PostsHTTPAdapter    posts.HTTPAdapter
+	FeedsHTTPAdapter    feeds.HTTPAdapter
+	WebhooksHTTPAdapter webhooks.HTTPAdapter
+	StreamsHTTPAdapter  streams.HTTPAdapter
+	LiveHTTPAdapter     live.HTTPAdapter
+	GraphQLHTTPAdapter  gql.HTTPAdapter
+	OpenAPIHTTPAdapter  openapi.HTTPAdapter
+	PostsGRPCAdapter    postsv1.PostsServiceServer
+
+	// stopJobs Stops background jobs, tracked by jobs.
+	stopJobs context.CancelFunc
+	jobs     sync.WaitGroup
+}
+
+// validate Checks configuration values the app cannot run with.
+func (c Config) validate() error {
+	intervals := []struct {
+		name     string
+		interval time.Duration
+	}{
+		{"SCHEDULER_INTERVAL", c.SchedulerInterval},
+		{"TRASH_PURGE_INTERVAL", c.TrashPurgeInterval},
+		{"WEBHOOK_DELIVERY_INTERVAL", c.WebhookDeliveryInterval},
+		{"OUTBOX_DISPATCH_INTERVAL", c.OutboxDispatchInterval},
+		{"OUTBOX_PRUNE_INTERVAL", c.OutboxPruneInterval},
+	}
+	var errs []error
+	for _, i := range intervals {
+		if i.interval < 0 {
+			errs = append(errs, fmt.Errorf("%s cannot be negative (%s)", i.name, i.interval))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ParseConfig Returns the app configuration read from the environment, validated.
+func ParseConfig() (Config, error) {
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		return Config{}, fmt.Errorf("error parsing env config: %s", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid env config: %s", err)
+	}
+	return cfg, nil
+}
+
+// NewPostsService Returns the posts service the app runs with configuration, content filters and comment
+// moderation included, so posts and comments are checked the same whoever writes them.
+func NewPostsService(cfg Config, repository posts.Repository) (posts.Service, error) {
+	contentFilter, err := newContentFilter(cfg, repository)
+	if err != nil {
+		return nil, fmt.Errorf("error creating content filters: %s", err)
+	}
+
+	return posts.NewService(repository, posts.ServiceConfig{
+		DefaultCommentModeration: posts.ModerationStatus(cfg.CommentModeration),
+		ContentFilter:            contentFilter,
+		TrashRetention:           cfg.TrashRetention,
+	})
 }
 
 // New Returns new productive app implementation
 func New() *App {
-	// parse
-	var cfg Config
-	err := env.Parse(&cfg)
+	cfg, err := ParseConfig()
 	if err != nil {
-		panic(fmt.Errorf("error parsing env config: %s", err))
+		panic(err)
 	}
 
 	app := &App{
@@ -51,27 +194,157 @@ func HealthCheck(w http.ResponseWriter, _ *http.Request) {
 	w.Write([]byte("pong"))
 }
 
-// Start Starts listening at configured port.
-func (a *App) Start() {
+// Start Serves the API at configured port, and the gRPC API at the configured gRPC port when set, until ctx is done
+// or either of them fails. Both are then shut down, waiting up to the shutdown timeout for requests in flight, and
+// background jobs are stopped. Returns the error serving failed with, if any.
+func (a *App) Start(ctx context.Context) error {
+	defer a.Stop()
+
+	errs := make(chan error, 2)
+
+	var grpcServer *grpc.Server
+	if a.Config.GRPCPort != "" {
+		listener, err := net.Listen("tcp", a.Config.GRPCPort)
+		if err != nil {
+			return fmt.Errorf("error listening at gRPC port: %w", err)
+		}
+
+		grpcServer = grpc.NewServer()
+		postsv1.RegisterPostsServiceServer(grpcServer, a.PostsGRPCAdapter)
+
+		fmt.Printf("gRPC API listening at port %s...", a.Config.GRPCPort)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				errs <- fmt.Errorf("error serving gRPC API: %w", err)
+			}
+		}()
+	}
+
+	server := &http.Server{Addr: a.Config.Port, Handler: a.Router}
 	fmt.Printf("App listening at port %s...", a.Config.Port)
-	http.ListenAndServe(a.Config.Port, a.Router)
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errs <- fmt.Errorf("error serving API: %w", err)
+		}
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.Config.ShutdownTimeout)
+	defer cancel()
+	var stopping sync.WaitGroup
+	if grpcServer != nil {
+		stopping.Go(func() {
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-shutdownCtx.Done():
+				grpcServer.Stop()
+			}
+		})
+	}
+	if shutdownErr := server.Shutdown(shutdownCtx); shutdownErr != nil {
+		server.Close()
+	}
+	stopping.Wait()
+
+	return err
+}
+
+// Stop Stops background jobs, waiting for those running to finish.
+func (a *App) Stop() {
+	a.stopJobs()
+	a.jobs.Wait()
 }
 
 // mapRoutes Maps routes to handlers
 func (a *App) mapRoutes() {
+	a.Router.Use(httputil.Authenticate(a.Config.AdminToken))
 	a.Router.Get("/ping", HealthCheck)
+	a.Router.Get("/openapi.json", a.OpenAPIHTTPAdapter.GetSpec)
+	a.Router.Get("/docs", a.OpenAPIHTTPAdapter.GetDocs)
+	a.Router.Get("/docs/*", a.OpenAPIHTTPAdapter.GetDocs)
+	a.Router.Get("/ws", a.LiveHTTPAdapter.Connect)
+	a.Router.Get("/graphql", a.GraphQLHTTPAdapter.Query)
+	a.Router.Post("/graphql", a.GraphQLHTTPAdapter.Query)
+
+	a.Router.Route("/feeds", func(feeds chi.Router) {
+		feeds.Get("/posts.atom", a.FeedsHTTPAdapter.GetPostsAtom)
+		feeds.Get("/posts.rss", a.FeedsHTTPAdapter.GetPostsRSS)
+		feeds.Get("/posts/{id}/comments.atom", a.FeedsHTTPAdapter.GetCommentsAtom)
+	})
 
 	a.Router.Route("/api", func(api chi.Router) {
 		api.Get("/posts", a.PostsHTTPAdapter.GetAllPosts)
 		api.Get("/posts/{id}", a.PostsHTTPAdapter.GetPost)
 		api.Post("/posts", a.PostsHTTPAdapter.CreatePost)
+		api.Get("/tags", a.PostsHTTPAdapter.GetTags)
 		api.Post("/posts/{id}/comments", a.PostsHTTPAdapter.CreateComment)
+		api.Get("/posts/{id}/comments", a.PostsHTTPAdapter.GetComments)
+		api.Get("/posts/{id}/comments/stream", a.StreamsHTTPAdapter.StreamComments)
+		api.Get("/posts/{id}/comments/{commentId}/replies", a.PostsHTTPAdapter.GetComments)
+		api.Post("/posts/{id}/comments/{commentId}/replies", a.PostsHTTPAdapter.CreateReply)
+
+		// Admin only
+		api.With(httputil.RequireAdmin).Post("/posts:batch", a.PostsHTTPAdapter.CreatePosts)
+		api.With(httputil.RequireAdmin).Put("/posts/{id}", a.PostsHTTPAdapter.UpdatePost)
+		api.With(httputil.RequireAdmin).Get("/posts/{id}/revisions", a.PostsHTTPAdapter.GetRevisions)
+		api.With(httputil.RequireAdmin).Get("/posts/{id}/revisions/diff", a.PostsHTTPAdapter.DiffRevisions)
+		api.With(httputil.RequireAdmin).Get("/posts/{id}/revisions/{rev}", a.PostsHTTPAdapter.GetRevision)
+		api.With(httputil.RequireAdmin).Post("/posts/{id}/revisions/{rev}/restore", a.PostsHTTPAdapter.RestoreRevision)
+		api.With(httputil.RequireAdmin).Post("/posts/{id}/publish", a.PostsHTTPAdapter.PublishPost)
+		api.With(httputil.RequireAdmin).Post("/posts/{id}/archive", a.PostsHTTPAdapter.ArchivePost)
+		api.With(httputil.RequireAdmin).Delete("/posts/{id}", a.PostsHTTPAdapter.DeletePost)
+		api.With(httputil.RequireAdmin).Delete("/posts/{id}/comments/{commentId}", a.PostsHTTPAdapter.DeleteComment)
+
+		api.Route("/admin", func(admin chi.Router) {
+			admin.Use(httputil.RequireAdmin)
+
+			admin.Get("/comments", a.PostsHTTPAdapter.GetModerationQueue)
+			admin.Post("/comments/moderation", a.PostsHTTPAdapter.ModerateComments)
+
+			admin.Get("/trash/posts", a.PostsHTTPAdapter.GetDeletedPosts)
+			admin.Get("/trash/comments", a.PostsHTTPAdapter.GetDeletedComments)
+			admin.Post("/trash/posts/{id}/restore", a.PostsHTTPAdapter.RestorePost)
+			admin.Post("/trash/comments/{commentId}/restore", a.PostsHTTPAdapter.RestoreComment)
+			admin.Delete("/trash", a.PostsHTTPAdapter.PurgeTrash)
+
+			admin.Get("/export", a.PostsHTTPAdapter.ExportContent)
+			admin.Post("/import", a.PostsHTTPAdapter.ImportContent)
+
+			admin.Post("/webhooks", a.WebhooksHTTPAdapter.CreateSubscription)
+			admin.Get("/webhooks", a.WebhooksHTTPAdapter.GetSubscriptions)
+			admin.Get("/webhooks/{id}", a.WebhooksHTTPAdapter.GetSubscription)
+			admin.Put("/webhooks/{id}", a.WebhooksHTTPAdapter.UpdateSubscription)
+			admin.Delete("/webhooks/{id}", a.WebhooksHTTPAdapter.DeleteSubscription)
+			admin.Get("/webhooks/{id}/deliveries", a.WebhooksHTTPAdapter.GetDeliveries)
+			admin.Post("/webhooks/{id}/deliveries/{deliveryId}/redeliver", a.WebhooksHTTPAdapter.Redeliver)
+		})
 	})
 }
 
+// OpenDB Opens the SQLite database at location. Background jobs write along with requests, so writers wait for each
+// other instead of failing right away, and transactions take the write lock as they begin, which keeps those reading
+// before writing from deadlocking.
+func OpenDB(location string) (*sql.DB, error) {
+	separator := "?"
+	if strings.Contains(location, "?") {
+		separator = "&"
+	}
+	return sql.Open("sqlite3", location+separator+"_busy_timeout=5000&_txlock=immediate")
+}
+
 // bootstrap Bootstraps handlers
 func (a *App) bootstrap() {
-	db, err := sql.Open("sqlite3", a.Config.DBLocation)
+	db, err := OpenDB(a.Config.DBLocation)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -81,9 +354,31 @@ func (a *App) bootstrap() {
 		panic("error creating repository")
 	}
 
-	service, err := posts.NewService(repository)
+	webhooksRepository, err := webhooks.NewRepository(db)
 	if err != nil {
-		panic("error creating service")
+		panic("error creating webhooks repository")
+	}
+
+	webhooksService, err := webhooks.NewService(webhooksRepository, webhooks.ServiceConfig{
+		Timeout:     a.Config.WebhookTimeout,
+		MaxAttempts: a.Config.WebhookMaxAttempts,
+		Backoff:     a.Config.WebhookBackoff,
+		MaxBackoff:  a.Config.WebhookMaxBackoff,
+
+		AllowPrivateTargets: a.Config.WebhookAllowPrivateTargets,
+	})
+	if err != nil {
+		panic(fmt.Errorf("error creating webhooks service: %s", err))
+	}
+
+	webhooksHTTPAdapter, err := webhooks.NewHTTPAdapter(webhooksService)
+	if err != nil {
+		panic("error creating webhooks http adapter")
+	}
+
+	service, err := NewPostsService(a.Config, repository)
+	if err != nil {
+		panic(fmt.Errorf("error creating service: %s", err))
 	}
 
 	httpAdapter, err := posts.NewHTTPAdapter(service)
@@ -91,5 +386,141 @@ func (a *App) bootstrap() {
 		panic("error creating service")
 	}
 
+	grpcAdapter, err := posts.NewGRPCAdapter(service)
+	if err != nil {
+		panic("error creating gRPC adapter")
+	}
+
+	feedsHTTPAdapter, err := feeds.NewHTTPAdapter(service, feeds.Config{
+		BaseURL: a.Config.FeedBaseURL,
+		Title:   a.Config.FeedTitle,
+		Size:    a.Config.FeedSize,
+	})
+	if err != nil {
+		panic(fmt.Errorf("error creating feeds: %s", err))
+	}
+
+	eventBus := posts.NewEventBus()
+	sinks, err := a.eventSinks(webhooksService, eventBus)
+	if err != nil {
+		panic(fmt.Errorf("error creating event sinks: %s", err))
+	}
+
+	dispatcher, err := posts.NewDispatcher(repository, sinks, posts.DispatcherConfig{
+		BatchSize:  a.Config.OutboxBatchSize,
+		Backoff:    a.Config.OutboxBackoff,
+		MaxBackoff: a.Config.OutboxMaxBackoff,
+		Retention:  a.Config.OutboxRetention,
+	})
+	if err != nil {
+		panic(fmt.Errorf("error creating events dispatcher: %s", err))
+	}
+
+	hub := streams.NewHub(a.Config.StreamBuffer)
+	eventBus.Subscribe(hub.Handle)
+
+	streamsHTTPAdapter, err := streams.NewHTTPAdapter(service, hub, streams.Config{
+		Heartbeat: a.Config.StreamHeartbeat,
+	})
+	if err != nil {
+		panic(fmt.Errorf("error creating streams: %s", err))
+	}
+
+	liveHTTPAdapter, err := live.NewHTTPAdapter(service, eventBus, live.Config{
+		OriginPatterns:   a.Config.WSOrigins,
+		Heartbeat:        a.Config.WSHeartbeat,
+		WriteTimeout:     a.Config.WSWriteTimeout,
+		SendBuffer:       a.Config.WSSendBuffer,
+		MaxSubscriptions: a.Config.WSMaxSubscriptions,
+		MaxMessageSize:   a.Config.WSMaxMessageSize,
+		MessageRate:      a.Config.WSMessageRate,
+	})
+	if err != nil {
+		panic(fmt.Errorf("error creating live updates: %s", err))
+	}
+
+	graphQLHTTPAdapter, err := gql.NewHTTPAdapter(service, gql.Config{
+		MaxDepth:      a.Config.GraphQLMaxDepth,
+		MaxComplexity: a.Config.GraphQLMaxComplexity,
+	})
+	if err != nil {
+		panic(fmt.Errorf("error creating GraphQL adapter: %s", err))
+	}
+
+	openAPIHTTPAdapter, err := openapi.NewHTTPAdapter()
+	if err != nil {
+		panic(fmt.Errorf("error creating API documentation: %s", err))
+	}
+
+	a.PostsService = service
+	a.EventBus = eventBus
+	a.EventsDispatcher = dispatcher
 	a.PostsHTTPAdapter = httpAdapter
+	a.PostsGRPCAdapter = grpcAdapter
+	a.FeedsHTTPAdapter = feedsHTTPAdapter
+	a.WebhooksService = webhooksService
+	a.WebhooksHTTPAdapter = webhooksHTTPAdapter
+	a.StreamsHTTPAdapter = streamsHTTPAdapter
+	a.LiveHTTPAdapter = liveHTTPAdapter
+	a.GraphQLHTTPAdapter = graphQLHTTPAdapter
+	a.OpenAPIHTTPAdapter = openAPIHTTPAdapter
+
+	ctx, stop := context.WithCancel(context.Background())
+	a.stopJobs = stop
+	jobs := []struct {
+		name     string
+		interval time.Duration
+		job      func() error
+	}{
+		{"publish scheduled posts", a.Config.SchedulerInterval, a.publishScheduledPosts},
+		{"purge trash", a.Config.TrashPurgeInterval, a.purgeTrash},
+		{"dispatch events", a.Config.OutboxDispatchInterval, a.dispatchEvents},
+		{"prune events", a.Config.OutboxPruneInterval, a.pruneEvents},
+		{"deliver webhooks", a.Config.WebhookDeliveryInterval, a.deliverWebhooks},
+	}
+	for _, j := range jobs {
+		a.jobs.Go(func() { runPeriodically(ctx, j.name, j.interval, j.job) })
+	}
+}
+
+// eventSinks Builds the sinks outbox events are dispatched to from configuration.
+func (a *App) eventSinks(webhooksService webhooks.Service, eventBus *posts.EventBus) ([]posts.Sink, error) {
+	sinks := make([]posts.Sink, 0, len(a.Config.OutboxSinks))
+	for _, name := range a.Config.OutboxSinks {
+		var publisher posts.EventPublisher
+		switch name {
+		case "webhook":
+			publisher = webhooksService
+		case "log":
+			publisher = posts.EventLogger{}
+		case "bus":
+			publisher = eventBus
+		default:
+			return nil, fmt.Errorf("unknown event sink (%s)", name)
+		}
+		sinks = append(sinks, posts.Sink{Name: name, Publisher: publisher})
+	}
+	return sinks, nil
+}
+
+// newContentFilter Builds the content filter chain from configuration.
+func newContentFilter(cfg Config, repository posts.Repository) (posts.ContentFilter, error) {
+	blockedWordsAction, err := posts.ParseFilterAction(cfg.FilterBlockedWordsAction)
+	if err != nil {
+		return nil, err
+	}
+	maxLinksAction, err := posts.ParseFilterAction(cfg.FilterMaxLinksAction)
+	if err != nil {
+		return nil, err
+	}
+	duplicatesAction, err := posts.ParseFilterAction(cfg.FilterDuplicatesAction)
+	if err != nil {
+		return nil, err
+	}
+
+	return posts.FilterChain{
+		posts.BlockedWordsFilter{Words: cfg.FilterBlockedWords, Action: blockedWordsAction},
+		posts.LinkLimitFilter{MaxLinks: cfg.FilterMaxLinks, Action: maxLinksAction},
+		posts.DuplicateCommentFilter{Repository: repository, Action: duplicatesAction},
+	}, nil
 }
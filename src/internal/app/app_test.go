@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/openapi"
+	"github.com/go-chi/chi/v5"
+)
+
+// TestRoutesDocumented Checks the OpenAPI document describes every route of the app, and nothing else.
+func TestRoutesDocumented(t *testing.T) {
+	t.Setenv("DB_LOCATION", filepath.Join(t.TempDir(), "posts.db"))
+	app := New()
+	t.Cleanup(app.Stop)
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openapi.Spec, &doc); err != nil {
+		t.Fatalf("failed to read OpenAPI document: %v", err)
+	}
+	var documented []string
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	var routed []string
+	err := chi.Walk(app.Router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// Wildcard routes are documented by their root.
+		routed = append(routed, method+" "+strings.TrimSuffix(route, "*"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+
+	for _, route := range routed {
+		if !slices.Contains(documented, route) {
+			t.Errorf("route %s is not documented", route)
+		}
+	}
+	for _, route := range documented {
+		if !slices.Contains(routed, route) {
+			t.Errorf("documented route %s does not exist", route)
+		}
+	}
+}
+
+// freeAddress Returns a local address nothing listens at.
+func freeAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestApp_Start(t *testing.T) {
+	t.Setenv("DB_LOCATION", filepath.Join(t.TempDir(), "posts.db"))
+	t.Setenv("APP_PORT", freeAddress(t))
+	t.Setenv("GRPC_PORT", freeAddress(t))
+	app := New()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- app.Start(ctx) }()
+
+	url := "http://" + app.Config.Port + "/ping"
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, err := http.Get(url)
+		if err == nil {
+			res.Body.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("app not serving: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start() did not return once its context was done")
+	}
+	for _, addr := range []string{app.Config.Port, app.Config.GRPCPort} {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			t.Errorf("%s still listening after Start() returned", addr)
+		}
+	}
+}
+
+func TestApp_Start_portInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	t.Setenv("DB_LOCATION", filepath.Join(t.TempDir(), "posts.db"))
+	t.Setenv("APP_PORT", listener.Addr().String())
+
+	if err := New().Start(t.Context()); err == nil || !strings.Contains(err.Error(), "error serving API") {
+		t.Errorf("Start() error = %v, want error serving API", err)
+	}
+}
+
+func TestConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "ok", cfg: Config{SchedulerInterval: time.Minute}},
+		{name: "zero_disables", cfg: Config{}},
+		{name: "negative_interval", cfg: Config{SchedulerInterval: -time.Second}, wantErr: "SCHEDULER_INTERVAL cannot be negative (-1s)"},
+		{name: "negative_prune_interval", cfg: Config{OutboxPruneInterval: -time.Minute}, wantErr: "OUTBOX_PRUNE_INTERVAL cannot be negative (-1m0s)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate()
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_runPeriodically(t *testing.T) {
+	var runs atomic.Int32
+	job := func() error {
+		runs.Add(1)
+		return nil
+	}
+
+	// Disabled jobs return right away.
+	runPeriodically(t.Context(), "disabled", 0, job)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		runPeriodically(ctx, "job", time.Millisecond, job)
+		close(done)
+	}()
+	for runs.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runPeriodically() did not stop once its context was done")
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runPeriodically Runs job every interval until ctx is done, logging its failures.
+// Jobs without a positive interval are disabled.
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func() error) {
+	if interval <= 0 {
+		log.Printf("background job %q disabled", name)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(); err != nil {
+				log.Printf("background job %q failed: %s", name, err)
+			}
+		}
+	}
+}
+
+// publishScheduledPosts Publishes scheduled posts whose publish date is due.
+func (a *App) publishScheduledPosts() error {
+	published, err := a.PostsService.PublishDueBlogPosts(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if published > 0 {
+		log.Printf("published %d scheduled posts", published)
+	}
+	return nil
+}
+
+// purgeTrash Permanently deletes posts and comments in trash for longer than the retention window.
+func (a *App) purgeTrash() error {
+	purged, err := a.PostsService.PurgeTrash(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		log.Printf("purged %d posts and comments from trash", purged)
+	}
+	return nil
+}
+
+// deliverWebhooks Attempts webhook deliveries due, first ones or retries.
+func (a *App) deliverWebhooks() error {
+	attempted, err := a.WebhooksService.DeliverDue(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if attempted > 0 {
+		log.Printf("attempted %d webhook deliveries", attempted)
+	}
+	return nil
+}
+
+// dispatchEvents Publishes outbox events due to every event sink.
+func (a *App) dispatchEvents() error {
+	dispatched, err := a.EventsDispatcher.Dispatch(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if dispatched > 0 {
+		log.Printf("dispatched %d events", dispatched)
+	}
+	return nil
+}
+
+// pruneEvents Deletes outbox events dispatched longer than their retention ago.
+func (a *App) pruneEvents() error {
+	pruned, err := a.EventsDispatcher.Prune(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if pruned > 0 {
+		log.Printf("pruned %d dispatched events", pruned)
+	}
+	return nil
+}
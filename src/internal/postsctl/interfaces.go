@@ -0,0 +1,53 @@
+package postsctl
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/MatiasKopp/prosig-code-challenge/client"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+var (
+	// ErrNotSupported Command cannot be run through the backend in use.
+	ErrNotSupported = errors.New("not supported through the HTTP API, use -db instead")
+)
+
+// Backend Blog data commands work on, either a database file or the posts API.
+// Backends act as admin, so unpublished posts and unapproved comments are included.
+type Backend interface {
+	// ListPosts Returns a page of posts.
+	ListPosts(ctx context.Context, opts client.ListOptions) ([]posts.BlogPost, error)
+	// GetPost Returns single post, found by ID or slug.
+	GetPost(ctx context.Context, id string) (*posts.BlogPost, error)
+	// GetComments Returns the comment thread of a post as a flat list ordered depth first, with up to
+	// posts.MaxCommentReplies replies below every comment.
+	GetComments(ctx context.Context, postID string) ([]posts.Comment, error)
+	// CreatePost Creates new post and returns its ID.
+	CreatePost(ctx context.Context, request posts.CreatePostRequest) (string, error)
+	// CreateComment Creates new comment for a post, replying to another comment when parentID is set, and returns its ID.
+	CreateComment(ctx context.Context, postID, parentID, text string) (string, error)
+	// DeletePost Moves a post to trash.
+	DeletePost(ctx context.Context, id string) error
+	// DeleteComment Moves a comment and its replies to trash.
+	DeleteComment(ctx context.Context, postID, commentID string) error
+	// GetTags Returns all tags in use with their post counts.
+	GetTags(ctx context.Context) ([]posts.Tag, error)
+	// ExportContent Writes every post followed by its comments to w as NDJSON.
+	ExportContent(ctx context.Context, w io.Writer) error
+	// ImportContent Imports posts and comments from NDJSON read from r. Nothing is stored on dry runs.
+	ImportContent(ctx context.Context, r io.Reader, dryRun bool) (*posts.ImportResult, error)
+	// ReindexBlogPosts Renders again HTML, excerpt and backfilled slug of every post and returns how many changed.
+	ReindexBlogPosts(ctx context.Context) (int64, error)
+}
+
+// Stats Summary of the blog contents.
+type Stats struct {
+	Posts    int                  `json:"posts"`
+	ByStatus map[posts.Status]int `json:"by_status"`
+	Comments int                  `json:"comments"`
+	Tags     int                  `json:"tags"`
+	// TopTags Most used tags, up to topTagsSize.
+	TopTags []posts.Tag `json:"top_tags"`
+}
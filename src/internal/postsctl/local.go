@@ -0,0 +1,124 @@
+package postsctl
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/MatiasKopp/prosig-code-challenge/client"
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+const (
+	// pageSize Items read per page when every item is needed.
+	pageSize = 100
+)
+
+// localBackend Backend working directly on a database through the posts service.
+type localBackend struct {
+	Service posts.Service
+}
+
+// NewLocalBackend Returns new backend working directly on a database through provided service.
+func NewLocalBackend(service posts.Service) (Backend, error) {
+	return &localBackend{
+		Service: service,
+	}, nil
+}
+
+// ListPosts Returns a page of posts.
+func (b *localBackend) ListPosts(_ context.Context, opts client.ListOptions) ([]posts.BlogPost, error) {
+	p := httputil.Pagination{Page: max(opts.Page, 1), Limit: opts.Limit}
+	if p.Limit < 1 {
+		p.Limit = httputil.DefaultLimit
+	}
+	p.Offset = (p.Page - 1) * p.Limit
+
+	return b.Service.GetAllBlogPosts(p.Limit, p.Offset, posts.ReadOptions{
+		IncludeUnpublished: true,
+		IncludeUnapproved:  true,
+		Tags:               opts.Tags,
+		MatchAllTags:       opts.MatchAllTags,
+		Filter:             opts.Filter,
+	})
+}
+
+// GetPost Returns single post, found by ID or slug.
+func (b *localBackend) GetPost(_ context.Context, id string) (*posts.BlogPost, error) {
+	return b.Service.GetBlogPost(id, posts.ReadOptions{IncludeUnpublished: true, IncludeUnapproved: true})
+}
+
+// GetComments Returns the comment thread of a post as a flat list ordered depth first, with up to
+// posts.MaxCommentReplies replies below every comment.
+func (b *localBackend) GetComments(_ context.Context, postID string) ([]posts.Comment, error) {
+	var thread []posts.Comment
+	for offset := 0; ; offset += pageSize {
+		comments, err := b.Service.GetComments(postID, posts.CommentsQuery{
+			MaxDepth:          posts.MaxCommentDepth,
+			Limit:             pageSize,
+			Offset:            offset,
+			IncludeUnapproved: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		thread = append(thread, comments...)
+
+		topLevel := 0
+		for _, comment := range comments {
+			if comment.ParentID == "" {
+				topLevel++
+			}
+		}
+		if topLevel < pageSize {
+			return thread, nil
+		}
+	}
+}
+
+// CreatePost Creates new post and returns its ID.
+func (b *localBackend) CreatePost(_ context.Context, request posts.CreatePostRequest) (string, error) {
+	return b.Service.CreateBlogPost(request)
+}
+
+// CreateComment Creates new comment for a post, replying to another comment when parentID is set, and returns its ID.
+func (b *localBackend) CreateComment(_ context.Context, postID, parentID, text string) (string, error) {
+	if parentID != "" {
+		return b.Service.CreateReply(postID, parentID, text)
+	}
+	return b.Service.CreateComment(postID, text)
+}
+
+// DeletePost Moves a post to trash.
+func (b *localBackend) DeletePost(_ context.Context, id string) error {
+	return b.Service.DeleteBlogPost(id)
+}
+
+// DeleteComment Moves a comment and its replies to trash.
+func (b *localBackend) DeleteComment(_ context.Context, postID, commentID string) error {
+	return b.Service.DeleteComment(postID, commentID)
+}
+
+// GetTags Returns all tags in use with their post counts.
+func (b *localBackend) GetTags(_ context.Context) ([]posts.Tag, error) {
+	return b.Service.GetTags(posts.ReadOptions{IncludeUnpublished: true})
+}
+
+// ExportContent Writes every post followed by its comments to w as NDJSON.
+func (b *localBackend) ExportContent(_ context.Context, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	return b.Service.ExportContent(func(record posts.ExportRecord) error {
+		return encoder.Encode(record)
+	})
+}
+
+// ImportContent Imports posts and comments from NDJSON read from r. Nothing is stored on dry runs.
+func (b *localBackend) ImportContent(_ context.Context, r io.Reader, dryRun bool) (*posts.ImportResult, error) {
+	return b.Service.ImportContent(r, dryRun)
+}
+
+// ReindexBlogPosts Renders again HTML, excerpt and backfilled slug of every post and returns how many changed.
+func (b *localBackend) ReindexBlogPosts(_ context.Context) (int64, error) {
+	return b.Service.ReindexBlogPosts()
+}
@@ -0,0 +1,133 @@
+package postsctl
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Migrate Applies the SQL migrations found in dir that db lacks, in file name order, and returns their versions.
+// A migration version is the file name up to its first underscore. Every migration runs in its own transaction
+// and is recorded in the schema_migrations table. Migrations up to baseline, when set, are only recorded, for
+// databases migrated before they were tracked.
+func Migrate(db *sql.DB, dir, baseline string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no migrations found in (%s)", dir)
+	}
+	slices.Sort(files)
+
+	versions := make([]string, len(files))
+	for i, file := range files {
+		versions[i], _, _ = strings.Cut(filepath.Base(file), "_")
+	}
+	if baseline != "" && !slices.Contains(versions, baseline) {
+		return nil, fmt.Errorf("baseline (%s) is not a known migration version", baseline)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version TEXT PRIMARY KEY,
+			applied_at DATETIME NOT NULL
+		)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applied) == 0 && baseline == "" {
+		var tables int
+		err := db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'blog_posts'`).Scan(&tables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect database: %w", err)
+		}
+		if tables > 0 {
+			return nil, errors.New("database has tables but no migration history, set -baseline to the last migration version it has")
+		}
+	}
+
+	// Without a baseline its index is -1, so every migration runs.
+	baselineIndex := slices.Index(versions, baseline)
+
+	migrated := []string{}
+	for i, file := range files {
+		version := versions[i]
+		if applied[version] {
+			continue
+		}
+
+		script := ""
+		if i > baselineIndex {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return migrated, fmt.Errorf("failed to read migration (%s): %w", file, err)
+			}
+			script = string(content)
+		}
+		if err := applyMigration(db, version, script); err != nil {
+			return migrated, err
+		}
+		if script != "" {
+			migrated = append(migrated, version)
+		}
+	}
+
+	return migrated, nil
+}
+
+// appliedMigrations Returns the versions of the migrations recorded as applied.
+func appliedMigrations(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[string]bool{}
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration Runs a migration script, when not empty, and records its version in a single transaction.
+func applyMigration(db *sql.DB, version, script string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if script != "" {
+		if _, err := tx.Exec(script); err != nil {
+			return fmt.Errorf("failed to apply migration (%s): %w", version, err)
+		}
+	}
+
+	_, err = tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, version, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record migration (%s): %w", version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package postsctl
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeMigrations Writes SQL migrations into dir, by file name.
+func writeMigrations(t *testing.T, dir string, migrations map[string]string) {
+	t.Helper()
+
+	for name, script := range migrations {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o644); err != nil {
+			t.Fatalf("failed to write migration: %v", err)
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	initial := map[string]string{
+		"100_blog_posts.sql": "CREATE TABLE blog_posts (id INTEGER PRIMARY KEY, title TEXT);",
+		"200_titles.sql":     "INSERT INTO blog_posts (title) VALUES ('first');\nINSERT INTO blog_posts (title) VALUES ('second');",
+	}
+
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, db *sql.DB, dir string)
+		added    map[string]string
+		baseline string
+		want     []string
+		wantErr  string
+		wantRows int
+	}{
+		{
+			name:     "fresh_database",
+			want:     []string{"100", "200"},
+			wantRows: 2,
+		},
+		{
+			name: "only_pending",
+			setup: func(t *testing.T, db *sql.DB, dir string) {
+				if _, err := Migrate(db, dir, ""); err != nil {
+					t.Fatalf("failed to migrate: %v", err)
+				}
+			},
+			added:    map[string]string{"300_more.sql": "INSERT INTO blog_posts (title) VALUES ('third');"},
+			want:     []string{"300"},
+			wantRows: 3,
+		},
+		{
+			name: "untracked_database",
+			setup: func(t *testing.T, db *sql.DB, _ string) {
+				if _, err := db.Exec("CREATE TABLE blog_posts (id INTEGER PRIMARY KEY, title TEXT)"); err != nil {
+					t.Fatalf("failed to create table: %v", err)
+				}
+			},
+			wantErr: "no migration history",
+		},
+		{
+			name: "baseline_recorded_only",
+			setup: func(t *testing.T, db *sql.DB, _ string) {
+				if _, err := db.Exec("CREATE TABLE blog_posts (id INTEGER PRIMARY KEY, title TEXT)"); err != nil {
+					t.Fatalf("failed to create table: %v", err)
+				}
+			},
+			baseline: "100",
+			want:     []string{"200"},
+			wantRows: 2,
+		},
+		{
+			name:     "unknown_baseline",
+			baseline: "150",
+			wantErr:  "baseline (150) is not a known migration version",
+		},
+		{
+			name:     "failed_migration_stops",
+			added:    map[string]string{"300_broken.sql": "INSERT INTO missing VALUES (1);"},
+			want:     []string{"100", "200"},
+			wantErr:  "failed to apply migration (300)",
+			wantRows: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeMigrations(t, dir, initial)
+
+			db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "posts.db"))
+			if err != nil {
+				t.Fatalf("failed to open database: %v", err)
+			}
+			defer db.Close()
+
+			if tt.setup != nil {
+				tt.setup(t, db, dir)
+			}
+			writeMigrations(t, dir, tt.added)
+
+			got, err := Migrate(db, dir, tt.baseline)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Migrate() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Migrate() = %v, want %v", got, tt.want)
+			}
+			if tt.wantRows == 0 {
+				return
+			}
+
+			var rows int
+			if err := db.QueryRow("SELECT count(*) FROM blog_posts").Scan(&rows); err != nil {
+				t.Fatalf("failed to count rows: %v", err)
+			}
+			if rows != tt.wantRows {
+				t.Errorf("got %d rows, want %d", rows, tt.wantRows)
+			}
+
+			// Running again applies nothing, failed migrations aside.
+			again, err := Migrate(db, dir, "")
+			if tt.wantErr == "" && (err != nil || len(again) != 0) {
+				t.Errorf("Migrate() again = %v, %v", again, err)
+			}
+		})
+	}
+}
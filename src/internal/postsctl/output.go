@@ -0,0 +1,71 @@
+package postsctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// OutputTable Human readable output, aligned in columns.
+	OutputTable = "table"
+	// OutputJSON Machine readable output, as indented JSON.
+	OutputJSON = "json"
+)
+
+// printer Writes command results in the requested output format.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// json Reports whether results are printed as JSON.
+func (p printer) json() bool {
+	return p.format == OutputJSON
+}
+
+// printJSON Writes v as indented JSON.
+func (p printer) printJSON(v any) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printTable Writes rows aligned in columns under headers.
+func (p printer) printTable(headers []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printFields Writes name and value pairs aligned in two columns.
+func (p printer) printFields(fields [][2]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "%s:\t%s\n", field[0], field[1])
+	}
+	return tw.Flush()
+}
+
+// formatTime Formats a time for tables, empty when it is not set.
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// oneLine Shortens text to a single line of up to size characters for tables.
+func oneLine(text string, size int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > size {
+		return string(runes[:size-1]) + "…"
+	}
+	return text
+}
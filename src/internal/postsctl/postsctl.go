@@ -0,0 +1,728 @@
+package postsctl
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/client"
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/internal/app"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	// topTagsSize Tags listed by stats.
+	topTagsSize = 10
+)
+
+var (
+	// errUsage Command line is invalid, its usage was already printed.
+	errUsage = errors.New("invalid usage")
+
+	// commands Available commands, in the order they are listed in the usage.
+	commands = []command{
+		{name: "list", summary: "Lists posts", run: (*cli).list},
+		{name: "show", summary: "Shows a post with its comments", run: (*cli).show},
+		{name: "create", summary: "Creates a post", run: (*cli).create},
+		{name: "comment", summary: "Comments on a post, or replies to one of its comments", run: (*cli).comment},
+		{name: "delete", summary: "Moves a post or a comment to trash", run: (*cli).delete},
+		{name: "import", summary: "Imports posts and comments from NDJSON", run: (*cli).importContent},
+		{name: "export", summary: "Exports posts and comments as NDJSON", run: (*cli).exportContent},
+		{name: "migrate", summary: "Applies pending SQL migrations to the database", run: (*cli).migrate},
+		{name: "reindex", summary: "Renders again the HTML, excerpt and backfilled slug of every post", run: (*cli).reindex},
+		{name: "stats", summary: "Summarizes posts, comments and tags", run: (*cli).stats},
+	}
+)
+
+// command Subcommand of postsctl.
+type command struct {
+	name    string
+	summary string
+	run     func(c *cli, ctx context.Context, args []string) error
+}
+
+// cli State shared by the commands of a single run.
+type cli struct {
+	stdin  io.Reader
+	stderr io.Writer
+	out    printer
+
+	dbLocation string
+	apiURL     string
+	adminToken string
+
+	db *sql.DB
+}
+
+// stringsFlag Flag that can be repeated, collecting every value.
+type stringsFlag []string
+
+// String Returns the values joined by commas.
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+// Set Adds a value.
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// Run Runs postsctl with provided arguments, program name excluded, and returns its exit code.
+// Commands work directly on the database file set by -db, or DB_LOCATION, or through the HTTP API at the URL
+// set by -url, or POSTSCTL_URL, with the admin token set by -token, or ADMIN_TOKEN.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	c := &cli{stdin: stdin, stderr: stderr}
+
+	fs := flag.NewFlagSet("postsctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&c.dbLocation, "db", "", "SQLite database file to work on directly (default $DB_LOCATION)")
+	fs.StringVar(&c.apiURL, "url", "", "base URL of the API to work through (default $POSTSCTL_URL)")
+	fs.StringVar(&c.adminToken, "token", os.Getenv("ADMIN_TOKEN"), "admin token sent to the API")
+	output := fs.String("output", OutputTable, "output format, either table or json")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: postsctl [flags] <command> [command flags] [args]")
+		fmt.Fprintln(stderr, "\nCommands:")
+		for _, cmd := range commands {
+			fmt.Fprintf(stderr, "  %-8s %s\n", cmd.name, cmd.summary)
+		}
+		fmt.Fprintln(stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitCode(err)
+	}
+	if *output != OutputTable && *output != OutputJSON {
+		fmt.Fprintf(stderr, "postsctl: output must be either %s or %s\n", OutputTable, OutputJSON)
+		return 2
+	}
+	c.out = printer{w: stdout, format: *output}
+
+	if c.dbLocation != "" && c.apiURL != "" {
+		fmt.Fprintln(stderr, "postsctl: -db and -url cannot be used together")
+		return 2
+	}
+	if c.dbLocation == "" && c.apiURL == "" {
+		c.apiURL = os.Getenv("POSTSCTL_URL")
+		if c.apiURL == "" {
+			c.dbLocation = os.Getenv("DB_LOCATION")
+		}
+	}
+
+	name := fs.Arg(0)
+	i := slices.IndexFunc(commands, func(cmd command) bool { return cmd.name == name })
+	if i < 0 {
+		if name != "" {
+			fmt.Fprintf(stderr, "postsctl: unknown command %q\n", name)
+		}
+		fs.Usage()
+		return 2
+	}
+
+	defer func() {
+		if c.db != nil {
+			c.db.Close()
+		}
+	}()
+
+	if err := commands[i].run(c, ctx, fs.Args()[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "postsctl: %s\n", err)
+		}
+		return exitCode(err)
+	}
+	return 0
+}
+
+// exitCode Returns the exit code of a run failed with err.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	}
+	return 1
+}
+
+// flags Returns the flag set of a command, printing its usage on errors.
+func (c *cli) flags(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("postsctl "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: postsctl %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse Parses command args, checking the amount of positional args is between minArgs and maxArgs.
+func (c *cli) parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() < minArgs || fs.NArg() > maxArgs {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// openDB Opens the database file, creating it only when create is set.
+func (c *cli) openDB(create bool) (*sql.DB, error) {
+	if c.dbLocation == "" {
+		return nil, errors.New("either -db or -url must be set")
+	}
+	if !create {
+		if _, err := os.Stat(c.dbLocation); err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+	}
+
+	db, err := app.OpenDB(c.dbLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	c.db = db
+	return db, nil
+}
+
+// backend Returns the backend commands work on, through the API when its URL is set.
+func (c *cli) backend() (Backend, error) {
+	if c.apiURL != "" {
+		apiClient, err := client.NewClient(client.Config{BaseURL: c.apiURL, AdminToken: c.adminToken})
+		if err != nil {
+			return nil, err
+		}
+		return NewRemoteBackend(apiClient)
+	}
+
+	db, err := c.openDB(false)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := posts.NewRepository(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository: %w", err)
+	}
+	// Posts and comments are checked with the configuration the API runs with.
+	cfg, err := app.ParseConfig()
+	if err != nil {
+		return nil, err
+	}
+	service, err := app.NewPostsService(cfg, repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service: %w", err)
+	}
+	return NewLocalBackend(service)
+}
+
+// list Lists posts, a page of them unless every one is requested.
+func (c *cli) list(ctx context.Context, args []string) error {
+	fs := c.flags("list", "[flags]")
+	page := fs.Int("page", 1, "page to list")
+	limit := fs.Int("limit", httputil.DefaultLimit, "posts per page")
+	all := fs.Bool("all", false, "lists every post, ignoring -page and -limit")
+	var tags stringsFlag
+	fs.Var(&tags, "tag", "only lists posts with this tag, can be repeated")
+	matchAll := fs.Bool("match-all", false, "only lists posts with every tag instead of any")
+	status := fs.String("status", "", "only lists posts with this status")
+	author := fs.String("author", "", "only lists posts written by this author")
+	title := fs.String("title", "", "only lists posts whose title contains this text")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	opts := client.ListOptions{
+		Page:         *page,
+		Limit:        *limit,
+		Tags:         tags,
+		MatchAllTags: *matchAll,
+		Filter: posts.ListFilter{
+			Status:        posts.Status(*status),
+			Author:        *author,
+			TitleContains: *title,
+		},
+	}
+
+	var list []posts.BlogPost
+	if *all {
+		list, err = allPosts(ctx, b, opts)
+	} else {
+		list, err = b.ListPosts(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.out.json() {
+		if list == nil {
+			list = []posts.BlogPost{}
+		}
+		return c.out.printJSON(list)
+	}
+
+	rows := make([][]string, len(list))
+	for i, post := range list {
+		rows[i] = []string{
+			post.ID,
+			post.Slug,
+			string(post.Status),
+			strconv.Itoa(post.CommentCount),
+			formatTime(&post.CreatedAt),
+			oneLine(post.Title, 60),
+		}
+	}
+	return c.out.printTable([]string{"ID", "SLUG", "STATUS", "COMMENTS", "CREATED", "TITLE"}, rows)
+}
+
+// show Shows a post, with its comment thread unless left out.
+func (c *cli) show(ctx context.Context, args []string) error {
+	fs := c.flags("show", "[flags] <post ID or slug>")
+	withComments := fs.Bool("comments", true, "shows the comment thread, only readable on published posts")
+	if err := c.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	post, err := b.GetPost(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	// Comments of unpublished posts cannot be read.
+	*withComments = *withComments && post.Status == posts.StatusPublished
+
+	var comments []posts.Comment
+	if *withComments {
+		comments, err = b.GetComments(ctx, post.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.out.json() {
+		if *withComments {
+			post.Comments = posts.CommentTree(comments)
+			if post.Comments == nil {
+				post.Comments = []posts.Comment{}
+			}
+		}
+		return c.out.printJSON(post)
+	}
+
+	err = c.out.printFields([][2]string{
+		{"ID", post.ID},
+		{"Slug", post.Slug},
+		{"Title", post.Title},
+		{"Author", post.Author},
+		{"Status", string(post.Status)},
+		{"Publish at", formatTime(post.PublishAt)},
+		{"Format", string(post.ContentFormat)},
+		{"Tags", strings.Join(post.Tags, ", ")},
+		{"Comments", strconv.Itoa(post.CommentCount)},
+		{"Created", formatTime(&post.CreatedAt)},
+		{"Updated", formatTime(&post.UpdatedAt)},
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out.w, "\n%s\n", post.Content)
+
+	if !*withComments || len(comments) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(c.out.w)
+	rows := make([][]string, len(comments))
+	for i, comment := range comments {
+		rows[i] = []string{
+			comment.ID,
+			string(comment.ModerationStatus),
+			formatTime(&comment.CreatedAt),
+			strings.Repeat("  ", comment.Depth) + oneLine(comment.CommentText, 80),
+		}
+	}
+	return c.out.printTable([]string{"COMMENT", "MODERATION", "CREATED", "TEXT"}, rows)
+}
+
+// create Creates a post from flags, reading its content from a file when requested.
+func (c *cli) create(ctx context.Context, args []string) error {
+	fs := c.flags("create", "[flags]")
+	title := fs.String("title", "", "title of the post")
+	content := fs.String("content", "", "content of the post")
+	file := fs.String("file", "", "file to read the content from instead, - for stdin")
+	author := fs.String("author", "", "author of the post")
+	var tags stringsFlag
+	fs.Var(&tags, "tag", "tag of the post, can be repeated")
+	status := fs.String("status", "", "initial status, draft unless -publish-at is set")
+	format := fs.String("format", "", "format the content is written in, either text or markdown")
+	publishAt := fs.String("publish-at", "", "RFC 3339 time the post is scheduled for")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	request := posts.CreatePostRequest{
+		Title:         *title,
+		Content:       *content,
+		Author:        *author,
+		Tags:          tags,
+		Status:        posts.Status(*status),
+		ContentFormat: posts.ContentFormat(*format),
+	}
+	if *file != "" {
+		if *content != "" {
+			return errors.New("-content and -file cannot be used together")
+		}
+		data, err := c.readInput(*file)
+		if err != nil {
+			return err
+		}
+		request.Content = string(data)
+	}
+	if *publishAt != "" {
+		at, err := time.Parse(time.RFC3339, *publishAt)
+		if err != nil {
+			return fmt.Errorf("-publish-at must be an RFC 3339 time: %w", err)
+		}
+		request.PublishAt = &at
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	postID, err := b.CreatePost(ctx, request)
+	if err != nil {
+		return err
+	}
+	return c.printID(postID)
+}
+
+// comment Comments on a post, or replies to one of its comments.
+func (c *cli) comment(ctx context.Context, args []string) error {
+	fs := c.flags("comment", "[flags] <post ID or slug> <text>")
+	replyTo := fs.String("reply-to", "", "comment the new comment replies to")
+	if err := c.parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	commentID, err := b.CreateComment(ctx, fs.Arg(0), *replyTo, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	return c.printID(commentID)
+}
+
+// delete Moves a post, or one of its comments, to trash.
+func (c *cli) delete(ctx context.Context, args []string) error {
+	fs := c.flags("delete", "[flags] <post ID or slug>")
+	commentID := fs.String("comment", "", "comment of the post to delete instead, along with its replies")
+	if err := c.parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	deleted := fs.Arg(0)
+	if *commentID != "" {
+		deleted = *commentID
+		err = b.DeleteComment(ctx, fs.Arg(0), *commentID)
+	} else {
+		err = b.DeletePost(ctx, fs.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.out.json() {
+		return c.out.printJSON(map[string]string{"deleted": deleted})
+	}
+	_, err = fmt.Fprintf(c.out.w, "Moved %s to trash\n", deleted)
+	return err
+}
+
+// importContent Imports posts and comments from an NDJSON file, failing when any line could not be imported.
+func (c *cli) importContent(ctx context.Context, args []string) error {
+	fs := c.flags("import", "[flags] [file]")
+	dryRun := fs.Bool("dry-run", false, "validates the file without storing anything")
+	if err := c.parse(fs, args, 0, 1); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	input, err := c.openInput(cmp.Or(fs.Arg(0), "-"))
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	result, err := b.ImportContent(ctx, input, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if c.out.json() {
+		err = c.out.printJSON(result)
+	} else {
+		err = c.out.printFields([][2]string{
+			{"Posts", strconv.Itoa(result.Posts)},
+			{"Comments", strconv.Itoa(result.Comments)},
+			{"Dry run", strconv.FormatBool(result.DryRun)},
+		})
+		if err == nil && len(result.Errors) > 0 {
+			fmt.Fprintln(c.out.w)
+			rows := make([][]string, len(result.Errors))
+			for i, importErr := range result.Errors {
+				rows[i] = []string{strconv.Itoa(importErr.Line), importErr.Message}
+			}
+			err = c.out.printTable([]string{"LINE", "ERROR"}, rows)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d lines could not be imported", len(result.Errors))
+	}
+	return nil
+}
+
+// exportContent Exports posts and comments as NDJSON, whatever the output format.
+func (c *cli) exportContent(ctx context.Context, args []string) error {
+	fs := c.flags("export", "[flags]")
+	file := fs.String("file", "-", "file to write the export to, - for stdout")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	if *file == "-" {
+		return b.ExportContent(ctx, c.out.w)
+	}
+
+	output, err := os.Create(*file)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := b.ExportContent(ctx, output); err != nil {
+		output.Close()
+		return err
+	}
+	return output.Close()
+}
+
+// migrate Applies pending SQL migrations to the database, which is created when missing.
+func (c *cli) migrate(_ context.Context, args []string) error {
+	fs := c.flags("migrate", "[flags]")
+	dir := fs.String("dir", "migrations", "directory holding the SQL migrations")
+	baseline := fs.String("baseline", "", "last migration version an untracked database already has")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if c.apiURL != "" {
+		return ErrNotSupported
+	}
+
+	db, err := c.openDB(true)
+	if err != nil {
+		return err
+	}
+
+	applied, err := Migrate(db, *dir, *baseline)
+	if err != nil {
+		return err
+	}
+
+	if c.out.json() {
+		return c.out.printJSON(map[string][]string{"applied": applied})
+	}
+	if len(applied) == 0 {
+		_, err = fmt.Fprintln(c.out.w, "Database is up to date")
+		return err
+	}
+	for _, version := range applied {
+		fmt.Fprintf(c.out.w, "Applied %s\n", version)
+	}
+	return nil
+}
+
+// reindex Renders again the HTML, excerpt and backfilled slug of every post.
+func (c *cli) reindex(ctx context.Context, args []string) error {
+	fs := c.flags("reindex", "")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	reindexed, err := b.ReindexBlogPosts(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.out.json() {
+		return c.out.printJSON(map[string]int64{"reindexed": reindexed})
+	}
+	_, err = fmt.Fprintf(c.out.w, "Reindexed %d posts\n", reindexed)
+	return err
+}
+
+// stats Summarizes posts, comments and tags.
+func (c *cli) stats(ctx context.Context, args []string) error {
+	fs := c.flags("stats", "")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	b, err := c.backend()
+	if err != nil {
+		return err
+	}
+
+	list, err := allPosts(ctx, b, client.ListOptions{})
+	if err != nil {
+		return err
+	}
+	tags, err := b.GetTags(ctx)
+	if err != nil {
+		return err
+	}
+
+	stats := Stats{
+		Posts:    len(list),
+		ByStatus: map[posts.Status]int{},
+		Tags:     len(tags),
+	}
+	for _, post := range list {
+		stats.ByStatus[post.Status]++
+		stats.Comments += post.CommentCount
+	}
+	slices.SortStableFunc(tags, func(a, b posts.Tag) int {
+		return cmp.Or(cmp.Compare(b.PostCount, a.PostCount), strings.Compare(a.Slug, b.Slug))
+	})
+	stats.TopTags = tags[:min(len(tags), topTagsSize)]
+	if stats.TopTags == nil {
+		stats.TopTags = []posts.Tag{}
+	}
+
+	if c.out.json() {
+		return c.out.printJSON(stats)
+	}
+
+	fields := [][2]string{{"Posts", strconv.Itoa(stats.Posts)}}
+	for _, status := range []posts.Status{posts.StatusDraft, posts.StatusScheduled, posts.StatusPublished, posts.StatusArchived} {
+		fields = append(fields, [2]string{"  " + string(status), strconv.Itoa(stats.ByStatus[status])})
+	}
+	fields = append(fields,
+		[2]string{"Comments", strconv.Itoa(stats.Comments)},
+		[2]string{"Tags", strconv.Itoa(stats.Tags)},
+	)
+	if err := c.out.printFields(fields); err != nil {
+		return err
+	}
+
+	if len(stats.TopTags) == 0 {
+		return nil
+	}
+	fmt.Fprintln(c.out.w)
+	rows := make([][]string, len(stats.TopTags))
+	for i, tag := range stats.TopTags {
+		rows[i] = []string{tag.Slug, strconv.Itoa(tag.PostCount)}
+	}
+	return c.out.printTable([]string{"TAG", "POSTS"}, rows)
+}
+
+// printID Prints the ID of a created post or comment.
+func (c *cli) printID(id string) error {
+	if c.out.json() {
+		return c.out.printJSON(map[string]string{"id": id})
+	}
+	_, err := fmt.Fprintln(c.out.w, id)
+	return err
+}
+
+// openInput Opens a file to read from, stdin for -.
+func (c *cli) openInput(file string) (io.ReadCloser, error) {
+	if file == "-" {
+		return io.NopCloser(c.stdin), nil
+	}
+	input, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %w", err)
+	}
+	return input, nil
+}
+
+// readInput Reads a whole file, stdin for -.
+func (c *cli) readInput(file string) ([]byte, error) {
+	input, err := c.openInput(file)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+	return io.ReadAll(input)
+}
+
+// allPosts Returns every post matching opts, reading them page by page.
+func allPosts(ctx context.Context, b Backend, opts client.ListOptions) ([]posts.BlogPost, error) {
+	opts.Limit = pageSize
+	all := []posts.BlogPost{}
+	for opts.Page = 1; ; opts.Page++ {
+		page, err := b.ListPosts(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+
+		// A short page is the last one.
+		if len(page) < pageSize {
+			return all, nil
+		}
+	}
+}
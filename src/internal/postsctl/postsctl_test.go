@@ -0,0 +1,308 @@
+package postsctl
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/MatiasKopp/prosig-code-challenge/internal/app"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+const testAdminToken = "test-token"
+
+// runResult Outcome of a single postsctl run.
+type runResult struct {
+	stdout string
+	stderr string
+	code   int
+}
+
+// run Runs postsctl with provided stdin and args.
+func run(t *testing.T, stdin string, args ...string) runResult {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := Run(t.Context(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return runResult{stdout: stdout.String(), stderr: stderr.String(), code: code}
+}
+
+// mustRun Runs postsctl and fails the test unless it succeeds, decoding its JSON output into out when set.
+func mustRun(t *testing.T, out any, args ...string) string {
+	t.Helper()
+
+	result := run(t, "", args...)
+	if result.code != 0 {
+		t.Fatalf("postsctl %v exited with %d: %s", args, result.code, result.stderr)
+	}
+	if out != nil {
+		if err := json.Unmarshal([]byte(result.stdout), out); err != nil {
+			t.Fatalf("failed to decode output of postsctl %v: %v\n%s", args, err, result.stdout)
+		}
+	}
+	return result.stdout
+}
+
+// migratedDB Returns a database file migrated from scratch with postsctl.
+func migratedDB(t *testing.T) string {
+	t.Helper()
+
+	dbLocation := filepath.Join(t.TempDir(), "posts.db")
+	mustRun(t, nil, "-db", dbLocation, "migrate", "-dir", filepath.Join("..", "..", "..", "migrations"))
+	return dbLocation
+}
+
+// backendArgs Returns the flags making postsctl work on a fresh database, directly or through the API.
+func backendArgs(t *testing.T, remote bool) []string {
+	t.Helper()
+
+	dbLocation := migratedDB(t)
+	if !remote {
+		return []string{"-db", dbLocation}
+	}
+
+	t.Setenv("DB_LOCATION", dbLocation)
+	t.Setenv("ADMIN_TOKEN", testAdminToken)
+	a := app.New()
+	t.Cleanup(a.Stop)
+	server := httptest.NewServer(a.Router)
+	t.Cleanup(server.Close)
+	return []string{"-url", server.URL, "-token", testAdminToken}
+}
+
+func TestRun_Commands(t *testing.T) {
+	for _, remote := range []bool{false, true} {
+		name := "local"
+		if remote {
+			name = "remote"
+		}
+		t.Run(name, func(t *testing.T) {
+			base := backendArgs(t, remote)
+			args := func(args ...string) []string {
+				return append(slices.Clone(base), args...)
+			}
+			jsonArgs := func(args ...string) []string {
+				return append(append(slices.Clone(base), "-output", "json"), args...)
+			}
+
+			var created map[string]string
+			mustRun(t, &created, jsonArgs("create", "-title", "Release notes", "-content", "# New *things*",
+				"-format", "markdown", "-tag", "go", "-tag", "ops", "-status", "published")...)
+			postID := created["id"]
+
+			result := run(t, "Draft body", args("create", "-title", "Work in progress", "-file", "-", "-tag", "go")...)
+			if result.code != 0 {
+				t.Fatalf("create from stdin exited with %d: %s", result.code, result.stderr)
+			}
+
+			commentID := strings.TrimSpace(mustRun(t, nil, args("comment", "release-notes", "Great")...))
+			mustRun(t, nil, args("comment", "-reply-to", commentID, postID, "Thanks")...)
+
+			var list []posts.BlogPost
+			mustRun(t, &list, jsonArgs("list")...)
+			if len(list) != 2 {
+				t.Errorf("list returned %d posts, want 2", len(list))
+			}
+			mustRun(t, &list, jsonArgs("list", "-all", "-status", "draft")...)
+			if len(list) != 1 || list[0].Title != "Work in progress" || list[0].Content != "Draft body" {
+				t.Errorf("list -status draft = %+v", list)
+			}
+
+			var post posts.BlogPost
+			mustRun(t, &post, jsonArgs("show", "release-notes")...)
+			if post.ID != postID || post.ContentHTML != "<h1>New <em>things</em></h1>\n" {
+				t.Errorf("show = %+v", post)
+			}
+			if len(post.Comments) != 1 || post.Comments[0].CommentText != "Great" ||
+				len(post.Comments[0].Replies) != 1 || post.Comments[0].Replies[0].CommentText != "Thanks" {
+				t.Errorf("show comments = %+v", post.Comments)
+			}
+
+			var stats Stats
+			mustRun(t, &stats, jsonArgs("stats")...)
+			wantTags := []posts.Tag{{Slug: "go", PostCount: 2}, {Slug: "ops", PostCount: 1}}
+			if stats.Posts != 2 || stats.ByStatus[posts.StatusDraft] != 1 || stats.ByStatus[posts.StatusPublished] != 1 ||
+				stats.Comments != 2 || stats.Tags != 2 || !slices.Equal(stats.TopTags, wantTags) {
+				t.Errorf("stats = %+v", stats)
+			}
+
+			exportFile := filepath.Join(t.TempDir(), "export.ndjson")
+			mustRun(t, nil, args("export", "-file", exportFile)...)
+			export, err := os.ReadFile(exportFile)
+			if err != nil {
+				t.Fatalf("failed to read export: %v", err)
+			}
+			if lines := strings.Count(string(export), "\n"); lines != 4 {
+				t.Errorf("export has %d lines, want 4", lines)
+			}
+			if stdout := mustRun(t, nil, args("export")...); stdout != string(export) {
+				t.Errorf("export to stdout = %s, want %s", stdout, export)
+			}
+
+			var imported posts.ImportResult
+			mustRun(t, &imported, jsonArgs("import", "-dry-run", exportFile)...)
+			if !imported.DryRun || imported.Posts != 2 || imported.Comments != 2 {
+				t.Errorf("import = %+v", imported)
+			}
+			result = run(t, "not json\n", args("import")...)
+			if result.code != 1 || !strings.Contains(result.stdout, "invalid JSON") {
+				t.Errorf("import of invalid lines exited with %d: %s", result.code, result.stdout)
+			}
+
+			mustRun(t, nil, args("delete", "-comment", commentID, postID)...)
+			mustRun(t, &post, jsonArgs("show", postID)...)
+			if len(post.Comments) != 0 {
+				t.Errorf("show after deleting comment = %+v", post.Comments)
+			}
+			mustRun(t, nil, args("delete", "release-notes")...)
+			result = run(t, "", args("show", postID)...)
+			if result.code != 1 || !strings.Contains(result.stderr, posts.ErrBlogPostNotFound.Error()) {
+				t.Errorf("show after deleting post exited with %d: %s", result.code, result.stderr)
+			}
+
+			result = run(t, "", args("reindex")...)
+			if remote {
+				if result.code != 1 || !strings.Contains(result.stderr, ErrNotSupported.Error()) {
+					t.Errorf("remote reindex exited with %d: %s", result.code, result.stderr)
+				}
+			} else if result.code != 0 || result.stdout != "Reindexed 0 posts\n" {
+				t.Errorf("reindex exited with %d: %s%s", result.code, result.stdout, result.stderr)
+			}
+		})
+	}
+}
+
+func TestRun_TableOutput(t *testing.T) {
+	base := backendArgs(t, false)
+	mustRun(t, nil, append(base, "create", "-title", "Table  row\ntitle", "-content", "C", "-status", "published")...)
+
+	stdout := mustRun(t, nil, append(base, "list")...)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("list printed %d lines, want 2:\n%s", len(lines), stdout)
+	}
+	if fields := strings.Fields(lines[0]); !slices.Equal(fields, []string{"ID", "SLUG", "STATUS", "COMMENTS", "CREATED", "TITLE"}) {
+		t.Errorf("got headers %v", fields)
+	}
+	if !strings.Contains(lines[1], "table-row-title  published  0") || !strings.HasSuffix(lines[1], "Table row title") {
+		t.Errorf("got row %q", lines[1])
+	}
+
+	stdout = mustRun(t, nil, append(base, "stats")...)
+	if !strings.HasPrefix(stdout, "Posts:        1\n  draft:      0\n") {
+		t.Errorf("got stats\n%s", stdout)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	dbLocation := migratedDB(t)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{name: "no_command", args: []string{"-db", dbLocation}, wantCode: 2, wantStderr: "Commands:"},
+		{name: "unknown_command", args: []string{"-db", dbLocation, "drop"}, wantCode: 2, wantStderr: `unknown command "drop"`},
+		{name: "help", args: []string{"-h"}, wantCode: 0, wantStderr: "Usage: postsctl"},
+		{name: "command_help", args: []string{"list", "-h"}, wantCode: 0, wantStderr: "Usage: postsctl list"},
+		{name: "unknown_output", args: []string{"-db", dbLocation, "-output", "xml", "list"}, wantCode: 2, wantStderr: "output must be"},
+		{name: "db_and_url", args: []string{"-db", dbLocation, "-url", "http://localhost", "list"}, wantCode: 2, wantStderr: "cannot be used together"},
+		{name: "missing_args", args: []string{"-db", dbLocation, "comment", "post"}, wantCode: 2, wantStderr: "Usage: postsctl comment"},
+		{name: "extra_args", args: []string{"-db", dbLocation, "stats", "now"}, wantCode: 2, wantStderr: "Usage: postsctl stats"},
+		{name: "unknown_flag", args: []string{"-db", dbLocation, "list", "-sort"}, wantCode: 2, wantStderr: "flag provided but not defined"},
+		{name: "missing_db", args: []string{"-db", filepath.Join(t.TempDir(), "none.db"), "list"}, wantCode: 1, wantStderr: "failed to open database"},
+		{name: "remote_migrate", args: []string{"-url", "http://localhost", "migrate"}, wantCode: 1, wantStderr: ErrNotSupported.Error()},
+		{name: "content_and_file", args: []string{"-db", dbLocation, "create", "-title", "T", "-content", "C", "-file", "f"}, wantCode: 1, wantStderr: "cannot be used together"},
+		{name: "bad_request", args: []string{"-db", dbLocation, "create", "-title", "T"}, wantCode: 1, wantStderr: "missing title or content"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_LOCATION", "")
+			t.Setenv("POSTSCTL_URL", "")
+
+			result := run(t, "", tt.args...)
+			if result.code != tt.wantCode {
+				t.Errorf("Run() = %d, want %d", result.code, tt.wantCode)
+			}
+			if !strings.Contains(result.stderr, tt.wantStderr) {
+				t.Errorf("got stderr %q, want it to contain %q", result.stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRun_EnvBackend(t *testing.T) {
+	dbLocation := migratedDB(t)
+	t.Setenv("POSTSCTL_URL", "")
+	t.Setenv("DB_LOCATION", dbLocation)
+
+	if stdout := mustRun(t, nil, "-output", "json", "list"); stdout != "[]\n" {
+		t.Errorf("list = %q, want empty list", stdout)
+	}
+}
+
+func TestRun_LocalContentRules(t *testing.T) {
+	base := backendArgs(t, false)
+	t.Setenv("FILTER_BLOCKED_WORDS", "casino")
+	t.Setenv("COMMENT_MODERATION", "pending")
+
+	result := run(t, "", append(base, "create", "-title", "Offer", "-content", "Visit my casino", "-status", "published")...)
+	if result.code != 1 || !strings.Contains(result.stderr, posts.ErrContentRejected.Error()) {
+		t.Errorf("create with blocked word exited with %d: %s", result.code, result.stderr)
+	}
+
+	mustRun(t, nil, append(base, "create", "-title", "Moderated", "-content", "C", "-status", "published")...)
+	mustRun(t, nil, append(base, "comment", "moderated", "Held back")...)
+
+	var post posts.BlogPost
+	mustRun(t, &post, append(base, "-output", "json", "show", "moderated")...)
+	if len(post.Comments) != 1 || post.Comments[0].ModerationStatus != posts.ModerationPending {
+		t.Errorf("show comments = %+v, want a pending comment", post.Comments)
+	}
+}
+
+func TestRun_ReindexBackfilledSlugs(t *testing.T) {
+	dbLocation := migratedDB(t)
+	base := []string{"-db", dbLocation, "-output", "json"}
+	mustRun(t, nil, append(base, "create", "-title", "日本語 ¿Qué tal?", "-content", "C", "-status", "published")...)
+	mustRun(t, nil, append(base, "create", "-title", "Plain title", "-content", "C", "-status", "published")...)
+
+	// Slugs as the post slugs migration backfilled them.
+	db, err := sql.Open("sqlite3", dbLocation)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`
+		UPDATE blog_posts SET slug = trim(lower(replace(replace(trim(title), ' ', '-'), '/', '-')), '-') || '-' || id;
+		INSERT INTO blog_post_slugs (slug, blog_post_id) SELECT slug, id FROM blog_posts WHERE true ON CONFLICT (slug) DO NOTHING;`,
+	); err != nil {
+		t.Fatalf("failed to backfill slugs: %v", err)
+	}
+
+	var reindexed map[string]int64
+	mustRun(t, &reindexed, append(base, "reindex")...)
+	if reindexed["reindexed"] != 1 {
+		t.Errorf("reindexed %d posts, want 1", reindexed["reindexed"])
+	}
+
+	for _, tt := range []struct{ id, want string }{
+		{id: "日本語-¿qué-tal?-1", want: "日本語-que-tal-1"},
+		{id: "日本語-que-tal-1", want: "日本語-que-tal-1"},
+		{id: "plain-title-2", want: "plain-title-2"},
+	} {
+		var post posts.BlogPost
+		mustRun(t, &post, append(base, "show", tt.id)...)
+		if post.Slug != tt.want {
+			t.Errorf("show %s got slug %q, want %q", tt.id, post.Slug, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package postsctl
+
+import (
+	"context"
+	"io"
+
+	"github.com/MatiasKopp/prosig-code-challenge/client"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// remoteBackend Backend working through the posts API.
+type remoteBackend struct {
+	Client client.Client
+}
+
+// NewRemoteBackend Returns new backend working through the posts API with provided client.
+// The client needs the admin token for most commands.
+func NewRemoteBackend(c client.Client) (Backend, error) {
+	return &remoteBackend{
+		Client: c,
+	}, nil
+}
+
+// ListPosts Returns a page of posts.
+func (b *remoteBackend) ListPosts(ctx context.Context, opts client.ListOptions) ([]posts.BlogPost, error) {
+	response, err := b.Client.ListPosts(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return response.BlogPosts, nil
+}
+
+// GetPost Returns single post, found by ID or slug.
+func (b *remoteBackend) GetPost(ctx context.Context, id string) (*posts.BlogPost, error) {
+	return b.Client.GetPost(ctx, id)
+}
+
+// GetComments Returns the comment thread of a post as a flat list ordered depth first, with up to
+// posts.MaxCommentReplies replies below every comment.
+func (b *remoteBackend) GetComments(ctx context.Context, postID string) ([]posts.Comment, error) {
+	maxDepth := posts.MaxCommentDepth
+	opts := client.CommentsOptions{Limit: pageSize, MaxDepth: &maxDepth, Flat: true}
+
+	var thread []posts.Comment
+	for comment, err := range b.Client.Comments(ctx, postID, opts) {
+		if err != nil {
+			return nil, err
+		}
+		thread = append(thread, comment)
+	}
+	return thread, nil
+}
+
+// CreatePost Creates new post and returns its ID.
+func (b *remoteBackend) CreatePost(ctx context.Context, request posts.CreatePostRequest) (string, error) {
+	return b.Client.CreatePost(ctx, request)
+}
+
+// CreateComment Creates new comment for a post, replying to another comment when parentID is set, and returns its ID.
+func (b *remoteBackend) CreateComment(ctx context.Context, postID, parentID, text string) (string, error) {
+	if parentID != "" {
+		return b.Client.CreateReply(ctx, postID, parentID, text)
+	}
+	return b.Client.CreateComment(ctx, postID, text)
+}
+
+// DeletePost Moves a post to trash.
+func (b *remoteBackend) DeletePost(ctx context.Context, id string) error {
+	return b.Client.DeletePost(ctx, id)
+}
+
+// DeleteComment Moves a comment and its replies to trash.
+func (b *remoteBackend) DeleteComment(ctx context.Context, postID, commentID string) error {
+	return b.Client.DeleteComment(ctx, postID, commentID)
+}
+
+// GetTags Returns all tags in use with their post counts.
+func (b *remoteBackend) GetTags(ctx context.Context) ([]posts.Tag, error) {
+	return b.Client.GetTags(ctx)
+}
+
+// ExportContent Writes every post followed by its comments to w as NDJSON.
+func (b *remoteBackend) ExportContent(ctx context.Context, w io.Writer) error {
+	return b.Client.ExportContent(ctx, w)
+}
+
+// ImportContent Imports posts and comments from NDJSON read from r. Nothing is stored on dry runs.
+func (b *remoteBackend) ImportContent(ctx context.Context, r io.Reader, dryRun bool) (*posts.ImportResult, error) {
+	return b.Client.ImportContent(ctx, r, dryRun)
+}
+
+// ReindexBlogPosts Not supported, the API renders posts as they are written.
+func (b *remoteBackend) ReindexBlogPosts(_ context.Context) (int64, error) {
+	return 0, ErrNotSupported
+}
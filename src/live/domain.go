@@ -0,0 +1,56 @@
+package live
+
+import (
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// MessageType Kind of protocol message.
+type MessageType string
+
+const (
+	// MessageSubscribe Client subscribes to a channel.
+	MessageSubscribe MessageType = "subscribe"
+	// MessageUnsubscribe Client unsubscribes from a channel.
+	MessageUnsubscribe MessageType = "unsubscribe"
+	// MessageCommentCreate Client creates a comment, or a reply when a parent is provided.
+	MessageCommentCreate MessageType = "comment.create"
+	// MessageEvent Server pushes an event of a subscribed channel.
+	MessageEvent MessageType = "event"
+	// MessageAck Server acknowledges a client message.
+	MessageAck MessageType = "ack"
+	// MessageError Server reports why a client message failed.
+	MessageError MessageType = "error"
+)
+
+// Channel Stream of events clients can subscribe to.
+type Channel string
+
+const (
+	// ChannelPosts Posts as they are created or published, unpublished ones included for admins.
+	ChannelPosts Channel = "posts"
+	// ChannelComments Comments of a post as they are created or approved, unapproved ones included for admins.
+	ChannelComments Channel = "comments"
+)
+
+// ClientMessage Message sent by clients. ID is echoed back in the ack or error answering it.
+type ClientMessage struct {
+	Type     MessageType `json:"type"`
+	ID       string      `json:"id"`
+	Channel  Channel     `json:"channel"`
+	PostID   string      `json:"post_id"`
+	ParentID string      `json:"parent_id"`
+	Text     string      `json:"text"`
+}
+
+// ServerMessage Message sent by the server, either an event, an ack or an error.
+type ServerMessage struct {
+	Type      MessageType     `json:"type"`
+	ID        string          `json:"id,omitempty"`
+	Channel   Channel         `json:"channel,omitempty"`
+	PostID    string          `json:"post_id,omitempty"`
+	CommentID string          `json:"comment_id,omitempty"`
+	Event     posts.EventType `json:"event,omitempty"`
+	Data      any             `json:"data,omitempty"`
+	Status    int             `json:"status,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
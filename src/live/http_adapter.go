@@ -0,0 +1,81 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/coder/websocket"
+)
+
+var (
+	// ErrRateLimited Client sent more messages than allowed per second.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrTooManySubscriptions Client holds as many subscriptions as allowed.
+	ErrTooManySubscriptions = errors.New("too many subscriptions")
+	// ErrNotSubscribed Client is not subscribed to the channel it unsubscribes from.
+	ErrNotSubscribed = errors.New("not subscribed")
+
+	errMapper = map[error]int{
+		posts.ErrBlogPostNotFound: http.StatusNotFound,
+		posts.ErrCommentNotFound:  http.StatusNotFound,
+		ErrNotSubscribed:          http.StatusNotFound,
+		posts.ErrorBadRequest:     http.StatusBadRequest,
+		posts.ErrContentRejected:  http.StatusUnprocessableEntity,
+		ErrRateLimited:            http.StatusTooManyRequests,
+		ErrTooManySubscriptions:   http.StatusTooManyRequests,
+	}
+)
+
+// httpAdapter Productive live updates http adapter implementation
+type httpAdapter struct {
+	Service posts.Service
+	Bus     *posts.EventBus
+	Config  Config
+}
+
+// NewHTTPAdapter Returns new productive live updates HTTP adapter implementation, pushing the events of bus.
+func NewHTTPAdapter(service posts.Service, bus *posts.EventBus, cfg Config) (HTTPAdapter, error) {
+	if cfg.Heartbeat <= 0 || cfg.WriteTimeout <= 0 {
+		return nil, fmt.Errorf("invalid heartbeat (%s) or write timeout (%s)", cfg.Heartbeat, cfg.WriteTimeout)
+	}
+	if cfg.SendBuffer < 1 || cfg.MaxSubscriptions < 1 || cfg.MaxMessageSize < 1 || cfg.MessageRate < 1 {
+		return nil, fmt.Errorf("invalid connection limits (%+v)", cfg)
+	}
+
+	return &httpAdapter{
+		Service: service,
+		Bus:     bus,
+		Config:  cfg,
+	}, nil
+}
+
+// Connect Upgrades the request to a WebSocket connection speaking the live updates protocol, authenticated as
+// admin when the upgrade request is. Connections end when the client closes them, falls behind or stops
+// answering pings.
+func (a *httpAdapter) Connect(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: a.Config.OriginPatterns})
+	if err != nil {
+		// Accept already responded with the reason.
+		return
+	}
+	conn.SetReadLimit(a.Config.MaxMessageSize)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	s := newSession(a, conn, httputil.IsAdmin(r))
+	unsubscribe := a.Bus.Subscribe(s.handleEvent)
+	defer unsubscribe()
+
+	go func() {
+		defer cancel()
+		s.writeLoop(ctx)
+	}()
+
+	s.readLoop(ctx)
+	conn.CloseNow()
+}
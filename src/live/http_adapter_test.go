@@ -0,0 +1,313 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/coder/websocket"
+)
+
+// testConfig Connection limits used by tests unless overridden.
+var testConfig = Config{
+	Heartbeat:        time.Hour,
+	WriteTimeout:     time.Second,
+	SendBuffer:       8,
+	MaxSubscriptions: 2,
+	MaxMessageSize:   1024,
+	MessageRate:      100,
+}
+
+// dial Serves the adapter behind the admin token "tok" and connects to it, as admin if requested.
+func dial(t *testing.T, a *httpAdapter, admin bool) *websocket.Conn {
+	t.Helper()
+	server := httptest.NewServer(httputil.Authenticate("tok")(http.HandlerFunc(a.Connect)))
+	t.Cleanup(server.Close)
+
+	opts := &websocket.DialOptions{HTTPHeader: http.Header{}}
+	if admin {
+		opts.HTTPHeader.Set("Authorization", "Bearer tok")
+	}
+	conn, _, err := websocket.Dial(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), opts)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+// exchange Sends a raw message and returns the raw answer.
+func exchange(t *testing.T, conn *websocket.Conn, msg string) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := conn.Write(ctx, websocket.MessageText, []byte(msg)); err != nil {
+		t.Fatalf("failed to send %s: %v", msg, err)
+	}
+	return receive(t, conn)
+}
+
+// receive Returns the next raw message sent by the server.
+func receive(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, data, err := conn.Read(ctx)
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func TestNewHTTPAdapter(t *testing.T) {
+	if _, err := NewHTTPAdapter(nil, posts.NewEventBus(), testConfig); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := testConfig
+	invalid.MessageRate = 0
+	if _, err := NewHTTPAdapter(nil, posts.NewEventBus(), invalid); err == nil {
+		t.Error("expected error for zero message rate")
+	}
+}
+
+func Test_httpAdapter_Connect(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(s *posts.MocksService)
+		admin    bool
+		messages []string
+		want     []string
+	}{
+		{
+			name: "subscribe_and_unsubscribe_comments",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("hello", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1"}, nil)
+			},
+			messages: []string{
+				`{"type":"subscribe","id":"1","channel":"comments","post_id":"hello"}`,
+				`{"type":"unsubscribe","id":"2","channel":"comments","post_id":"p1"}`,
+				`{"type":"unsubscribe","id":"3","channel":"comments","post_id":"p1"}`,
+			},
+			want: []string{
+				`{"type":"ack","id":"1","channel":"comments","post_id":"p1"}`,
+				`{"type":"ack","id":"2","channel":"comments","post_id":"p1"}`,
+				`{"type":"error","id":"3","status":404,"error":"not subscribed"}`,
+			},
+		},
+		{
+			name: "admin_subscribes_to_unpublished_post",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("draft", posts.ReadOptions{IncludeUnpublished: true}).Return(&posts.BlogPost{ID: "p2"}, nil)
+			},
+			admin:    true,
+			messages: []string{`{"type":"subscribe","channel":"comments","post_id":"draft"}`},
+			want:     []string{`{"type":"ack","channel":"comments","post_id":"p2"}`},
+		},
+		{
+			name: "subscribe_unknown_post_404",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("nope", posts.ReadOptions{}).Return(nil, posts.ErrBlogPostNotFound)
+			},
+			messages: []string{`{"type":"subscribe","id":"1","channel":"comments","post_id":"nope"}`},
+			want:     []string{`{"type":"error","id":"1","status":404,"error":"blog post not found"}`},
+		},
+		{
+			name: "subscription_limit_429",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("p1", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1"}, nil).Twice()
+				s.EXPECT().GetBlogPost("p2", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p2"}, nil)
+			},
+			messages: []string{
+				`{"type":"subscribe","channel":"posts"}`,
+				`{"type":"subscribe","channel":"comments","post_id":"p1"}`,
+				`{"type":"subscribe","channel":"comments","post_id":"p1"}`,
+				`{"type":"subscribe","channel":"comments","post_id":"p2"}`,
+			},
+			want: []string{
+				`{"type":"ack","channel":"posts"}`,
+				`{"type":"ack","channel":"comments","post_id":"p1"}`,
+				`{"type":"ack","channel":"comments","post_id":"p1"}`,
+				`{"type":"error","status":429,"error":"too many subscriptions"}`,
+			},
+		},
+		{
+			name: "create_comment_and_reply",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().CreateComment("p1", "hi").Return("c1", nil)
+				s.EXPECT().CreateReply("p1", "c1", "hey").Return("c2", nil)
+			},
+			messages: []string{
+				`{"type":"comment.create","id":"1","post_id":"p1","text":"hi"}`,
+				`{"type":"comment.create","id":"2","post_id":"p1","parent_id":"c1","text":"hey"}`,
+			},
+			want: []string{
+				`{"type":"ack","id":"1","post_id":"p1","comment_id":"c1"}`,
+				`{"type":"ack","id":"2","post_id":"p1","comment_id":"c2"}`,
+			},
+		},
+		{
+			name: "create_rejected_comment_422",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().CreateComment("p1", "casino").Return("", posts.ErrContentRejected)
+			},
+			messages: []string{`{"type":"comment.create","id":"1","post_id":"p1","text":"casino"}`},
+			want:     []string{`{"type":"error","id":"1","status":422,"error":"content rejected"}`},
+		},
+		{
+			name: "invalid_messages_400",
+			messages: []string{
+				`{"type":`,
+				`{"type":"shout","id":"1"}`,
+				`{"type":"subscribe","id":"2","channel":"tags"}`,
+				`{"type":"comment.create","id":"3","post_id":"p1"}`,
+			},
+			want: []string{
+				`{"type":"error","status":400,"error":"bad request: malformed message"}`,
+				`{"type":"error","id":"1","status":400,"error":"bad request: unknown message type (shout)"}`,
+				`{"type":"error","id":"2","status":400,"error":"bad request: channel must be either posts or comments"}`,
+				`{"type":"error","id":"3","status":400,"error":"bad request: missing comment text"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := posts.NewMocksService(t)
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+			a := &httpAdapter{Service: service, Bus: posts.NewEventBus(), Config: testConfig}
+			conn := dial(t, a, tt.admin)
+
+			for i, msg := range tt.messages {
+				if got := exchange(t, conn, msg); got != tt.want[i] {
+					t.Errorf("message %d: got %s, want %s", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_Connect_events(t *testing.T) {
+	bus := posts.NewEventBus()
+	a := &httpAdapter{Service: posts.NewMocksService(t), Bus: bus, Config: testConfig}
+	conn := dial(t, a, false)
+	exchange(t, conn, `{"type":"subscribe","channel":"posts"}`)
+
+	// Only published posts reach non admins.
+	bus.Publish(posts.Event{Type: posts.EventPostCreated, AggregateID: "p0", BlogPost: &posts.BlogPost{ID: "p0", Status: posts.StatusDraft}})
+	bus.Publish(posts.Event{Type: posts.EventPostCreated, AggregateID: "p1", BlogPost: &posts.BlogPost{ID: "p1", Status: posts.StatusPublished}})
+	// Comments of posts not subscribed to are skipped.
+	bus.Publish(posts.Event{Type: posts.EventCommentCreated, AggregateID: "p1", PostStatus: posts.StatusPublished, Comment: &posts.Comment{ID: "c1", BlogPostID: "p1", ModerationStatus: posts.ModerationApproved}})
+	// Scheduled posts reach non admins once published, without their moderation setting.
+	bus.Publish(posts.Event{Type: posts.EventPostPublished, AggregateID: "p0", BlogPost: &posts.BlogPost{ID: "p0", Status: posts.StatusPublished, CommentModeration: posts.ModerationPending}})
+
+	for _, want := range []struct {
+		event posts.EventType
+		id    string
+	}{
+		{event: posts.EventPostCreated, id: "p1"},
+		{event: posts.EventPostPublished, id: "p0"},
+	} {
+		var got ServerMessage
+		raw := receive(t, conn)
+		if err := json.Unmarshal([]byte(raw), &got); err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		if got.Type != MessageEvent || got.Event != want.event || got.Channel != ChannelPosts || got.PostID != want.id || got.Data == nil {
+			t.Errorf("got unexpected event %+v", got)
+		}
+		if strings.Contains(raw, "comment_moderation") {
+			t.Errorf("got comment moderation of a post in %s", raw)
+		}
+	}
+
+	// Answers are queued behind events, so nothing else was pushed if the next message is the answer.
+	want := `{"type":"ack","channel":"posts"}`
+	if got := exchange(t, conn, `{"type":"unsubscribe","channel":"posts"}`); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func Test_httpAdapter_Connect_commentEvents(t *testing.T) {
+	service := posts.NewMocksService(t)
+	service.EXPECT().GetBlogPost("p1", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1"}, nil)
+	bus := posts.NewEventBus()
+	a := &httpAdapter{Service: service, Bus: bus, Config: testConfig}
+	conn := dial(t, a, false)
+	exchange(t, conn, `{"type":"subscribe","channel":"comments","post_id":"p1"}`)
+
+	bus.Publish(posts.Event{Type: posts.EventCommentCreated, AggregateID: "p1", PostStatus: posts.StatusPublished, Comment: &posts.Comment{ID: "c1", BlogPostID: "p1", ModerationStatus: posts.ModerationPending}})
+	bus.Publish(posts.Event{Type: posts.EventCommentCreated, AggregateID: "p2", PostStatus: posts.StatusPublished, Comment: &posts.Comment{ID: "c2", BlogPostID: "p2", ModerationStatus: posts.ModerationApproved}})
+	bus.Publish(posts.Event{Type: posts.EventCommentCreated, AggregateID: "p1", PostStatus: posts.StatusPublished, Comment: &posts.Comment{ID: "c3", BlogPostID: "p1", ModerationStatus: posts.ModerationApproved}})
+	// Pending comments reach non admins once approved.
+	bus.Publish(posts.Event{Type: posts.EventCommentApproved, AggregateID: "p1", PostStatus: posts.StatusPublished, Comment: &posts.Comment{ID: "c1", BlogPostID: "p1", ModerationStatus: posts.ModerationApproved}})
+
+	for _, want := range []string{
+		`{"type":"event","channel":"comments","post_id":"p1","event":"comment.created","data":{"id":"c3","blog_post_id":"p1","comment_text":"","moderation_status":"approved"}}`,
+		`{"type":"event","channel":"comments","post_id":"p1","event":"comment.approved","data":{"id":"c1","blog_post_id":"p1","comment_text":"","moderation_status":"approved"}}`,
+	} {
+		if got := receive(t, conn); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func Test_httpAdapter_Connect_rateLimit(t *testing.T) {
+	cfg := testConfig
+	cfg.MessageRate = 1
+	a := &httpAdapter{Service: posts.NewMocksService(t), Bus: posts.NewEventBus(), Config: cfg}
+	conn := dial(t, a, false)
+
+	exchange(t, conn, `{"type":"subscribe","channel":"posts"}`)
+	want := `{"type":"error","id":"2","status":429,"error":"rate limit exceeded"}`
+	if got := exchange(t, conn, `{"type":"subscribe","id":"2","channel":"posts"}`); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func Test_httpAdapter_Connect_messageTooLarge(t *testing.T) {
+	cfg := testConfig
+	cfg.MaxMessageSize = 16
+	a := &httpAdapter{Service: posts.NewMocksService(t), Bus: posts.NewEventBus(), Config: cfg}
+	conn := dial(t, a, false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn.Write(ctx, websocket.MessageText, []byte(`{"type":"subscribe","channel":"posts"}`))
+	_, _, err := conn.Read(ctx)
+	if status := websocket.CloseStatus(err); status != websocket.StatusMessageTooBig {
+		t.Errorf("got close status %d, want %d (%v)", status, websocket.StatusMessageTooBig, err)
+	}
+}
+
+func Test_session_slowClient(t *testing.T) {
+	cfg := testConfig
+	cfg.SendBuffer = 1
+	s := newSession(&httpAdapter{Config: cfg}, nil, false)
+	s.posts = true
+
+	published := posts.Event{Type: posts.EventPostCreated, AggregateID: "p1", BlogPost: &posts.BlogPost{ID: "p1", Status: posts.StatusPublished}}
+	s.handleEvent(published)
+	select {
+	case <-s.slow:
+		t.Fatal("client flagged slow before its queue was full")
+	default:
+	}
+
+	// Nobody drains the queue, so the second event flags the client instead of blocking the bus.
+	s.handleEvent(published)
+	select {
+	case <-s.slow:
+	default:
+		t.Error("expected client to be flagged slow")
+	}
+	s.handleEvent(published)
+}
@@ -0,0 +1,30 @@
+package live
+
+import (
+	"net/http"
+	"time"
+)
+
+// HTTPAdapter Live updates http adapter interface.
+type HTTPAdapter interface {
+	// Connect Upgrades the request to a WebSocket connection speaking the live updates protocol.
+	Connect(http.ResponseWriter, *http.Request)
+}
+
+// Config Live updates configuration, limits apply to every connection.
+type Config struct {
+	// OriginPatterns Host patterns of cross origin pages allowed to connect, same origin pages always are.
+	OriginPatterns []string
+	// Heartbeat How often connections are pinged, closing the ones not answering.
+	Heartbeat time.Duration
+	// WriteTimeout How long a message can take to be written before the connection is closed.
+	WriteTimeout time.Duration
+	// SendBuffer Messages queued for a connection before it is closed for falling behind.
+	SendBuffer int
+	// MaxSubscriptions Subscriptions a connection can hold at once.
+	MaxSubscriptions int
+	// MaxMessageSize Largest message accepted from clients, in bytes.
+	MaxMessageSize int64
+	// MessageRate Messages accepted from a client per second, the rest are answered with an error.
+	MessageRate int
+}
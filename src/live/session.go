@@ -0,0 +1,274 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// session State of a single live updates connection.
+type session struct {
+	adapter *httpAdapter
+	conn    *websocket.Conn
+	admin   bool
+
+	// send Queue of messages written by writeLoop, full once the client falls behind.
+	send chan ServerMessage
+	// slow Closed once the client falls behind.
+	slow     chan struct{}
+	slowOnce sync.Once
+
+	mu       sync.Mutex
+	posts    bool
+	comments map[string]bool
+
+	// windowStart and windowCount Messages received in the current one second rate limit window.
+	windowStart time.Time
+	windowCount int
+}
+
+// newSession Returns the state of a new connection.
+func newSession(adapter *httpAdapter, conn *websocket.Conn, admin bool) *session {
+	return &session{
+		adapter:  adapter,
+		conn:     conn,
+		admin:    admin,
+		send:     make(chan ServerMessage, adapter.Config.SendBuffer),
+		slow:     make(chan struct{}),
+		comments: map[string]bool{},
+	}
+}
+
+// readLoop Answers client messages until the connection fails or ctx is done.
+func (s *session) readLoop(ctx context.Context) {
+	for {
+		// Decoded here rather than with wsjson, which closes the connection on malformed messages.
+		_, data, err := s.conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		allowed := s.allow(time.Now())
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.reply(errorMessage("", fmt.Errorf("%w: malformed message", posts.ErrorBadRequest)))
+			continue
+		}
+		if !allowed {
+			s.reply(errorMessage(msg.ID, ErrRateLimited))
+			continue
+		}
+
+		s.reply(s.handleMessage(msg))
+	}
+}
+
+// writeLoop Writes queued messages and pings the client until writing fails, the client falls behind
+// or ctx is done.
+func (s *session) writeLoop(ctx context.Context) {
+	heartbeat := time.NewTicker(s.adapter.Config.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.slow:
+			s.conn.Close(websocket.StatusPolicyViolation, "client too slow")
+			return
+		case msg := <-s.send:
+			writeCtx, cancel := context.WithTimeout(ctx, s.adapter.Config.WriteTimeout)
+			err := wsjson.Write(writeCtx, s.conn, msg)
+			cancel()
+			if err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			pingCtx, cancel := context.WithTimeout(ctx, s.adapter.Config.WriteTimeout)
+			err := s.conn.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// reply Queues a message without blocking, flagging the client as slow when its queue is full.
+func (s *session) reply(msg ServerMessage) {
+	select {
+	case s.send <- msg:
+	default:
+		s.slowOnce.Do(func() { close(s.slow) })
+	}
+}
+
+// allow Reports whether a message received at now is within the connection rate limit.
+func (s *session) allow(now time.Time) bool {
+	if now.Sub(s.windowStart) >= time.Second {
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	s.windowCount++
+	return s.windowCount <= s.adapter.Config.MessageRate
+}
+
+// handleMessage Runs a client message and returns its answer.
+func (s *session) handleMessage(msg ClientMessage) ServerMessage {
+	var (
+		answer ServerMessage
+		err    error
+	)
+	switch msg.Type {
+	case MessageSubscribe:
+		answer, err = s.subscribe(msg)
+	case MessageUnsubscribe:
+		answer, err = s.unsubscribe(msg)
+	case MessageCommentCreate:
+		answer, err = s.createComment(msg)
+	default:
+		err = fmt.Errorf("%w: unknown message type (%s)", posts.ErrorBadRequest, msg.Type)
+	}
+	if err != nil {
+		return errorMessage(msg.ID, err)
+	}
+
+	answer.Type = MessageAck
+	answer.ID = msg.ID
+	return answer
+}
+
+// subscribe Subscribes to new posts or to new comments of a post, acknowledging the public ID of the post.
+func (s *session) subscribe(msg ClientMessage) (ServerMessage, error) {
+	var postID string
+	switch msg.Channel {
+	case ChannelPosts:
+	case ChannelComments:
+		post, err := s.adapter.Service.GetBlogPost(msg.PostID, posts.ReadOptions{IncludeUnpublished: s.admin})
+		if err != nil {
+			return ServerMessage{}, err
+		}
+		postID = post.ID
+	default:
+		return ServerMessage{}, fmt.Errorf("%w: channel must be either posts or comments", posts.ErrorBadRequest)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscribed := s.comments[postID]
+	if postID == "" {
+		subscribed = s.posts
+	}
+	if !subscribed && s.subscriptions() >= s.adapter.Config.MaxSubscriptions {
+		return ServerMessage{}, ErrTooManySubscriptions
+	}
+	if postID == "" {
+		s.posts = true
+	} else {
+		s.comments[postID] = true
+	}
+
+	return ServerMessage{Channel: msg.Channel, PostID: postID}, nil
+}
+
+// unsubscribe Unsubscribes from new posts or from new comments of a post, by the public ID acknowledged on subscribe.
+func (s *session) unsubscribe(msg ClientMessage) (ServerMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch msg.Channel {
+	case ChannelPosts:
+		if !s.posts {
+			return ServerMessage{}, ErrNotSubscribed
+		}
+		s.posts = false
+	case ChannelComments:
+		if !s.comments[msg.PostID] {
+			return ServerMessage{}, ErrNotSubscribed
+		}
+		delete(s.comments, msg.PostID)
+	default:
+		return ServerMessage{}, fmt.Errorf("%w: channel must be either posts or comments", posts.ErrorBadRequest)
+	}
+
+	return ServerMessage{Channel: msg.Channel, PostID: msg.PostID}, nil
+}
+
+// subscriptions Returns how many subscriptions the connection holds. Callers must hold the lock.
+func (s *session) subscriptions() int {
+	count := len(s.comments)
+	if s.posts {
+		count++
+	}
+	return count
+}
+
+// createComment Creates a comment, or a reply when a parent is provided, acknowledging its public ID.
+func (s *session) createComment(msg ClientMessage) (ServerMessage, error) {
+	if msg.Text == "" {
+		return ServerMessage{}, fmt.Errorf("%w: missing comment text", posts.ErrorBadRequest)
+	}
+
+	var (
+		commentID string
+		err       error
+	)
+	if msg.ParentID != "" {
+		commentID, err = s.adapter.Service.CreateReply(msg.PostID, msg.ParentID, msg.Text)
+	} else {
+		commentID, err = s.adapter.Service.CreateComment(msg.PostID, msg.Text)
+	}
+	if err != nil {
+		return ServerMessage{}, err
+	}
+
+	return ServerMessage{PostID: msg.PostID, CommentID: commentID}, nil
+}
+
+// handleEvent Queues events of subscribed channels, meant to be subscribed to the posts event bus: posts as they are
+// created or published, and comments as they are created or approved. Only admins get unpublished posts and
+// unapproved comments.
+func (s *session) handleEvent(event posts.Event) {
+	if !s.admin && !event.Public() {
+		return
+	}
+
+	s.mu.Lock()
+	var msg *ServerMessage
+	switch {
+	case (event.Type == posts.EventPostCreated || event.Type == posts.EventPostPublished) && event.BlogPost != nil && s.posts:
+		post := *event.BlogPost
+		if !s.admin {
+			post = post.PublicView()
+		}
+		msg = &ServerMessage{Channel: ChannelPosts, PostID: event.AggregateID, Data: post}
+	case (event.Type == posts.EventCommentCreated || event.Type == posts.EventCommentApproved) && event.Comment != nil &&
+		s.comments[event.AggregateID]:
+		msg = &ServerMessage{Channel: ChannelComments, PostID: event.AggregateID, Data: event.Comment}
+	}
+	s.mu.Unlock()
+
+	if msg != nil {
+		msg.Type = MessageEvent
+		msg.Event = event.Type
+		s.reply(*msg)
+	}
+}
+
+// errorMessage Returns the error answering a client message, with the HTTP status the error maps to.
+func errorMessage(id string, err error) ServerMessage {
+	return ServerMessage{
+		Type:   MessageError,
+		ID:     id,
+		Status: httputil.StatusCode(err, errMapper),
+		Error:  err.Error(),
+	}
+}
@@ -0,0 +1,58 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/go-chi/chi/v5"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+var (
+	// Spec OpenAPI document of every route served by the API.
+	//go:embed openapi.json
+	Spec []byte
+
+	// initializer Script pointing the documentation UI at Spec.
+	//go:embed swagger-initializer.js
+	initializer []byte
+
+	// startedAt Last modification date of the embedded documents, which change only with new builds.
+	startedAt = time.Now()
+)
+
+// httpAdapter Productive API documentation http adapter implementation
+type httpAdapter struct{}
+
+// NewHTTPAdapter Returns new productive API documentation http adapter implementation.
+func NewHTTPAdapter() (HTTPAdapter, error) {
+	if !json.Valid(Spec) {
+		return nil, errors.New("invalid OpenAPI document")
+	}
+	return &httpAdapter{}, nil
+}
+
+// GetSpec Returns the OpenAPI document of the API.
+func (a *httpAdapter) GetSpec(w http.ResponseWriter, r *http.Request) {
+	httputil.ServeCacheable(w, r, "application/json", startedAt, Spec)
+}
+
+// GetDocs Returns the documentation UI, whose files are served below `/docs/`.
+func (a *httpAdapter) GetDocs(w http.ResponseWriter, r *http.Request) {
+	file := chi.URLParam(r, "*")
+	switch {
+	case file == "" && !strings.HasSuffix(r.URL.Path, "/"):
+		http.Redirect(w, r, r.URL.Path+"/", http.StatusMovedPermanently)
+	case file == "":
+		http.ServeFileFS(w, r, swaggerFiles.FS, "index.html")
+	case file == "swagger-initializer.js":
+		httputil.ServeCacheable(w, r, "text/javascript; charset=utf-8", startedAt, initializer)
+	default:
+		http.ServeFileFS(w, r, swaggerFiles.FS, file)
+	}
+}
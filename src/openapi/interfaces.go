@@ -0,0 +1,11 @@
+package openapi
+
+import "net/http"
+
+// HTTPAdapter API documentation http adapter interface.
+type HTTPAdapter interface {
+	// GetSpec Returns the OpenAPI document of the API.
+	GetSpec(http.ResponseWriter, *http.Request)
+	// GetDocs Returns the documentation UI, rendered from the OpenAPI document.
+	GetDocs(http.ResponseWriter, *http.Request)
+}
@@ -1,15 +1,294 @@
 package posts
 
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Status Blog post publication status.
+type Status string
+
+const (
+	// StatusDraft Post is being written and is only visible to privileged callers.
+	StatusDraft Status = "draft"
+	// StatusScheduled Post will be published automatically once its publish date is due.
+	StatusScheduled Status = "scheduled"
+	// StatusPublished Post is publicly visible.
+	StatusPublished Status = "published"
+	// StatusArchived Post is no longer publicly visible.
+	StatusArchived Status = "archived"
+)
+
+// statusTransitions Allowed publication status transitions.
+var statusTransitions = map[Status][]Status{
+	StatusDraft:     {StatusScheduled, StatusPublished, StatusArchived},
+	StatusScheduled: {StatusDraft, StatusScheduled, StatusPublished, StatusArchived},
+	StatusPublished: {StatusArchived},
+	StatusArchived:  {StatusDraft, StatusPublished},
+}
+
+// Valid Reports whether the status is a known publication status.
+func (s Status) Valid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo Reports whether a post can move from current status to the provided one.
+func (s Status) CanTransitionTo(to Status) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+// ModerationStatus Comment moderation status.
+type ModerationStatus string
+
+const (
+	// ModerationPending Comment awaits moderation and is only visible to privileged callers.
+	ModerationPending ModerationStatus = "pending"
+	// ModerationApproved Comment is publicly visible.
+	ModerationApproved ModerationStatus = "approved"
+	// ModerationRejected Comment was rejected by a moderator.
+	ModerationRejected ModerationStatus = "rejected"
+	// ModerationSpam Comment was flagged as spam.
+	ModerationSpam ModerationStatus = "spam"
+)
+
+// Valid Reports whether the status is a known moderation status.
+func (s ModerationStatus) Valid() bool {
+	switch s {
+	case ModerationPending, ModerationApproved, ModerationRejected, ModerationSpam:
+		return true
+	}
+	return false
+}
+
+// ContentFormat Format blog post content is written in.
+type ContentFormat string
+
+const (
+	// ContentFormatText Plain text, rendered as a single escaped paragraph.
+	ContentFormatText ContentFormat = "text"
+	// ContentFormatMarkdown CommonMark with GitHub Flavored Markdown extensions.
+	ContentFormatMarkdown ContentFormat = "markdown"
+)
+
+// Valid Reports whether the format is a known content format.
+func (f ContentFormat) Valid() bool {
+	switch f {
+	case ContentFormatText, ContentFormatMarkdown:
+		return true
+	}
+	return false
+}
+
 // BlogPost Represents blogpost data.
 type BlogPost struct {
-	ID       string    `json:"id"`
-	Title    string    `json:"title"`
-	Content  string    `json:"content"`
-	Comments []Comment `json:"comments"`
+	ID      string `json:"id"`
+	Slug    string `json:"slug"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Author  string `json:"author,omitempty"`
+
+	// ContentFormat Format Content is written in.
+	ContentFormat ContentFormat `json:"content_format"`
+	// ContentHTML Sanitized HTML rendered from Content when the post is written.
+	ContentHTML string `json:"content_html"`
+	// Excerpt Plain text teaser taken from Content when the post is written.
+	Excerpt string `json:"excerpt"`
+	// CommentCount Amount of comments visible to the reader.
+	CommentCount int `json:"comment_count"`
+
+	Status    Status     `json:"status"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+
+	Tags []string `json:"tags,omitempty"`
+
+	// CommentModeration Moderation status of new comments, empty to use the service default.
+	CommentModeration ModerationStatus `json:"comment_moderation,omitempty"`
+
+	// CreatedAt When the post was first written.
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	// UpdatedAt When the title or content of the post were last written.
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	// DeletedAt When the post was moved to trash, nil unless it is in trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Comments Comments of the post, nil when they were not requested.
+	Comments []Comment `json:"comments,omitzero"`
+}
+
+// PublicView Returns the blog post without the settings only admins can see, such as its comment moderation.
+func (p BlogPost) PublicView() BlogPost {
+	p.CommentModeration = ""
+	return p
+}
+
+// Revision Snapshot of blog post content saved on every write.
+type Revision struct {
+	Number        int           `json:"revision"`
+	Title         string        `json:"title"`
+	Content       string        `json:"content"`
+	ContentFormat ContentFormat `json:"content_format"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// Tag Blog post tag.
+type Tag struct {
+	Slug      string `json:"slug"`
+	PostCount int    `json:"post_count"`
 }
 
 // Comment Blogpost comment.
 type Comment struct {
-	ID          string `json:"id"`
-	CommentText string `json:"comment_text"`
+	ID               string           `json:"id"`
+	BlogPostID       string           `json:"blog_post_id,omitempty"`
+	CommentText      string           `json:"comment_text"`
+	ModerationStatus ModerationStatus `json:"moderation_status,omitempty"`
+	ParentID         string           `json:"parent_id,omitempty"`
+	Depth            int              `json:"depth,omitempty"`
+	ReplyCount       int              `json:"reply_count,omitempty"`
+	Replies          []Comment        `json:"replies,omitempty"`
+	CreatedAt        time.Time        `json:"created_at,omitzero"`
+	DeletedAt        *time.Time       `json:"deleted_at,omitempty"`
+}
+
+// BatchResult Outcome of a single item of a batch, either the ID it was given or why it failed.
+type BatchResult struct {
+	ID  string
+	Err error
+}
+
+// BatchItemError Error returned when an item of an atomic batch fails, so no item of the batch is stored.
+type BatchItemError struct {
+	// Index Position of the failed item in the batch.
+	Index int
+	Err   error
+}
+
+// Error Returns error message.
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("post %d: %s", e.Index, e.Err)
+}
+
+// Unwrap Returns why the item failed.
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// ItemIndex Returns the position of the failed item in the batch.
+func (e *BatchItemError) ItemIndex() int {
+	return e.Index
+}
+
+// RecordType Kind of content held by an export record.
+type RecordType string
+
+const (
+	// RecordPost Record holding a blog post.
+	RecordPost RecordType = "post"
+	// RecordComment Record holding a comment, linked to its post and parent by their exported IDs.
+	RecordComment RecordType = "comment"
+)
+
+// ExportRecord Line of NDJSON exports and imports, holding either a blog post or a comment.
+// Every post is followed by its comments, and replies follow their parent comment.
+type ExportRecord struct {
+	Type    RecordType `json:"type"`
+	Post    *BlogPost  `json:"post,omitempty"`
+	Comment *Comment   `json:"comment,omitempty"`
+}
+
+// ImportResult Outcome of an import.
+type ImportResult struct {
+	DryRun   bool          `json:"dry_run"`
+	Posts    int           `json:"posts"`
+	Comments int           `json:"comments"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// ImportError Line of an import that could not be imported.
+type ImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// CommentsQuery Options used when reading a comment thread.
+type CommentsQuery struct {
+	// ParentID Comment whose replies are read, empty for top level comments.
+	ParentID string
+	// MaxDepth How many levels of replies are read below the paginated comments.
+	MaxDepth int
+	// MaxReplies Maximum replies read below every comment, oldest first. Comments keep their total in ReplyCount.
+	MaxReplies int
+	// Limit Maximum amount of paginated comments.
+	Limit int
+	// Offset Amount of paginated comments skipped.
+	Offset int
+	// IncludeUnapproved Includes comments pending moderation, rejected or flagged as spam.
+	IncludeUnapproved bool
+}
+
+// CommentTree Nests a flat comment list, ordered depth first, into a tree of replies.
+// Comments whose parent is not part of the list are returned as roots.
+func CommentTree(comments []Comment) []Comment {
+	children := map[string][]Comment{}
+	ids := map[string]bool{}
+	for _, comment := range comments {
+		ids[comment.ID] = true
+	}
+
+	var roots []Comment
+	for _, comment := range comments {
+		if comment.ParentID != "" && ids[comment.ParentID] {
+			children[comment.ParentID] = append(children[comment.ParentID], comment)
+			continue
+		}
+		roots = append(roots, comment)
+	}
+
+	var nest func(level []Comment) []Comment
+	nest = func(level []Comment) []Comment {
+		for i := range level {
+			level[i].Replies = nest(children[level[i].ID])
+		}
+		return level
+	}
+
+	return nest(roots)
+}
+
+// ReadOptions Options applied when reading blog posts.
+type ReadOptions struct {
+	// IncludeUnpublished Includes draft, scheduled and archived posts.
+	IncludeUnpublished bool
+	// IncludeUnapproved Includes comments pending moderation, rejected or flagged as spam.
+	IncludeUnapproved bool
+	// Tags Only returns posts tagged with any of these slugs.
+	Tags []string
+	// MatchAllTags Requires posts to be tagged with every slug in Tags.
+	MatchAllTags bool
+	// IncludeComments Reads the comments of every post, comments are not read otherwise.
+	IncludeComments bool
+	// CommentsLimit Only reads the latest comments of every post up to this amount, 0 to read every comment.
+	CommentsLimit int
+	// Filter Only returns posts matching every criteria set.
+	Filter ListFilter
+	// NewestFirst Orders posts by publication date, newest first, instead of oldest created first.
+	NewestFirst bool
+}
+
+// ListFilter Criteria blog post listings can be narrowed by, unset criteria match every post.
+type ListFilter struct {
+	// CreatedAfter Only matches posts created at or after this time.
+	CreatedAfter *time.Time `query:"created_after"`
+	// CreatedBefore Only matches posts created before this time.
+	CreatedBefore *time.Time `query:"created_before"`
+	// Author Only matches posts written by this author.
+	Author string `query:"author"`
+	// TitleContains Only matches posts whose title contains this text, ignoring the case of ASCII letters only.
+	TitleContains string `query:"title_contains"`
+	// HasComments Only matches posts with or without visible comments.
+	HasComments *bool `query:"has_comments"`
+	// Status Only matches posts with this publication status.
+	Status Status `query:"status"`
 }
@@ -0,0 +1,108 @@
+package posts
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// EventType Kind of successful write reported to event publishers.
+type EventType string
+
+const (
+	// EventPostCreated A blog post was created, whatever its status.
+	EventPostCreated EventType = "post.created"
+	// EventPostPublished A blog post created unpublished was published, either by an admin or once scheduled.
+	EventPostPublished EventType = "post.published"
+	// EventCommentCreated A comment or reply was created, whatever its moderation status.
+	EventCommentCreated EventType = "comment.created"
+	// EventCommentApproved A comment or reply not approved on creation was approved by a moderator.
+	EventCommentApproved EventType = "comment.approved"
+)
+
+// EventTypes Every event type reported to event publishers.
+var EventTypes = []EventType{EventPostCreated, EventPostPublished, EventCommentCreated, EventCommentApproved}
+
+// Valid Reports whether event type is one of the known ones.
+func (t EventType) Valid() bool {
+	switch t {
+	case EventPostCreated, EventPostPublished, EventCommentCreated, EventCommentApproved:
+		return true
+	}
+	return false
+}
+
+// Event Successful write recorded in the outbox, carrying either the blog post or the comment written
+// as they were stored. Events of the same blog post share their aggregate ID and are published in order.
+// PostStatus is the status the blog post had when the event occurred, empty when it was not found.
+type Event struct {
+	ID          string
+	Type        EventType
+	AggregateID string
+	OccurredAt  time.Time
+	Attempts    int
+	PostStatus  Status
+	BlogPost    *BlogPost
+	Comment     *Comment
+}
+
+// Public Reports whether the event is about content anyone can read: published blog posts and approved comments
+// of published blog posts. Content becoming public later is reported by its own post.published or comment.approved event.
+func (e Event) Public() bool {
+	switch {
+	case e.BlogPost != nil:
+		return e.BlogPost.Status == StatusPublished
+	case e.Comment != nil:
+		return e.Comment.ModerationStatus == ModerationApproved && e.PostStatus == StatusPublished
+	}
+	return false
+}
+
+// EventLogger Event publisher logging every event.
+type EventLogger struct{}
+
+// Publish Logs event.
+func (EventLogger) Publish(event Event) error {
+	log.Printf("event %s (%s) of blog post %s", event.Type, event.ID, event.AggregateID)
+	return nil
+}
+
+// EventBus In-process event publisher fanning events out to its subscribers.
+type EventBus struct {
+	mu       sync.RWMutex
+	next     int
+	handlers map[int]func(Event)
+}
+
+// NewEventBus Returns new in-process event bus without subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: map[int]func(Event){}}
+}
+
+// Subscribe Calls handler with every event published from now on, until the returned function is called.
+// Handlers are called synchronously by the publisher, so they must not block.
+func (b *EventBus) Subscribe(handler func(Event)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+	b.handlers[id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// Publish Calls every subscriber with event.
+func (b *EventBus) Publish(event Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package posts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventType_Valid(t *testing.T) {
+	for _, eventType := range EventTypes {
+		if !eventType.Valid() {
+			t.Errorf("Valid() = false for %s", eventType)
+		}
+	}
+	if EventType("post.deleted").Valid() {
+		t.Error("Valid() = true for unknown event type")
+	}
+}
+
+func TestEvent_Public(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  bool
+	}{
+		{name: "published_post", event: Event{Type: EventPostCreated, BlogPost: &BlogPost{Status: StatusPublished}}, want: true},
+		{name: "draft_post", event: Event{Type: EventPostCreated, BlogPost: &BlogPost{Status: StatusDraft}}},
+		{name: "scheduled_post", event: Event{Type: EventPostCreated, BlogPost: &BlogPost{Status: StatusScheduled}}},
+		{name: "approved_comment", event: Event{Type: EventCommentCreated, PostStatus: StatusPublished, Comment: &Comment{ModerationStatus: ModerationApproved}}, want: true},
+		{name: "approved_comment_archived_post", event: Event{Type: EventCommentApproved, PostStatus: StatusArchived, Comment: &Comment{ModerationStatus: ModerationApproved}}},
+		{name: "approved_comment_missing_post", event: Event{Type: EventCommentApproved, Comment: &Comment{ModerationStatus: ModerationApproved}}},
+		{name: "pending_comment", event: Event{Type: EventCommentCreated, PostStatus: StatusPublished, Comment: &Comment{ModerationStatus: ModerationPending}}},
+		{name: "spam_comment", event: Event{Type: EventCommentCreated, PostStatus: StatusPublished, Comment: &Comment{ModerationStatus: ModerationSpam}}},
+		{name: "empty", event: Event{Type: EventCommentCreated}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.Public(); got != tt.want {
+				t.Errorf("Public() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+
+	var first, second []string
+	unsubscribe := bus.Subscribe(func(e Event) { first = append(first, e.ID) })
+	bus.Subscribe(func(e Event) { second = append(second, e.ID) })
+
+	bus.Publish(Event{ID: "1"})
+	unsubscribe()
+	bus.Publish(Event{ID: "2"})
+
+	if want := []string{"1"}; !reflect.DeepEqual(first, want) {
+		t.Errorf("unsubscribed handler got %v, want %v", first, want)
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(second, want) {
+		t.Errorf("subscribed handler got %v, want %v", second, want)
+	}
+}
@@ -0,0 +1,183 @@
+package posts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrContentRejected Content rejected by content filters error.
+	ErrContentRejected = errors.New("content rejected")
+)
+
+// ContentKind Kind of user content inspected by content filters.
+type ContentKind string
+
+const (
+	// ContentPost Blog post content.
+	ContentPost ContentKind = "post"
+	// ContentComment Comment content.
+	ContentComment ContentKind = "comment"
+)
+
+// FilterAction Outcome of a content filter, ordered by severity.
+type FilterAction int
+
+const (
+	// FilterAllow Content can be stored as is.
+	FilterAllow FilterAction = iota
+	// FilterModerate Content can be stored but must be reviewed before being public.
+	FilterModerate
+	// FilterReject Content must not be stored.
+	FilterReject
+)
+
+// ParseFilterAction Parses a filter action from its configuration name.
+func ParseFilterAction(action string) (FilterAction, error) {
+	switch action {
+	case "allow":
+		return FilterAllow, nil
+	case "moderate":
+		return FilterModerate, nil
+	case "reject":
+		return FilterReject, nil
+	}
+	return FilterAllow, fmt.Errorf("unknown filter action (%s)", action)
+}
+
+// Content User content inspected by content filters.
+type Content struct {
+	Kind       ContentKind
+	BlogPostID string
+	Title      string
+	Text       string
+}
+
+// FilterResult Content filter verdict.
+type FilterResult struct {
+	Action  FilterAction
+	Reasons []string
+}
+
+// ContentRejectedError Error returned when content filters reject content, carrying the reasons why.
+type ContentRejectedError struct {
+	Reasons []string
+}
+
+// Error Returns error message.
+func (e *ContentRejectedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrContentRejected, strings.Join(e.Reasons, "; "))
+}
+
+// Is Matches ErrContentRejected.
+func (e *ContentRejectedError) Is(target error) bool {
+	return target == ErrContentRejected
+}
+
+// Details Returns rejection reasons.
+func (e *ContentRejectedError) Details() []string {
+	return e.Reasons
+}
+
+// FilterChain Runs every filter and returns the most severe verdict along with all the reasons given.
+type FilterChain []ContentFilter
+
+// Check Inspects content with every filter of the chain.
+func (c FilterChain) Check(content Content) (FilterResult, error) {
+	var res FilterResult
+	for _, filter := range c {
+		filterRes, err := filter.Check(content)
+		if err != nil {
+			return FilterResult{}, err
+		}
+		if filterRes.Action == FilterAllow {
+			continue
+		}
+
+		res.Action = max(res.Action, filterRes.Action)
+		res.Reasons = append(res.Reasons, filterRes.Reasons...)
+	}
+	return res, nil
+}
+
+// BlockedWordsFilter Flags content containing any blocked word or phrase, ignoring case and punctuation.
+type BlockedWordsFilter struct {
+	Words  []string
+	Action FilterAction
+}
+
+// Check Inspects content for blocked words and phrases, which only match whole words.
+func (f BlockedWordsFilter) Check(content Content) (FilterResult, error) {
+	text := " " + normalizeWords(content.Title+" "+content.Text) + " "
+
+	var reasons []string
+	for _, blocked := range f.Words {
+		blocked = normalizeWords(blocked)
+		switch {
+		case blocked == "" || !strings.Contains(text, " "+blocked+" "):
+		case strings.Contains(blocked, " "):
+			reasons = append(reasons, fmt.Sprintf("contains blocked phrase %q", blocked))
+		default:
+			reasons = append(reasons, fmt.Sprintf("contains blocked word %q", blocked))
+		}
+	}
+
+	if len(reasons) == 0 {
+		return FilterResult{}, nil
+	}
+	return FilterResult{Action: f.Action, Reasons: reasons}, nil
+}
+
+// normalizeWords Returns the lowercase words of text separated by single spaces, dropping everything else.
+func normalizeWords(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// linkRegexp Matches links in user content.
+var linkRegexp = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// LinkLimitFilter Flags content with more links than allowed.
+type LinkLimitFilter struct {
+	MaxLinks int
+	Action   FilterAction
+}
+
+// Check Counts links in content, title included.
+func (f LinkLimitFilter) Check(content Content) (FilterResult, error) {
+	links := len(linkRegexp.FindAllString(content.Title+" "+content.Text, -1))
+	if links <= f.MaxLinks {
+		return FilterResult{}, nil
+	}
+
+	reason := fmt.Sprintf("contains %d links, at most %d allowed", links, f.MaxLinks)
+	return FilterResult{Action: f.Action, Reasons: []string{reason}}, nil
+}
+
+// DuplicateCommentFilter Flags comments repeating an existing comment of the same blog post.
+type DuplicateCommentFilter struct {
+	Repository Repository
+	Action     FilterAction
+}
+
+// Check Looks for an identical comment on the same blog post.
+func (f DuplicateCommentFilter) Check(content Content) (FilterResult, error) {
+	if content.Kind != ContentComment {
+		return FilterResult{}, nil
+	}
+
+	exists, err := f.Repository.HasComment(content.BlogPostID, content.Text)
+	if err != nil {
+		return FilterResult{}, err
+	}
+	if !exists {
+		return FilterResult{}, nil
+	}
+
+	return FilterResult{Action: f.Action, Reasons: []string{"duplicates an existing comment"}}, nil
+}
@@ -0,0 +1,191 @@
+package posts
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFilterChain_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(first, second *MocksContentFilter)
+		want    FilterResult
+		wantErr bool
+	}{
+		{
+			name: "allow",
+			setup: func(first, second *MocksContentFilter) {
+				first.EXPECT().Check(Content{Text: "hi"}).Return(FilterResult{}, nil)
+				second.EXPECT().Check(Content{Text: "hi"}).Return(FilterResult{}, nil)
+			},
+			want: FilterResult{},
+		},
+		{
+			name: "most_severe_action_with_all_reasons",
+			setup: func(first, second *MocksContentFilter) {
+				first.EXPECT().Check(Content{Text: "hi"}).Return(FilterResult{Action: FilterReject, Reasons: []string{"a"}}, nil)
+				second.EXPECT().Check(Content{Text: "hi"}).Return(FilterResult{Action: FilterModerate, Reasons: []string{"b"}}, nil)
+			},
+			want: FilterResult{Action: FilterReject, Reasons: []string{"a", "b"}},
+		},
+		{
+			name: "filter_error",
+			setup: func(first, second *MocksContentFilter) {
+				first.EXPECT().Check(Content{Text: "hi"}).Return(FilterResult{}, errors.New("fail"))
+			},
+			want:    FilterResult{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := NewMocksContentFilter(t), NewMocksContentFilter(t)
+			tt.setup(first, second)
+
+			got, err := FilterChain{first, second}.Check(Content{Text: "hi"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockedWordsFilter_Check(t *testing.T) {
+	filter := BlockedWordsFilter{Words: []string{"Spam", "casino", " Buy  NOW "}, Action: FilterReject}
+
+	tests := []struct {
+		name    string
+		content Content
+		want    FilterResult
+	}{
+		{
+			name:    "clean",
+			content: Content{Title: "Hello", Text: "nothing to see here, spammer"},
+			want:    FilterResult{},
+		},
+		{
+			name:    "blocked_words_in_title_and_text",
+			content: Content{Title: "SPAM!", Text: "visit the casino"},
+			want:    FilterResult{Action: FilterReject, Reasons: []string{`contains blocked word "spam"`, `contains blocked word "casino"`}},
+		},
+		{
+			name:    "blocked_phrase_across_punctuation",
+			content: Content{Text: "Pills, cheap! Buy... now?"},
+			want:    FilterResult{Action: FilterReject, Reasons: []string{`contains blocked phrase "buy now"`}},
+		},
+		{
+			name:    "blocked_phrase_partial_words",
+			content: Content{Text: "rebuy nowhere"},
+			want:    FilterResult{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filter.Check(tt.content)
+			if err != nil {
+				t.Errorf("Check() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkLimitFilter_Check(t *testing.T) {
+	filter := LinkLimitFilter{MaxLinks: 1, Action: FilterModerate}
+
+	tests := []struct {
+		name    string
+		content Content
+		want    FilterResult
+	}{
+		{
+			name:    "within_limit",
+			content: Content{Text: "see https://example.com"},
+			want:    FilterResult{},
+		},
+		{
+			name:    "over_limit",
+			content: Content{Text: "see https://example.com and www.example.org"},
+			want:    FilterResult{Action: FilterModerate, Reasons: []string{"contains 2 links, at most 1 allowed"}},
+		},
+		{
+			name:    "over_limit_with_title",
+			content: Content{Title: "www.example.org", Text: "see https://example.com"},
+			want:    FilterResult{Action: FilterModerate, Reasons: []string{"contains 2 links, at most 1 allowed"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filter.Check(tt.content)
+			if err != nil {
+				t.Errorf("Check() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicateCommentFilter_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		content Content
+		want    FilterResult
+		wantErr bool
+	}{
+		{
+			name:    "posts_are_ignored",
+			content: Content{Kind: ContentPost, Text: "hi"},
+			want:    FilterResult{},
+		},
+		{
+			name: "new_comment",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().HasComment("1", "hi").Return(false, nil)
+			},
+			content: Content{Kind: ContentComment, BlogPostID: "1", Text: "hi"},
+			want:    FilterResult{},
+		},
+		{
+			name: "duplicate_comment",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().HasComment("1", "hi").Return(true, nil)
+			},
+			content: Content{Kind: ContentComment, BlogPostID: "1", Text: "hi"},
+			want:    FilterResult{Action: FilterReject, Reasons: []string{"duplicates an existing comment"}},
+		},
+		{
+			name: "repo_error",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().HasComment("1", "hi").Return(false, errors.New("fail"))
+			},
+			content: Content{Kind: ContentComment, BlogPostID: "1", Text: "hi"},
+			want:    FilterResult{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			got, err := DuplicateCommentFilter{Repository: repo, Action: FilterReject}.Check(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
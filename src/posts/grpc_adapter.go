@@ -0,0 +1,276 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	postsv1 "github.com/MatiasKopp/prosig-code-challenge/proto/posts/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	grpcErrMapper = map[error]codes.Code{
+		ErrBlogPostNotFound:        codes.NotFound,
+		ErrCommentNotFound:         codes.NotFound,
+		ErrorBadRequest:            codes.InvalidArgument,
+		ErrInvalidStatusTransition: codes.FailedPrecondition,
+		ErrParentCommentDeleted:    codes.FailedPrecondition,
+		ErrContentRejected:         codes.InvalidArgument,
+		httputil.ErrUnauthorized:   codes.PermissionDenied,
+	}
+
+	// Enum values of the protobuf API by domain value.
+	protoStatuses = map[Status]postsv1.Status{
+		StatusDraft:     postsv1.Status_STATUS_DRAFT,
+		StatusScheduled: postsv1.Status_STATUS_SCHEDULED,
+		StatusPublished: postsv1.Status_STATUS_PUBLISHED,
+		StatusArchived:  postsv1.Status_STATUS_ARCHIVED,
+	}
+	protoContentFormats = map[ContentFormat]postsv1.ContentFormat{
+		ContentFormatText:     postsv1.ContentFormat_CONTENT_FORMAT_TEXT,
+		ContentFormatMarkdown: postsv1.ContentFormat_CONTENT_FORMAT_MARKDOWN,
+	}
+	protoModerationStatuses = map[ModerationStatus]postsv1.ModerationStatus{
+		ModerationPending:  postsv1.ModerationStatus_MODERATION_STATUS_PENDING,
+		ModerationApproved: postsv1.ModerationStatus_MODERATION_STATUS_APPROVED,
+		ModerationRejected: postsv1.ModerationStatus_MODERATION_STATUS_REJECTED,
+		ModerationSpam:     postsv1.ModerationStatus_MODERATION_STATUS_SPAM,
+	}
+)
+
+// grpcAdapter Productive post gRPC adapter implementation
+type grpcAdapter struct {
+	postsv1.UnimplementedPostsServiceServer
+
+	Service Service
+}
+
+// NewGRPCAdapter Returns new productive post gRPC adapter implementation.
+func NewGRPCAdapter(service Service) (postsv1.PostsServiceServer, error) {
+	return &grpcAdapter{
+		Service: service,
+	}, nil
+}
+
+// ListPosts Returns published posts paginated, oldest created first.
+func (a *grpcAdapter) ListPosts(_ context.Context, request *postsv1.ListPostsRequest) (*postsv1.ListPostsResponse, error) {
+	p := grpcPagination(request.GetPage(), request.GetLimit())
+
+	opts := ReadOptions{
+		Tags:         request.GetTags(),
+		MatchAllTags: request.GetMatchAllTags(),
+	}
+
+	posts, err := a.Service.GetAllBlogPosts(p.Limit, p.Offset, opts)
+	if err != nil {
+		return nil, grpcError("unexpected error getting all blog posts", err)
+	}
+
+	response := &postsv1.ListPostsResponse{
+		Posts: make([]*postsv1.BlogPost, len(posts)),
+		Pagination: &postsv1.Pagination{
+			Limit:  int32(p.Limit),
+			Offset: int32(p.Offset),
+			Page:   int32(p.Page),
+		},
+	}
+	for i, post := range posts {
+		response.Posts[i] = protoBlogPost(post)
+	}
+	return response, nil
+}
+
+// GetPost Returns single published post, found by ID or slug, old slugs included.
+func (a *grpcAdapter) GetPost(_ context.Context, request *postsv1.GetPostRequest) (*postsv1.GetPostResponse, error) {
+	post, err := a.Service.GetBlogPost(request.GetId(), ReadOptions{})
+	if err != nil {
+		return nil, grpcError(fmt.Sprintf("unexpected error getting post with ID (%s)", request.GetId()), err)
+	}
+
+	return &postsv1.GetPostResponse{Post: protoBlogPost(*post)}, nil
+}
+
+// CreatePost Creates new post.
+func (a *grpcAdapter) CreatePost(_ context.Context, request *postsv1.CreatePostRequest) (*postsv1.CreatePostResponse, error) {
+	if request.GetTitle() == "" || request.GetContent() == "" {
+		return nil, grpcError("missing title or content", ErrorBadRequest)
+	}
+
+	createRequest := CreatePostRequest{
+		Title:   request.GetTitle(),
+		Content: request.GetContent(),
+		Author:  request.GetAuthor(),
+		Tags:    request.GetTags(),
+	}
+	if request.GetStatus() != postsv1.Status_STATUS_UNSPECIFIED {
+		postStatus, ok := domainValue(protoStatuses, request.GetStatus())
+		if !ok {
+			return nil, grpcError(fmt.Sprintf("unknown status (%d)", request.GetStatus()), ErrorBadRequest)
+		}
+		createRequest.Status = postStatus
+	}
+	if request.GetContentFormat() != postsv1.ContentFormat_CONTENT_FORMAT_UNSPECIFIED {
+		format, ok := domainValue(protoContentFormats, request.GetContentFormat())
+		if !ok {
+			return nil, grpcError(fmt.Sprintf("unknown content format (%d)", request.GetContentFormat()), ErrorBadRequest)
+		}
+		createRequest.ContentFormat = format
+	}
+	if request.PublishAt != nil {
+		publishAt := request.GetPublishAt().AsTime()
+		createRequest.PublishAt = &publishAt
+	}
+	// The gRPC API is not authenticated, so its callers can only create drafts.
+	if createRequest.RequiresAdmin() {
+		return nil, grpcError("admin credentials required to publish or schedule posts", httputil.ErrUnauthorized)
+	}
+
+	postID, err := a.Service.CreateBlogPost(createRequest)
+	if err != nil {
+		return nil, grpcError("unexpected error creating post", err)
+	}
+
+	return &postsv1.CreatePostResponse{Id: postID}, nil
+}
+
+// ListComments Returns the approved comment thread of a post nested as a tree, or the replies of one of its comments.
+// Replies are read down to `max_depth` levels, up to MaxCommentReplies below every comment.
+func (a *grpcAdapter) ListComments(_ context.Context, request *postsv1.ListCommentsRequest) (*postsv1.ListCommentsResponse, error) {
+	p := grpcPagination(request.GetPage(), request.GetLimit())
+
+	maxDepth := DefaultCommentDepth
+	if request.MaxDepth != nil {
+		maxDepth = int(request.GetMaxDepth())
+		if maxDepth < 0 || maxDepth > MaxCommentDepth {
+			return nil, grpcError(fmt.Sprintf("max_depth must be a number between 0 and %d", MaxCommentDepth), ErrorBadRequest)
+		}
+	}
+
+	query := CommentsQuery{
+		ParentID: request.GetParentId(),
+		MaxDepth: maxDepth,
+		Limit:    p.Limit,
+		Offset:   p.Offset,
+	}
+
+	comments, err := a.Service.GetComments(request.GetPostId(), query)
+	if err != nil {
+		return nil, grpcError(fmt.Sprintf("unexpected error getting comments of post with ID (%s)", request.GetPostId()), err)
+	}
+
+	return &postsv1.ListCommentsResponse{
+		Comments: protoComments(CommentTree(comments)),
+		Pagination: &postsv1.Pagination{
+			Limit:  int32(p.Limit),
+			Offset: int32(p.Offset),
+			Page:   int32(p.Page),
+		},
+	}, nil
+}
+
+// CreateComment Creates new comment for a post, replying to another comment when `parent_id` is set.
+func (a *grpcAdapter) CreateComment(_ context.Context, request *postsv1.CreateCommentRequest) (*postsv1.CreateCommentResponse, error) {
+	if request.GetText() == "" {
+		return nil, grpcError("missing comment text", ErrorBadRequest)
+	}
+
+	var commentID string
+	var err error
+	if request.GetParentId() != "" {
+		commentID, err = a.Service.CreateReply(request.GetPostId(), request.GetParentId(), request.GetText())
+	} else {
+		commentID, err = a.Service.CreateComment(request.GetPostId(), request.GetText())
+	}
+	if err != nil {
+		return nil, grpcError("unexpected error creating comment", err)
+	}
+
+	return &postsv1.CreateCommentResponse{Id: commentID}, nil
+}
+
+// grpcError Translates service errors into gRPC status errors, internal when they are not mapped.
+func grpcError(msg string, serviceErr error) error {
+	code := codes.Internal
+	for errMap, errCode := range grpcErrMapper {
+		if errors.Is(serviceErr, errMap) {
+			code = errCode
+		}
+	}
+	return status.Errorf(code, "%s: %s", msg, serviceErr)
+}
+
+// grpcPagination Builds pagination from gRPC request params the same way HTTP params are read.
+func grpcPagination(page, limit int32) httputil.Pagination {
+	p := httputil.Pagination{Page: int(page), Limit: int(limit)}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Limit < 1 {
+		p.Limit = httputil.DefaultLimit
+	}
+	p.Offset = (p.Page - 1) * p.Limit
+	return p
+}
+
+// domainValue Returns the domain value of a protobuf enum value, and whether it has one.
+func domainValue[D comparable, P comparable](values map[D]P, value P) (D, bool) {
+	for domain, proto := range values {
+		if proto == value {
+			return domain, true
+		}
+	}
+	var zero D
+	return zero, false
+}
+
+// protoBlogPost Converts a blog post into its protobuf message.
+func protoBlogPost(post BlogPost) *postsv1.BlogPost {
+	return &postsv1.BlogPost{
+		Id:            post.ID,
+		Slug:          post.Slug,
+		Title:         post.Title,
+		Content:       post.Content,
+		Author:        post.Author,
+		ContentFormat: protoContentFormats[post.ContentFormat],
+		ContentHtml:   post.ContentHTML,
+		Excerpt:       post.Excerpt,
+		CommentCount:  int32(post.CommentCount),
+		Status:        protoStatuses[post.Status],
+		PublishAt:     protoTimestamp(post.PublishAt),
+		Tags:          post.Tags,
+		CreatedAt:     protoTimestamp(&post.CreatedAt),
+		UpdatedAt:     protoTimestamp(&post.UpdatedAt),
+	}
+}
+
+// protoComments Converts a comment tree into protobuf messages.
+func protoComments(comments []Comment) []*postsv1.Comment {
+	messages := make([]*postsv1.Comment, len(comments))
+	for i, comment := range comments {
+		messages[i] = &postsv1.Comment{
+			Id:               comment.ID,
+			PostId:           comment.BlogPostID,
+			Text:             comment.CommentText,
+			ModerationStatus: protoModerationStatuses[comment.ModerationStatus],
+			ParentId:         comment.ParentID,
+			Depth:            int32(comment.Depth),
+			ReplyCount:       int32(comment.ReplyCount),
+			Replies:          protoComments(comment.Replies),
+			CreatedAt:        protoTimestamp(&comment.CreatedAt),
+		}
+	}
+	return messages
+}
+
+// protoTimestamp Converts a time into a protobuf timestamp, nil when it is not set.
+func protoTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,439 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	postsv1 "github.com/MatiasKopp/prosig-code-challenge/proto/posts/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcClient Serves a gRPC adapter backed by service over an in-memory connection and returns a client of it.
+func grpcClient(t *testing.T, service Service) postsv1.PostsServiceClient {
+	t.Helper()
+
+	adapter, err := NewGRPCAdapter(service)
+	if err != nil {
+		t.Fatalf("failed to create adapter: %v", err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	postsv1.RegisterPostsServiceServer(server, adapter)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial adapter: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return postsv1.NewPostsServiceClient(conn)
+}
+
+// checkGRPCResult Fails the test when a gRPC call did not end with the wanted code and response.
+func checkGRPCResult(t *testing.T, got proto.Message, err error, wantCode codes.Code, wantMsg string, want proto.Message) {
+	t.Helper()
+
+	if status.Code(err) != wantCode {
+		t.Fatalf("got code %s, want %s (%v)", status.Code(err), wantCode, err)
+	}
+	if err != nil {
+		if msg := status.Convert(err).Message(); msg != wantMsg {
+			t.Errorf("got message %q, want %q", msg, wantMsg)
+		}
+		return
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("got response %v, want %v", got, want)
+	}
+}
+
+func Test_grpcAdapter_ListPosts(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		setup    func(s *MocksService)
+		request  *postsv1.ListPostsRequest
+		wantCode codes.Code
+		wantMsg  string
+		want     *postsv1.ListPostsResponse
+	}{
+		{
+			name: "default_page_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return([]BlogPost{{
+					ID:            "1",
+					Slug:          "first-post",
+					Title:         "First Post",
+					ContentFormat: ContentFormatMarkdown,
+					Status:        StatusPublished,
+					PublishAt:     &createdAt,
+					Tags:          []string{"go"},
+					CreatedAt:     createdAt,
+				}}, nil)
+			},
+			request:  &postsv1.ListPostsRequest{},
+			wantCode: codes.OK,
+			want: &postsv1.ListPostsResponse{
+				Posts: []*postsv1.BlogPost{{
+					Id:            "1",
+					Slug:          "first-post",
+					Title:         "First Post",
+					ContentFormat: postsv1.ContentFormat_CONTENT_FORMAT_MARKDOWN,
+					Status:        postsv1.Status_STATUS_PUBLISHED,
+					PublishAt:     timestamppb.New(createdAt),
+					Tags:          []string{"go"},
+					CreatedAt:     timestamppb.New(createdAt),
+				}},
+				Pagination: &postsv1.Pagination{Limit: 10, Offset: 0, Page: 1},
+			},
+		},
+		{
+			name: "tagged_page_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetAllBlogPosts(5, 10, ReadOptions{Tags: []string{"go", "sql"}, MatchAllTags: true}).Return(nil, nil)
+			},
+			request:  &postsv1.ListPostsRequest{Page: 3, Limit: 5, Tags: []string{"go", "sql"}, MatchAllTags: true},
+			wantCode: codes.OK,
+			want:     &postsv1.ListPostsResponse{Pagination: &postsv1.Pagination{Limit: 5, Offset: 10, Page: 3}},
+		},
+		{
+			name: "service_error_internal",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return(nil, errors.New("boom"))
+			},
+			request:  &postsv1.ListPostsRequest{},
+			wantCode: codes.Internal,
+			wantMsg:  "unexpected error getting all blog posts: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMocksService(t)
+			tt.setup(service)
+
+			got, err := grpcClient(t, service).ListPosts(t.Context(), tt.request)
+			checkGRPCResult(t, got, err, tt.wantCode, tt.wantMsg, tt.want)
+		})
+	}
+}
+
+func Test_grpcAdapter_GetPost(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(s *MocksService)
+		request  *postsv1.GetPostRequest
+		wantCode codes.Code
+		wantMsg  string
+		want     *postsv1.GetPostResponse
+	}{
+		{
+			name: "by_slug_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetBlogPost("first-post", ReadOptions{}).Return(&BlogPost{
+					ID:           "1",
+					Slug:         "first-post",
+					ContentHTML:  "<p>body</p>",
+					CommentCount: 2,
+					Status:       StatusPublished,
+				}, nil)
+			},
+			request:  &postsv1.GetPostRequest{Id: "first-post"},
+			wantCode: codes.OK,
+			want: &postsv1.GetPostResponse{Post: &postsv1.BlogPost{
+				Id:           "1",
+				Slug:         "first-post",
+				ContentHtml:  "<p>body</p>",
+				CommentCount: 2,
+				Status:       postsv1.Status_STATUS_PUBLISHED,
+			}},
+		},
+		{
+			name: "not_found",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetBlogPost("nope", ReadOptions{}).Return(nil, ErrBlogPostNotFound)
+			},
+			request:  &postsv1.GetPostRequest{Id: "nope"},
+			wantCode: codes.NotFound,
+			wantMsg:  "unexpected error getting post with ID (nope): blog post not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMocksService(t)
+			tt.setup(service)
+
+			got, err := grpcClient(t, service).GetPost(t.Context(), tt.request)
+			checkGRPCResult(t, got, err, tt.wantCode, tt.wantMsg, tt.want)
+		})
+	}
+}
+
+func Test_grpcAdapter_CreatePost(t *testing.T) {
+	publishAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		setup    func(s *MocksService)
+		request  *postsv1.CreatePostRequest
+		wantCode codes.Code
+		wantMsg  string
+		want     *postsv1.CreatePostResponse
+	}{
+		{
+			name: "defaults_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateBlogPost(CreatePostRequest{Title: "t", Content: "c"}).Return("1", nil)
+			},
+			request:  &postsv1.CreatePostRequest{Title: "t", Content: "c"},
+			wantCode: codes.OK,
+			want:     &postsv1.CreatePostResponse{Id: "1"},
+		},
+		{
+			name: "draft_markdown_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateBlogPost(CreatePostRequest{
+					Title:         "t",
+					Content:       "c",
+					Author:        "ana",
+					Status:        StatusDraft,
+					Tags:          []string{"go"},
+					ContentFormat: ContentFormatMarkdown,
+				}).Return("2", nil)
+			},
+			request: &postsv1.CreatePostRequest{
+				Title:         "t",
+				Content:       "c",
+				Author:        "ana",
+				Status:        postsv1.Status_STATUS_DRAFT,
+				Tags:          []string{"go"},
+				ContentFormat: postsv1.ContentFormat_CONTENT_FORMAT_MARKDOWN,
+			},
+			wantCode: codes.OK,
+			want:     &postsv1.CreatePostResponse{Id: "2"},
+		},
+		{
+			name:     "missing_content_invalid_argument",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.CreatePostRequest{Title: "t"},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "missing title or content: bad request",
+		},
+		{
+			name:     "published_permission_denied",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.CreatePostRequest{Title: "t", Content: "c", Status: postsv1.Status_STATUS_PUBLISHED},
+			wantCode: codes.PermissionDenied,
+			wantMsg:  "admin credentials required to publish or schedule posts: unauthorized",
+		},
+		{
+			name:     "scheduled_permission_denied",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.CreatePostRequest{Title: "t", Content: "c", Status: postsv1.Status_STATUS_SCHEDULED, PublishAt: timestamppb.New(publishAt)},
+			wantCode: codes.PermissionDenied,
+			wantMsg:  "admin credentials required to publish or schedule posts: unauthorized",
+		},
+		{
+			name:     "publish_at_permission_denied",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.CreatePostRequest{Title: "t", Content: "c", PublishAt: timestamppb.New(publishAt)},
+			wantCode: codes.PermissionDenied,
+			wantMsg:  "admin credentials required to publish or schedule posts: unauthorized",
+		},
+		{
+			name: "rejected_content_invalid_argument",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateBlogPost(CreatePostRequest{Title: "t", Content: "casino"}).
+					Return("", &ContentRejectedError{Reasons: []string{"blocked word"}})
+			},
+			request:  &postsv1.CreatePostRequest{Title: "t", Content: "casino"},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "unexpected error creating post: content rejected: blocked word",
+		},
+		{
+			name:     "unknown_status_invalid_argument",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.CreatePostRequest{Title: "t", Content: "c", Status: postsv1.Status(99)},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "unknown status (99): bad request",
+		},
+		{
+			name:     "unknown_content_format_invalid_argument",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.CreatePostRequest{Title: "t", Content: "c", ContentFormat: postsv1.ContentFormat(99)},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "unknown content format (99): bad request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMocksService(t)
+			tt.setup(service)
+
+			got, err := grpcClient(t, service).CreatePost(t.Context(), tt.request)
+			checkGRPCResult(t, got, err, tt.wantCode, tt.wantMsg, tt.want)
+		})
+	}
+}
+
+func Test_grpcAdapter_ListComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(s *MocksService)
+		request  *postsv1.ListCommentsRequest
+		wantCode codes.Code
+		wantMsg  string
+		want     *postsv1.ListCommentsResponse
+	}{
+		{
+			name: "thread_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetComments("1", CommentsQuery{MaxDepth: DefaultCommentDepth, Limit: 10}).Return([]Comment{
+					{ID: "c1", BlogPostID: "1", CommentText: "hi", ModerationStatus: ModerationApproved, ReplyCount: 1},
+					{ID: "c2", BlogPostID: "1", CommentText: "hey", ModerationStatus: ModerationApproved, ParentID: "c1", Depth: 1},
+				}, nil)
+			},
+			request:  &postsv1.ListCommentsRequest{PostId: "1"},
+			wantCode: codes.OK,
+			want: &postsv1.ListCommentsResponse{
+				Comments: []*postsv1.Comment{{
+					Id:               "c1",
+					PostId:           "1",
+					Text:             "hi",
+					ModerationStatus: postsv1.ModerationStatus_MODERATION_STATUS_APPROVED,
+					ReplyCount:       1,
+					Replies: []*postsv1.Comment{{
+						Id:               "c2",
+						PostId:           "1",
+						Text:             "hey",
+						ModerationStatus: postsv1.ModerationStatus_MODERATION_STATUS_APPROVED,
+						ParentId:         "c1",
+						Depth:            1,
+					}},
+				}},
+				Pagination: &postsv1.Pagination{Limit: 10, Offset: 0, Page: 1},
+			},
+		},
+		{
+			name: "replies_without_depth_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetComments("1", CommentsQuery{ParentID: "c1", Limit: 2, Offset: 2}).Return(nil, nil)
+			},
+			request:  &postsv1.ListCommentsRequest{PostId: "1", ParentId: "c1", Page: 2, Limit: 2, MaxDepth: proto.Int32(0)},
+			wantCode: codes.OK,
+			want:     &postsv1.ListCommentsResponse{Pagination: &postsv1.Pagination{Limit: 2, Offset: 2, Page: 2}},
+		},
+		{
+			name:     "too_deep_invalid_argument",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.ListCommentsRequest{PostId: "1", MaxDepth: proto.Int32(MaxCommentDepth + 1)},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "max_depth must be a number between 0 and 10: bad request",
+		},
+		{
+			name: "unknown_comment_not_found",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetComments("1", CommentsQuery{ParentID: "nope", MaxDepth: DefaultCommentDepth, Limit: 10}).
+					Return(nil, ErrCommentNotFound)
+			},
+			request:  &postsv1.ListCommentsRequest{PostId: "1", ParentId: "nope"},
+			wantCode: codes.NotFound,
+			wantMsg:  "unexpected error getting comments of post with ID (1): comment not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMocksService(t)
+			tt.setup(service)
+
+			got, err := grpcClient(t, service).ListComments(t.Context(), tt.request)
+			checkGRPCResult(t, got, err, tt.wantCode, tt.wantMsg, tt.want)
+		})
+	}
+}
+
+func Test_grpcAdapter_CreateComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(s *MocksService)
+		request  *postsv1.CreateCommentRequest
+		wantCode codes.Code
+		wantMsg  string
+		want     *postsv1.CreateCommentResponse
+	}{
+		{
+			name: "comment_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateComment("1", "hi").Return("c1", nil)
+			},
+			request:  &postsv1.CreateCommentRequest{PostId: "1", Text: "hi"},
+			wantCode: codes.OK,
+			want:     &postsv1.CreateCommentResponse{Id: "c1"},
+		},
+		{
+			name: "reply_ok",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateReply("1", "c1", "hey").Return("c2", nil)
+			},
+			request:  &postsv1.CreateCommentRequest{PostId: "1", ParentId: "c1", Text: "hey"},
+			wantCode: codes.OK,
+			want:     &postsv1.CreateCommentResponse{Id: "c2"},
+		},
+		{
+			name:     "missing_text_invalid_argument",
+			setup:    func(s *MocksService) {},
+			request:  &postsv1.CreateCommentRequest{PostId: "1"},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "missing comment text: bad request",
+		},
+		{
+			name: "unknown_post_not_found",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateComment("nope", "hi").Return("", ErrBlogPostNotFound)
+			},
+			request:  &postsv1.CreateCommentRequest{PostId: "nope", Text: "hi"},
+			wantCode: codes.NotFound,
+			wantMsg:  "unexpected error creating comment: blog post not found",
+		},
+		{
+			name: "deleted_parent_failed_precondition",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateReply("1", "c1", "hey").Return("", ErrParentCommentDeleted)
+			},
+			request:  &postsv1.CreateCommentRequest{PostId: "1", ParentId: "c1", Text: "hey"},
+			wantCode: codes.FailedPrecondition,
+			wantMsg:  "unexpected error creating comment: " + ErrParentCommentDeleted.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMocksService(t)
+			tt.setup(service)
+
+			got, err := grpcClient(t, service).CreateComment(t.Context(), tt.request)
+			checkGRPCResult(t, got, err, tt.wantCode, tt.wantMsg, tt.want)
+		})
+	}
+}
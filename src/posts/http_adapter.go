@@ -4,19 +4,51 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/MatiasKopp/prosig-code-challenge/httputil"
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	// DefaultCommentDepth Levels of replies returned when no max_depth is requested.
+	DefaultCommentDepth = 3
+	// MaxCommentDepth Maximum levels of replies that can be requested at once.
+	MaxCommentDepth = 10
+	// MaxCommentReplies Maximum replies returned below every comment of a thread, the rest are read from its replies.
+	MaxCommentReplies = 20
+	// MaxBatchPosts Maximum posts created by a single batch request.
+	MaxBatchPosts = 100
+	// MaxModeratedComments Maximum comments moderated by a single request.
+	MaxModeratedComments = 100
+)
+
 var (
 	errMapper = map[error]int{
-		ErrBlogPostNotFound: http.StatusNotFound,
-		ErrorBadRequest:     http.StatusBadRequest,
+		ErrBlogPostNotFound:        http.StatusNotFound,
+		ErrCommentNotFound:         http.StatusNotFound,
+		ErrRevisionNotFound:        http.StatusNotFound,
+		ErrorBadRequest:            http.StatusBadRequest,
+		ErrInvalidStatusTransition: http.StatusConflict,
+		ErrParentCommentDeleted:    http.StatusConflict,
+		ErrContentRejected:         http.StatusUnprocessableEntity,
+		httputil.ErrUnauthorized:   http.StatusUnauthorized,
 	}
 
 	ErrorBadRequest = errors.New("bad request")
+
+	// blogPostFields Fields that can be picked with the `fields` param.
+	blogPostFields = httputil.JSONFieldNames(BlogPost{})
+	// summaryOmittedFields Fields left out of post summaries.
+	summaryOmittedFields = []string{"content", "content_html", "comments"}
+	// listParams Query params of post listings other than ListFilter ones.
+	listParams = []string{"page", "limit", "tag", "tag_match", "fields", "include", "comments_limit", "view"}
 )
 
 // httpAdapter Productive post http adapter implementation
@@ -31,11 +63,76 @@ func NewHTTPAdapter(service Service) (HTTPAdapter, error) {
 	}, nil
 }
 
-// GetAllPosts Returns all posts.
+// GetAllPosts Returns all posts, with the settings only admins can see left out for everyone else.
+// Posts can be filtered by many `tag` params, matching any of them unless `tag_match=all` is requested.
+// Comments are only embedded with `include=comments`, the latest `comments_limit` of them when provided,
+// and `fields` picks which fields of every post are returned. `view=summary` leaves out content and comments.
+// Posts can also be narrowed by any ListFilter param, and unknown params are rejected.
 func (a *httpAdapter) GetAllPosts(w http.ResponseWriter, r *http.Request) {
 	p := httputil.GetPaginationParams(r)
 
-	posts, err := a.Service.GetAllBlogPosts(p.Limit, p.Offset)
+	var filter ListFilter
+	if err := httputil.GetFilterParams(r, &filter, listParams...); err != nil {
+		httputil.HandlerHTTPError(w, err.Error(), ErrorBadRequest, errMapper)
+		return
+	}
+
+	tagMatch := r.URL.Query().Get("tag_match")
+	if tagMatch != "" && tagMatch != "any" && tagMatch != "all" {
+		httputil.HandlerHTTPError(w, "tag_match must be either any or all", ErrorBadRequest, errMapper)
+		return
+	}
+
+	fields, err := httputil.GetFieldsParam(r, blogPostFields)
+	if err != nil {
+		httputil.HandlerHTTPError(w, err.Error(), ErrorBadRequest, errMapper)
+		return
+	}
+
+	includeComments := false
+	if include := r.URL.Query().Get("include"); include != "" {
+		if include != "comments" {
+			httputil.HandlerHTTPError(w, "include must be comments", ErrorBadRequest, errMapper)
+			return
+		}
+		includeComments = true
+	}
+
+	commentsLimit := 0
+	if commentsLimitStr := r.URL.Query().Get("comments_limit"); commentsLimitStr != "" {
+		commentsLimit, err = strconv.Atoi(commentsLimitStr)
+		if err != nil || commentsLimit < 1 {
+			httputil.HandlerHTTPError(w, "comments_limit must be a positive number", ErrorBadRequest, errMapper)
+			return
+		}
+	}
+
+	switch r.URL.Query().Get("view") {
+	case "", "full":
+	case "summary":
+		if fields == nil {
+			fields = blogPostFields
+		}
+		fields = slices.DeleteFunc(slices.Clone(fields), func(field string) bool {
+			return slices.Contains(summaryOmittedFields, field)
+		})
+		includeComments = false
+	default:
+		httputil.HandlerHTTPError(w, "view must be either full or summary", ErrorBadRequest, errMapper)
+		return
+	}
+
+	opts := ReadOptions{
+		IncludeUnpublished: httputil.IsAdmin(r),
+		IncludeUnapproved:  httputil.IsAdmin(r),
+		Tags:               r.URL.Query()["tag"],
+		MatchAllTags:       tagMatch == "all",
+		IncludeComments:    includeComments,
+		CommentsLimit:      commentsLimit,
+		Filter:             filter,
+	}
+
+	posts, err := a.Service.GetAllBlogPosts(p.Limit, p.Offset, opts)
 	if err != nil {
 		httputil.HandlerHTTPError(w, "unexpected error getting all blog posts", err, errMapper)
 		return
@@ -44,6 +141,32 @@ func (a *httpAdapter) GetAllPosts(w http.ResponseWriter, r *http.Request) {
 	if len(posts) == 0 {
 		posts = []BlogPost{}
 	}
+	if !httputil.IsAdmin(r) {
+		for i := range posts {
+			posts[i] = posts[i].PublicView()
+		}
+	}
+	if includeComments {
+		for i := range posts {
+			if posts[i].Comments == nil {
+				posts[i].Comments = []Comment{}
+			}
+		}
+	}
+
+	if fields != nil {
+		sparsePosts := make([]any, len(posts))
+		for i, post := range posts {
+			sparsePosts[i], err = httputil.PickFields(post, fields)
+			if err != nil {
+				httputil.HandlerHTTPError(w, "unexpected error picking post fields", err, errMapper)
+				return
+			}
+		}
+		httputil.HandlerHTTPResponse(w, http.StatusOK, map[string]any{"blog_posts": sparsePosts, "pagination": p})
+		return
+	}
+
 	response := GetAllResponse{
 		BlogPosts:  posts,
 		Pagination: p,
@@ -52,22 +175,51 @@ func (a *httpAdapter) GetAllPosts(w http.ResponseWriter, r *http.Request) {
 	httputil.HandlerHTTPResponse(w, http.StatusOK, response)
 }
 
-// GetPost Returns single specific post.
+// GetPost Returns single specific post, found by ID or slug.
+// Old slugs of renamed posts permanently redirect to their current slug, and `fields` picks which fields are returned.
+// Settings only admins can see are left out for everyone else.
 func (a *httpAdapter) GetPost(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	post, err := a.Service.GetBlogPost(id)
+	fields, err := httputil.GetFieldsParam(r, blogPostFields)
+	if err != nil {
+		httputil.HandlerHTTPError(w, err.Error(), ErrorBadRequest, errMapper)
+		return
+	}
+
+	opts := ReadOptions{
+		IncludeUnpublished: httputil.IsAdmin(r),
+		IncludeUnapproved:  httputil.IsAdmin(r),
+		IncludeComments:    fields == nil || slices.Contains(fields, "comments"),
+	}
+
+	post, err := a.Service.GetBlogPost(id, opts)
 	if err != nil {
 		msg := fmt.Sprintf("unexpected error getting post with ID (%s)", id)
 		httputil.HandlerHTTPError(w, msg, err, errMapper)
 		return
 	}
 
-	if len(post.Comments) == 0 {
+	if id != post.ID && id != post.Slug {
+		location := url.URL{Path: path.Join(path.Dir(r.URL.Path), post.Slug), RawQuery: r.URL.RawQuery}
+		http.Redirect(w, r, location.String(), http.StatusMovedPermanently)
+		return
+	}
+
+	if opts.IncludeComments && len(post.Comments) == 0 {
 		post.Comments = []Comment{}
 	}
+	if !httputil.IsAdmin(r) {
+		*post = post.PublicView()
+	}
+
+	response, err := httputil.PickFields(post, fields)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error picking post fields", err, errMapper)
+		return
+	}
 
-	httputil.HandlerHTTPResponse(w, http.StatusOK, post)
+	httputil.HandlerHTTPResponse(w, http.StatusOK, response)
 }
 
 // CreatePost Creates new post.
@@ -84,8 +236,12 @@ func (a *httpAdapter) CreatePost(w http.ResponseWriter, r *http.Request) {
 		httputil.HandlerHTTPError(w, "missing title or content", ErrorBadRequest, errMapper)
 		return
 	}
+	if requestBody.RequiresAdmin() && !httputil.IsAdmin(r) {
+		httputil.HandlerHTTPError(w, "admin credentials required to publish or schedule posts", httputil.ErrUnauthorized, errMapper)
+		return
+	}
 
-	postID, err := a.Service.CreateBlogPost(requestBody.Title, requestBody.Content)
+	postID, err := a.Service.CreateBlogPost(requestBody)
 	if err != nil {
 		httputil.HandlerHTTPError(w, "unexpected error creating post", err, errMapper)
 		return
@@ -94,6 +250,191 @@ func (a *httpAdapter) CreatePost(w http.ResponseWriter, r *http.Request) {
 	httputil.HandlerHTTPResponse(w, http.StatusCreated, map[string]any{"blog_post_id": postID})
 }
 
+// CreatePosts Creates up to MaxBatchPosts posts at once, either all of them or, with `best_effort` mode, every valid one.
+// Responds 201 when every post is created. Otherwise, atomic batches respond with the error of the failed post
+// and its index, and best effort batches respond 207 along with the status of every post.
+func (a *httpAdapter) CreatePosts(w http.ResponseWriter, r *http.Request) {
+	var requestBody CreatePostsRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error reading batch post creation body", err, errMapper)
+		return
+	}
+
+	if len(requestBody.Posts) == 0 {
+		httputil.HandlerHTTPError(w, "missing posts", ErrorBadRequest, errMapper)
+		return
+	}
+	if len(requestBody.Posts) > MaxBatchPosts {
+		msg := fmt.Sprintf("at most %d posts can be created at once", MaxBatchPosts)
+		httputil.HandlerHTTPError(w, msg, ErrorBadRequest, errMapper)
+		return
+	}
+
+	switch requestBody.Mode {
+	case "", BatchAtomic, BatchBestEffort:
+	default:
+		httputil.HandlerHTTPError(w, "mode must be either atomic or best_effort", ErrorBadRequest, errMapper)
+		return
+	}
+
+	results, err := a.Service.CreateBlogPosts(requestBody.Posts, requestBody.Mode != BatchBestEffort)
+	var itemErr *BatchItemError
+	if errors.As(err, &itemErr) {
+		httputil.HandlerHTTPError(w, fmt.Sprintf("post %d failed, no post was created", itemErr.Index), err, errMapper)
+		return
+	}
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error creating posts", err, errMapper)
+		return
+	}
+
+	statusCode := http.StatusCreated
+	response := CreatePostsResponse{Results: make([]BatchItemResponse, len(results))}
+	for i, result := range results {
+		item := BatchItemResponse{Index: i, Status: http.StatusCreated, BlogPostID: result.ID}
+		if result.Err != nil {
+			item.Status = httputil.StatusCode(result.Err, errMapper)
+			item.Error = result.Err.Error()
+			statusCode = http.StatusMultiStatus
+		}
+		response.Results[i] = item
+	}
+
+	httputil.HandlerHTTPResponse(w, statusCode, response)
+}
+
+// UpdatePost Updates title and content of specific post.
+func (a *httpAdapter) UpdatePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var requestBody UpdatePostRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error reading post update body", err, errMapper)
+		return
+	}
+
+	if requestBody.Title == "" || requestBody.Content == "" {
+		httputil.HandlerHTTPError(w, "missing title or content", ErrorBadRequest, errMapper)
+		return
+	}
+
+	err = a.Service.UpdateBlogPost(id, requestBody)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error updating post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// GetRevisions Returns content revisions of specific post, newest first.
+func (a *httpAdapter) GetRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	p := httputil.GetPaginationParams(r)
+
+	revisions, err := a.Service.GetRevisions(id, p.Limit, p.Offset)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error getting revisions of post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetRevisionsResponse{
+		Revisions:  revisions,
+		Pagination: p,
+	})
+}
+
+// GetRevision Returns single content revision of specific post.
+func (a *httpAdapter) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	number, err := revisionNumber(chi.URLParam(r, "rev"))
+	if err != nil {
+		httputil.HandlerHTTPError(w, "invalid revision number", err, errMapper)
+		return
+	}
+
+	revision, err := a.Service.GetRevision(id, number)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error getting revision %d of post with ID (%s)", number, id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, revision)
+}
+
+// DiffRevisions Returns a unified diff between the `from` and `to` content revisions of specific post.
+func (a *httpAdapter) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	from, err := revisionNumber(r.URL.Query().Get("from"))
+	if err != nil {
+		httputil.HandlerHTTPError(w, "from must be a revision number", err, errMapper)
+		return
+	}
+	to, err := revisionNumber(r.URL.Query().Get("to"))
+	if err != nil {
+		httputil.HandlerHTTPError(w, "to must be a revision number", err, errMapper)
+		return
+	}
+
+	diff, err := a.Service.DiffRevisions(id, from, to)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error diffing revisions of post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, DiffRevisionsResponse{From: from, To: to, Diff: diff})
+}
+
+// RestoreRevision Restores title and content of specific post from one of its revisions.
+func (a *httpAdapter) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	number, err := revisionNumber(chi.URLParam(r, "rev"))
+	if err != nil {
+		httputil.HandlerHTTPError(w, "invalid revision number", err, errMapper)
+		return
+	}
+
+	err = a.Service.RestoreRevision(id, number)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error restoring revision %d of post with ID (%s)", number, id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// revisionNumber Parses a revision number, which starts at 1.
+func revisionNumber(s string) (int, error) {
+	number, err := strconv.Atoi(s)
+	if err != nil || number < 1 {
+		return 0, fmt.Errorf("%w: invalid revision number (%s)", ErrorBadRequest, s)
+	}
+	return number, nil
+}
+
+// GetTags Returns all tags in use with their post counts.
+func (a *httpAdapter) GetTags(w http.ResponseWriter, r *http.Request) {
+	opts := ReadOptions{IncludeUnpublished: httputil.IsAdmin(r)}
+
+	tags, err := a.Service.GetTags(opts)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error getting tags", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetTagsResponse{Tags: tags})
+}
+
 // CreateComment Creates new comment for specific post.
 func (a *httpAdapter) CreateComment(w http.ResponseWriter, r *http.Request) {
 	blogPostID := chi.URLParam(r, "id")
@@ -118,3 +459,300 @@ func (a *httpAdapter) CreateComment(w http.ResponseWriter, r *http.Request) {
 
 	httputil.HandlerHTTPResponse(w, http.StatusCreated, map[string]any{"comment_id": commentID})
 }
+
+// GetComments Returns the comment thread of specific post, or the replies of one of its comments.
+// Comments are nested as a tree unless `format=flat` is requested, and replies are read down to `max_depth` levels,
+// up to MaxCommentReplies below every comment.
+func (a *httpAdapter) GetComments(w http.ResponseWriter, r *http.Request) {
+	blogPostID := chi.URLParam(r, "id")
+	p := httputil.GetPaginationParams(r)
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "tree" && format != "flat" {
+		httputil.HandlerHTTPError(w, "format must be either tree or flat", ErrorBadRequest, errMapper)
+		return
+	}
+
+	maxDepth := DefaultCommentDepth
+	if maxDepthStr := r.URL.Query().Get("max_depth"); maxDepthStr != "" {
+		var err error
+		maxDepth, err = strconv.Atoi(maxDepthStr)
+		if err != nil || maxDepth < 0 || maxDepth > MaxCommentDepth {
+			msg := fmt.Sprintf("max_depth must be a number between 0 and %d", MaxCommentDepth)
+			httputil.HandlerHTTPError(w, msg, ErrorBadRequest, errMapper)
+			return
+		}
+	}
+
+	query := CommentsQuery{
+		ParentID: chi.URLParam(r, "commentId"),
+		MaxDepth: maxDepth,
+		Limit:    p.Limit,
+		Offset:   p.Offset,
+
+		IncludeUnapproved: httputil.IsAdmin(r),
+	}
+
+	comments, err := a.Service.GetComments(blogPostID, query)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error getting comments of post with ID (%s)", blogPostID)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	if format != "flat" {
+		comments = CommentTree(comments)
+	}
+	if len(comments) == 0 {
+		comments = []Comment{}
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetCommentsResponse{
+		Comments:   comments,
+		Pagination: p,
+	})
+}
+
+// CreateReply Creates new reply to specific comment.
+func (a *httpAdapter) CreateReply(w http.ResponseWriter, r *http.Request) {
+	blogPostID := chi.URLParam(r, "id")
+	parentCommentID := chi.URLParam(r, "commentId")
+
+	var requestBody CreateCommentRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error reading reply creation body", err, errMapper)
+		return
+	}
+
+	if requestBody.Text == "" {
+		httputil.HandlerHTTPError(w, "missing comment text", ErrorBadRequest, errMapper)
+		return
+	}
+
+	commentID, err := a.Service.CreateReply(blogPostID, parentCommentID, requestBody.Text)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error creating reply", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusCreated, map[string]any{"comment_id": commentID})
+}
+
+// GetModerationQueue Returns comments with specific moderation status, pending by default.
+func (a *httpAdapter) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	p := httputil.GetPaginationParams(r)
+
+	status := ModerationStatus(r.URL.Query().Get("status"))
+	if status == "" {
+		status = ModerationPending
+	}
+
+	comments, err := a.Service.GetCommentsByModerationStatus(status, p.Limit, p.Offset)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error getting moderation queue", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetCommentsResponse{
+		Comments:   comments,
+		Pagination: p,
+	})
+}
+
+// ModerateComments Changes moderation status of many comments at once.
+func (a *httpAdapter) ModerateComments(w http.ResponseWriter, r *http.Request) {
+	var requestBody ModerateCommentsRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error reading comment moderation body", err, errMapper)
+		return
+	}
+
+	updated, err := a.Service.ModerateComments(requestBody.CommentIDs, requestBody.Status)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error moderating comments", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, map[string]any{"updated": updated})
+}
+
+// PublishPost Publishes or schedules specific post.
+func (a *httpAdapter) PublishPost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	// Body is optional, an empty one publishes the post right away.
+	var requestBody PublishPostRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil && !errors.Is(err, io.EOF) {
+		httputil.HandlerHTTPError(w, "unexpected error reading post publication body", err, errMapper)
+		return
+	}
+
+	err = a.Service.PublishBlogPost(id, requestBody.PublishAt)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error publishing post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// ArchivePost Archives specific post.
+func (a *httpAdapter) ArchivePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := a.Service.ArchiveBlogPost(id)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error archiving post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// DeletePost Moves specific post to trash.
+func (a *httpAdapter) DeletePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := a.Service.DeleteBlogPost(id)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error deleting post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// DeleteComment Moves specific comment and its replies to trash.
+func (a *httpAdapter) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	blogPostID := chi.URLParam(r, "id")
+	commentID := chi.URLParam(r, "commentId")
+
+	err := a.Service.DeleteComment(blogPostID, commentID)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error deleting comment with ID (%s)", commentID)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// GetDeletedPosts Returns posts in trash, most recently deleted first.
+func (a *httpAdapter) GetDeletedPosts(w http.ResponseWriter, r *http.Request) {
+	p := httputil.GetPaginationParams(r)
+
+	posts, err := a.Service.GetDeletedBlogPosts(p.Limit, p.Offset)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error getting deleted posts", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetDeletedPostsResponse{
+		BlogPosts:  posts,
+		Pagination: p,
+	})
+}
+
+// GetDeletedComments Returns comments in trash, most recently deleted first.
+func (a *httpAdapter) GetDeletedComments(w http.ResponseWriter, r *http.Request) {
+	p := httputil.GetPaginationParams(r)
+
+	comments, err := a.Service.GetDeletedComments(p.Limit, p.Offset)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error getting deleted comments", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetCommentsResponse{
+		Comments:   comments,
+		Pagination: p,
+	})
+}
+
+// RestorePost Takes specific post out of trash.
+func (a *httpAdapter) RestorePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := a.Service.RestoreBlogPost(id)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error restoring post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// RestoreComment Takes specific comment, and the replies deleted with it, out of trash.
+func (a *httpAdapter) RestoreComment(w http.ResponseWriter, r *http.Request) {
+	commentID := chi.URLParam(r, "commentId")
+
+	err := a.Service.RestoreComment(commentID)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error restoring comment with ID (%s)", commentID)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// PurgeTrash Permanently deletes posts and comments in trash for longer than the retention window.
+func (a *httpAdapter) PurgeTrash(w http.ResponseWriter, r *http.Request) {
+	purged, err := a.Service.PurgeTrash(time.Now())
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error purging trash", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, map[string]any{"purged": purged})
+}
+
+// ExportContent Streams all posts and comments as NDJSON, every post followed by its comments.
+func (a *httpAdapter) ExportContent(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	encoder := json.NewEncoder(w)
+	written := false
+	err := a.Service.ExportContent(func(record ExportRecord) error {
+		written = true
+		return encoder.Encode(record)
+	})
+	if err != nil {
+		if !written {
+			w.Header().Del("Content-Type")
+			httputil.HandlerHTTPError(w, "unexpected error exporting content", err, errMapper)
+			return
+		}
+		// The status was already sent, so the response is cut short for clients to notice the export is incomplete.
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// ImportContent Imports posts and comments from an NDJSON body in the export format, reporting lines that
+// could not be imported. Nothing is stored with `dry_run=true`.
+func (a *httpAdapter) ImportContent(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			httputil.HandlerHTTPError(w, "dry_run must be either true or false", ErrorBadRequest, errMapper)
+			return
+		}
+	}
+
+	result, err := a.Service.ImportContent(r.Body, dryRun)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error importing content", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, result)
+}
@@ -3,15 +3,43 @@ package posts
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
 	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
 )
 
+const testAdminToken = "test-admin-token"
+
+// adminRequest Returns a copy of the request authenticated as admin.
+func adminRequest(r *http.Request) *http.Request {
+	r.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	var authenticated *http.Request
+	httputil.Authenticate(testAdminToken)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authenticated = r
+	})).ServeHTTP(httptest.NewRecorder(), r)
+
+	return authenticated
+}
+
+// withURLParams Returns a copy of the request with injected chi route params.
+func withURLParams(r *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
 func Test_httpAdapter_GetAllPosts(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -25,7 +53,7 @@ func Test_httpAdapter_GetAllPosts(t *testing.T) {
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
 
-				service.EXPECT().GetAllBlogPosts(10, 0).Return([]BlogPost{}, nil)
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return([]BlogPost{}, nil)
 
 				return &httpAdapter{
 					Service: service,
@@ -40,12 +68,17 @@ func Test_httpAdapter_GetAllPosts(t *testing.T) {
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
 
-				service.EXPECT().GetAllBlogPosts(10, 0).Return([]BlogPost{
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return([]BlogPost{
 					{
-						ID:       "1",
-						Title:    "First Post",
-						Content:  "This is the body of the first post",
-						Comments: nil,
+						ID:      "1",
+						Slug:    "first-post",
+						Title:   "First Post",
+						Content: "This is the body of the first post",
+						Status:  StatusPublished,
+
+						ContentFormat: ContentFormatMarkdown,
+						ContentHTML:   "<p>This is the body of the first post</p>",
+						Comments:      nil,
 					},
 				}, nil)
 
@@ -55,14 +88,206 @@ func Test_httpAdapter_GetAllPosts(t *testing.T) {
 			},
 			request:    httptest.NewRequest(http.MethodGet, "/posts", nil),
 			wantStatus: http.StatusOK,
-			wantBody:   "{\"blog_posts\":[{\"id\":\"1\",\"title\":\"First Post\",\"content\":\"This is the body of the first post\",\"comments\":null}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+			wantBody:   "{\"blog_posts\":[{\"id\":\"1\",\"slug\":\"first-post\",\"title\":\"First Post\",\"content\":\"This is the body of the first post\",\"content_format\":\"markdown\",\"content_html\":\"\\u003cp\\u003eThis is the body of the first post\\u003c/p\\u003e\",\"excerpt\":\"\",\"comment_count\":0,\"status\":\"published\"}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "success_200_filtered_by_all_tags",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{Tags: []string{"go", "sqlite"}, MatchAllTags: true}).Return([]BlogPost{}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?tag=go&tag=sqlite&tag_match=all", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"blog_posts\":[],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "invalid_tag_match_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?tag=go&tag_match=some", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"tag_match must be either any or all\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "success_200_admin_includes_unpublished_and_unapproved",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{IncludeUnpublished: true, IncludeUnapproved: true}).Return([]BlogPost{}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    adminRequest(httptest.NewRequest(http.MethodGet, "/posts", nil)),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"blog_posts\":[],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "success_200_latest_comments_included",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{IncludeComments: true, CommentsLimit: 2}).Return([]BlogPost{
+					{ID: "1", Comments: []Comment{{ID: "5", CommentText: "hi"}}},
+					{ID: "2"},
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?include=comments&comments_limit=2&fields=id,comments", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"blog_posts\":[{\"comments\":[{\"id\":\"5\",\"comment_text\":\"hi\"}],\"id\":\"1\"},{\"comments\":[],\"id\":\"2\"}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "success_200_sparse_fields",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return([]BlogPost{
+					{ID: "1", Slug: "first-post", Title: "First Post", Content: "body", Status: StatusPublished},
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?fields=id,title", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"blog_posts\":[{\"id\":\"1\",\"title\":\"First Post\"}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "success_200_summary_view",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return([]BlogPost{
+					{ID: "1", Title: "First Post", Content: "body", ContentHTML: "<p>body</p>", Excerpt: "body", CommentCount: 3},
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?view=summary&include=comments&fields=id,content,excerpt,comment_count", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"blog_posts\":[{\"comment_count\":3,\"excerpt\":\"body\",\"id\":\"1\"}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "invalid_view_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?view=compact", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"view must be either full or summary\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "success_200_filtered",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				createdAfter := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+				hasComments := true
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{Filter: ListFilter{
+					CreatedAfter:  &createdAfter,
+					Author:        "ada",
+					TitleContains: "go",
+					HasComments:   &hasComments,
+					Status:        StatusPublished,
+				}}).Return([]BlogPost{}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?created_after=2026-01-01&author=ada&title_contains=go&has_comments=true&status=published&page=1", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"blog_posts\":[],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "unknown_filter_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?category=go", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"unknown filter (category)\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "repeated_filter_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?author=ada&author=bob", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"author must be given once\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "invalid_created_after_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?created_after=yesterday", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"created_after must be a date or an RFC 3339 timestamp\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "unknown_field_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?fields=id,publisher", nil),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid_include_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?include=tags", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"include must be comments\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "invalid_comments_limit_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts?include=comments&comments_limit=0", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"comments_limit must be a positive number\",\"cause\":\"bad request\"}",
 		},
 		{
 			name: "service_error_500",
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
 
-				service.EXPECT().GetAllBlogPosts(10, 0).Return(nil, errors.New("internal error"))
+				service.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return(nil, errors.New("internal error"))
 
 				return &httpAdapter{
 					Service: service,
@@ -100,6 +325,7 @@ func Test_httpAdapter_GetPost(t *testing.T) {
 		request    *http.Request
 		wantStatus int
 		wantBody   string
+		wantHeader http.Header
 		id         string
 	}{
 		{
@@ -107,11 +333,16 @@ func Test_httpAdapter_GetPost(t *testing.T) {
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
 
-				service.EXPECT().GetBlogPost("1").Return(&BlogPost{
-					ID:       "1",
-					Title:    "First Post",
-					Content:  "This is the body of the first post",
-					Comments: nil,
+				service.EXPECT().GetBlogPost("1", ReadOptions{IncludeComments: true}).Return(&BlogPost{
+					ID:      "1",
+					Slug:    "first-post",
+					Title:   "First Post",
+					Content: "This is the body of the first post",
+					Status:  StatusPublished,
+
+					ContentFormat: ContentFormatMarkdown,
+					ContentHTML:   "<p>This is the body of the first post</p>",
+					Comments:      nil,
 				}, nil)
 
 				return &httpAdapter{
@@ -120,7 +351,7 @@ func Test_httpAdapter_GetPost(t *testing.T) {
 			},
 			request:    httptest.NewRequest(http.MethodGet, "/posts/1", nil),
 			wantStatus: http.StatusOK,
-			wantBody:   "{\"id\":\"1\",\"title\":\"First Post\",\"content\":\"This is the body of the first post\",\"comments\":[]}",
+			wantBody:   "{\"id\":\"1\",\"slug\":\"first-post\",\"title\":\"First Post\",\"content\":\"This is the body of the first post\",\"content_format\":\"markdown\",\"content_html\":\"\\u003cp\\u003eThis is the body of the first post\\u003c/p\\u003e\",\"excerpt\":\"\",\"comment_count\":0,\"status\":\"published\",\"comments\":[]}",
 			id:         "1",
 		},
 		{
@@ -128,7 +359,7 @@ func Test_httpAdapter_GetPost(t *testing.T) {
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
 
-				service.EXPECT().GetBlogPost("1").Return(nil, errors.New("internal error"))
+				service.EXPECT().GetBlogPost("1", ReadOptions{IncludeComments: true}).Return(nil, errors.New("internal error"))
 
 				return &httpAdapter{
 					Service: service,
@@ -139,12 +370,112 @@ func Test_httpAdapter_GetPost(t *testing.T) {
 			wantBody:   "{\"message\":\"unexpected error getting post with ID (1)\",\"cause\":\"internal error\"}",
 			id:         "1",
 		},
+		{
+			name: "success_200_by_slug",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetBlogPost("first-post", ReadOptions{IncludeComments: true}).Return(&BlogPost{
+					ID:     "1",
+					Slug:   "first-post",
+					Title:  "First Post",
+					Status: StatusPublished,
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/first-post", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"id\":\"1\",\"slug\":\"first-post\",\"title\":\"First Post\",\"content\":\"\",\"content_format\":\"\",\"content_html\":\"\",\"excerpt\":\"\",\"comment_count\":0,\"status\":\"published\",\"comments\":[]}",
+			id:         "first-post",
+		},
+		{
+			name: "success_200_sparse_fields_without_comments",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{
+					ID:    "1",
+					Slug:  "first-post",
+					Title: "First Post",
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/1?fields=id,title", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"id\":\"1\",\"title\":\"First Post\"}",
+			id:         "1",
+		},
+		{
+			name: "success_200_comment_moderation_hidden",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{
+					ID:                "1",
+					Title:             "First Post",
+					CommentModeration: ModerationPending,
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/1?fields=id,title,comment_moderation", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"id\":\"1\",\"title\":\"First Post\"}",
+			id:         "1",
+		},
+		{
+			name: "success_200_comment_moderation_admin",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true, IncludeUnapproved: true}).Return(&BlogPost{
+					ID:                "1",
+					Title:             "First Post",
+					CommentModeration: ModerationPending,
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    adminRequest(httptest.NewRequest(http.MethodGet, "/posts/1?fields=id,title,comment_moderation", nil)),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"comment_moderation\":\"pending\",\"id\":\"1\",\"title\":\"First Post\"}",
+			id:         "1",
+		},
+		{
+			name: "old_slug_301",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().GetBlogPost("old-post", ReadOptions{IncludeComments: true}).Return(&BlogPost{
+					ID:   "1",
+					Slug: "first-post",
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/api/posts/old-post?page=2", nil),
+			wantStatus: http.StatusMovedPermanently,
+			wantHeader: http.Header{"Location": []string{"/api/posts/first-post?page=2"}},
+			id:         "old-post",
+		},
 		{
 			name: "service_not_found_404",
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
 
-				service.EXPECT().GetBlogPost("1").Return(nil, ErrBlogPostNotFound)
+				service.EXPECT().GetBlogPost("1", ReadOptions{IncludeComments: true}).Return(nil, ErrBlogPostNotFound)
 
 				return &httpAdapter{
 					Service: service,
@@ -173,6 +504,11 @@ func Test_httpAdapter_GetPost(t *testing.T) {
 			if recorder.Code != tt.wantStatus {
 				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
 			}
+			for key := range tt.wantHeader {
+				if recorder.Header().Get(key) != tt.wantHeader.Get(key) {
+					t.Errorf("got header %s %q, want %q", key, recorder.Header().Get(key), tt.wantHeader.Get(key))
+				}
+			}
 			if tt.wantBody != "" {
 				body, _ := io.ReadAll(recorder.Body)
 				if string(body) != tt.wantBody {
@@ -195,12 +531,12 @@ func Test_httpAdapter_CreatePost(t *testing.T) {
 			name: "success_201",
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
-				service.EXPECT().CreateBlogPost("some_title", "some_content").Return(1, nil)
+				service.EXPECT().CreateBlogPost(CreatePostRequest{Title: "some_title", Content: "some_content"}).Return("0192b7e4-6c1a-7d5e-9f1a-3b2c4d5e6f70", nil)
 				return &httpAdapter{Service: service}
 			},
 			request:    httptest.NewRequest(http.MethodPost, "/posts", io.NopCloser(strings.NewReader(`{"title":"some_title","content":"some_content"}`))),
 			wantStatus: http.StatusCreated,
-			wantBody:   "{\"blog_post_id\":1}",
+			wantBody:   "{\"blog_post_id\":\"0192b7e4-6c1a-7d5e-9f1a-3b2c4d5e6f70\"}",
 		},
 		{
 			name: "validation_error_400",
@@ -212,11 +548,52 @@ func Test_httpAdapter_CreatePost(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantBody:   "{\"message\":\"missing title or content\",\"cause\":\"bad request\"}",
 		},
+		{
+			name: "published_anonymous_401",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts", io.NopCloser(strings.NewReader(`{"title":"some_title","content":"some_content","status":"published"}`))),
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   "{\"message\":\"admin credentials required to publish or schedule posts\",\"cause\":\"unauthorized\"}",
+		},
+		{
+			name: "scheduled_anonymous_401",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts", io.NopCloser(strings.NewReader(`{"title":"some_title","content":"some_content","status":"scheduled","publish_at":"2030-01-01T00:00:00Z"}`))),
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   "{\"message\":\"admin credentials required to publish or schedule posts\",\"cause\":\"unauthorized\"}",
+		},
+		{
+			name: "publish_at_anonymous_401",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts", io.NopCloser(strings.NewReader(`{"title":"some_title","content":"some_content","publish_at":"2000-01-01T00:00:00Z"}`))),
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   "{\"message\":\"admin credentials required to publish or schedule posts\",\"cause\":\"unauthorized\"}",
+		},
+		{
+			name: "published_admin_201",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().CreateBlogPost(CreatePostRequest{Title: "some_title", Content: "some_content", Status: StatusPublished}).Return("42", nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    adminRequest(httptest.NewRequest(http.MethodPost, "/posts", io.NopCloser(strings.NewReader(`{"title":"some_title","content":"some_content","status":"published"}`)))),
+			wantStatus: http.StatusCreated,
+			wantBody:   "{\"blog_post_id\":\"42\"}",
+		},
 		{
 			name: "service_error_500",
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
-				service.EXPECT().CreateBlogPost("some_title", "some_content").Return(0, errors.New("internal error"))
+				service.EXPECT().CreateBlogPost(CreatePostRequest{Title: "some_title", Content: "some_content"}).Return("", errors.New("internal error"))
 				return &httpAdapter{Service: service}
 			},
 			request:    httptest.NewRequest(http.MethodPost, "/posts", io.NopCloser(strings.NewReader(`{"title":"some_title","content":"some_content"}`))),
@@ -267,12 +644,24 @@ func Test_httpAdapter_CreateComment(t *testing.T) {
 			name: "success_201",
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
-				service.EXPECT().CreateComment("1", "some comment").Return(2, nil)
+				service.EXPECT().CreateComment("1", "some comment").Return("2", nil)
 				return &httpAdapter{Service: service}
 			},
 			request:    httptest.NewRequest(http.MethodPost, "/posts/1/comments", io.NopCloser(strings.NewReader(`{"text":"some comment"}`))),
 			wantStatus: http.StatusCreated,
-			wantBody:   "{\"comment_id\":2}",
+			wantBody:   "{\"comment_id\":\"2\"}",
+			id:         "1",
+		},
+		{
+			name: "content_rejected_422",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().CreateComment("1", "some comment").Return("", &ContentRejectedError{Reasons: []string{"duplicates an existing comment"}})
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/comments", io.NopCloser(strings.NewReader(`{"text":"some comment"}`))),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "{\"message\":\"unexpected error creating comment\",\"cause\":\"content rejected: duplicates an existing comment\",\"details\":[\"duplicates an existing comment\"]}",
 			id:         "1",
 		},
 		{
@@ -290,7 +679,7 @@ func Test_httpAdapter_CreateComment(t *testing.T) {
 			name: "service_error_500",
 			setup: func() *httpAdapter {
 				service := NewMocksService(t)
-				service.EXPECT().CreateComment("1", "some comment").Return(0, errors.New("internal error"))
+				service.EXPECT().CreateComment("1", "some comment").Return("", errors.New("internal error"))
 				return &httpAdapter{Service: service}
 			},
 			request:    httptest.NewRequest(http.MethodPost, "/posts/1/comments", io.NopCloser(strings.NewReader(`{"text":"some comment"}`))),
@@ -336,3 +725,993 @@ func Test_httpAdapter_CreateComment(t *testing.T) {
 		})
 	}
 }
+
+func Test_httpAdapter_PublishPost(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_204_without_body",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().PublishBlogPost("1", (*time.Time)(nil)).Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/publish", nil),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "success_204_scheduled",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().PublishBlogPost("1", mock.MatchedBy(func(publishAt *time.Time) bool {
+					return publishAt != nil && publishAt.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+				})).Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/publish", strings.NewReader(`{"publish_at":"2030-01-01T00:00:00Z"}`)),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "invalid_transition_409",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().PublishBlogPost("1", (*time.Time)(nil)).Return(ErrInvalidStatusTransition)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/publish", nil),
+			wantStatus: http.StatusConflict,
+			wantBody:   "{\"message\":\"unexpected error publishing post with ID (1)\",\"cause\":\"invalid status transition\"}",
+		},
+		{
+			name: "json_unmarshal_error",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/publish", strings.NewReader(`{"publish_at":`)),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "{\"message\":\"unexpected error reading post publication body\",\"cause\":\"unexpected EOF\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+
+			a.PublishPost(recorder, withURLParams(tt.request, map[string]string{"id": "1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_ArchivePost(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_204",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().ArchiveBlogPost("1").Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().ArchiveBlogPost("1").Return(ErrBlogPostNotFound)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error archiving post with ID (1)\",\"cause\":\"blog post not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/posts/1/archive", nil)
+
+			a.ArchivePost(recorder, withURLParams(request, map[string]string{"id": "1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetComments(t *testing.T) {
+	thread := []Comment{
+		{ID: "5", CommentText: "root", ReplyCount: 1},
+		{ID: "6", CommentText: "reply", ParentID: "5", Depth: 1},
+	}
+
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		request    *http.Request
+		params     map[string]string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200_tree",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetComments("1", CommentsQuery{MaxDepth: DefaultCommentDepth, Limit: 10}).Return(slices.Clone(thread), nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/1/comments", nil),
+			params:     map[string]string{"id": "1"},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"comments\":[{\"id\":\"5\",\"comment_text\":\"root\",\"reply_count\":1,\"replies\":[{\"id\":\"6\",\"comment_text\":\"reply\",\"parent_id\":\"5\",\"depth\":1}]}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "success_200_flat_replies",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetComments("1", CommentsQuery{ParentID: "5", MaxDepth: 1, Limit: 5, Offset: 5}).Return(slices.Clone(thread[1:]), nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/1/comments/5/replies?format=flat&max_depth=1&page=2&limit=5", nil),
+			params:     map[string]string{"id": "1", "commentId": "5"},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"comments\":[{\"id\":\"6\",\"comment_text\":\"reply\",\"parent_id\":\"5\",\"depth\":1}],\"pagination\":{\"limit\":5,\"offset\":5,\"page\":2}}",
+		},
+		{
+			name: "invalid_max_depth_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/1/comments?max_depth=50", nil),
+			params:     map[string]string{"id": "1"},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"max_depth must be a number between 0 and 10\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "invalid_format_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/1/comments?format=xml", nil),
+			params:     map[string]string{"id": "1"},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"format must be either tree or flat\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "comment_not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetComments("1", CommentsQuery{ParentID: "9", MaxDepth: DefaultCommentDepth, Limit: 10}).Return(nil, ErrCommentNotFound)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/posts/1/comments/9/replies", nil),
+			params:     map[string]string{"id": "1", "commentId": "9"},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error getting comments of post with ID (1)\",\"cause\":\"comment not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+
+			a.GetComments(recorder, withURLParams(tt.request, tt.params))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_CreateReply(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_201",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().CreateReply("1", "5", "some reply").Return("6", nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/comments/5/replies", strings.NewReader(`{"text":"some reply"}`)),
+			wantStatus: http.StatusCreated,
+			wantBody:   "{\"comment_id\":\"6\"}",
+		},
+		{
+			name: "validation_error_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/comments/5/replies", strings.NewReader(`{"text":""}`)),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"missing comment text\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "parent_not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().CreateReply("1", "5", "some reply").Return("", ErrCommentNotFound)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/posts/1/comments/5/replies", strings.NewReader(`{"text":"some reply"}`)),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error creating reply\",\"cause\":\"comment not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+
+			a.CreateReply(recorder, withURLParams(tt.request, map[string]string{"id": "1", "commentId": "5"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetModerationQueue(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200_pending_by_default",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetCommentsByModerationStatus(ModerationPending, 10, 0).Return([]Comment{
+					{ID: "5", BlogPostID: "1", CommentText: "hello", ModerationStatus: ModerationPending},
+				}, nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/admin/comments", nil),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"comments\":[{\"id\":\"5\",\"blog_post_id\":\"1\",\"comment_text\":\"hello\",\"moderation_status\":\"pending\"}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "invalid_status_400",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetCommentsByModerationStatus(ModerationStatus("unknown"), 10, 0).Return(nil, ErrorBadRequest)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/admin/comments?status=unknown", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"unexpected error getting moderation queue\",\"cause\":\"bad request\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			a.GetModerationQueue(recorder, tt.request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_ModerateComments(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().ModerateComments([]string{"5", "6"}, ModerationApproved).Return(2, nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/admin/comments/moderation", strings.NewReader(`{"comment_ids":["5","6"],"status":"approved"}`)),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"updated\":2}",
+		},
+		{
+			name: "service_error_400",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().ModerateComments([]string(nil), ModerationApproved).Return(0, ErrorBadRequest)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/admin/comments/moderation", strings.NewReader(`{"status":"approved"}`)),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"unexpected error moderating comments\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "json_unmarshal_error",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/admin/comments/moderation", strings.NewReader(`{"status":`)),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "{\"message\":\"unexpected error reading comment moderation body\",\"cause\":\"unexpected EOF\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			a.ModerateComments(recorder, tt.request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetTags(ReadOptions{}).Return([]Tag{{Slug: "go", PostCount: 2}}, nil)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"tags\":[{\"slug\":\"go\",\"post_count\":2}]}",
+		},
+		{
+			name: "service_error_500",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetTags(ReadOptions{}).Return(nil, errors.New("internal error"))
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "{\"message\":\"unexpected error getting tags\",\"cause\":\"internal error\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			a.GetTags(recorder, httptest.NewRequest(http.MethodGet, "/tags", nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_UpdatePost(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_204",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().UpdateBlogPost("1", UpdatePostRequest{Title: "new title", Content: "new content"}).Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPut, "/posts/1", strings.NewReader(`{"title":"new title","content":"new content"}`)),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "validation_error_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			request:    httptest.NewRequest(http.MethodPut, "/posts/1", strings.NewReader(`{"title":"new title"}`)),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"missing title or content\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().UpdateBlogPost("1", UpdatePostRequest{Title: "new title", Content: "new content"}).Return(ErrBlogPostNotFound)
+				return &httpAdapter{Service: service}
+			},
+			request:    httptest.NewRequest(http.MethodPut, "/posts/1", strings.NewReader(`{"title":"new title","content":"new content"}`)),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error updating post with ID (1)\",\"cause\":\"blog post not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+
+			a.UpdatePost(recorder, withURLParams(tt.request, map[string]string{"id": "1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetRevision(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		rev        string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetRevision("1", 2).Return(&Revision{
+					Number:        2,
+					Title:         "T",
+					Content:       "C",
+					ContentFormat: ContentFormatText,
+					CreatedAt:     createdAt,
+				}, nil)
+				return &httpAdapter{Service: service}
+			},
+			rev:        "2",
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"revision\":2,\"title\":\"T\",\"content\":\"C\",\"content_format\":\"text\",\"created_at\":\"2025-01-02T03:04:05Z\"}",
+		},
+		{
+			name: "invalid_revision_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			rev:        "0",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"invalid revision number\",\"cause\":\"bad request: invalid revision number (0)\"}",
+		},
+		{
+			name: "not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetRevision("1", 3).Return(nil, ErrRevisionNotFound)
+				return &httpAdapter{Service: service}
+			},
+			rev:        "3",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error getting revision 3 of post with ID (1)\",\"cause\":\"revision not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/posts/1/revisions/"+tt.rev, nil)
+
+			a.GetRevision(recorder, withURLParams(request, map[string]string{"id": "1", "rev": tt.rev}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_DiffRevisions(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().DiffRevisions("1", 1, 2).Return("--- revision 1\n+++ revision 2\n", nil)
+				return &httpAdapter{Service: service}
+			},
+			query:      "from=1&to=2",
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"from\":1,\"to\":2,\"diff\":\"--- revision 1\\n+++ revision 2\\n\"}",
+		},
+		{
+			name: "missing_to_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			query:      "from=1",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"to must be a revision number\",\"cause\":\"bad request: invalid revision number ()\"}",
+		},
+		{
+			name: "not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().DiffRevisions("1", 1, 9).Return("", ErrRevisionNotFound)
+				return &httpAdapter{Service: service}
+			},
+			query:      "from=1&to=9",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error diffing revisions of post with ID (1)\",\"cause\":\"revision not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/posts/1/revisions/diff?"+tt.query, nil)
+
+			a.DiffRevisions(recorder, withURLParams(request, map[string]string{"id": "1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_RestoreRevision(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		wantStatus int
+	}{
+		{
+			name: "success_204",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().RestoreRevision("1", 2).Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "content_rejected_422",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().RestoreRevision("1", 2).Return(&ContentRejectedError{Reasons: []string{"contains blocked word \"casino\""}})
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/posts/1/revisions/2/restore", nil)
+
+			a.RestoreRevision(recorder, withURLParams(request, map[string]string{"id": "1", "rev": "2"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_DeletePost(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_204",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().DeleteBlogPost("1").Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().DeleteBlogPost("1").Return(ErrBlogPostNotFound)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error deleting post with ID (1)\",\"cause\":\"blog post not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodDelete, "/posts/1", nil)
+
+			a.DeletePost(recorder, withURLParams(request, map[string]string{"id": "1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_RestoreComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_204",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().RestoreComment("5").Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "parent_deleted_409",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().RestoreComment("5").Return(ErrParentCommentDeleted)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   "{\"message\":\"unexpected error restoring comment with ID (5)\",\"cause\":\"parent comment is in trash\"}",
+		},
+		{
+			name: "not_in_trash_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().RestoreComment("5").Return(ErrCommentNotFound)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error restoring comment with ID (5)\",\"cause\":\"comment not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/admin/trash/comments/5/restore", nil)
+
+			a.RestoreComment(recorder, withURLParams(request, map[string]string{"commentId": "5"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_PurgeTrash(t *testing.T) {
+	service := NewMocksService(t)
+	service.EXPECT().PurgeTrash(mock.AnythingOfType("time.Time")).Return(int64(3), nil)
+	a := &httpAdapter{Service: service}
+
+	recorder := httptest.NewRecorder()
+	a.PurgeTrash(recorder, httptest.NewRequest(http.MethodDelete, "/admin/trash", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if body := recorder.Body.String(); body != "{\"purged\":3}" {
+		t.Errorf("got body %q, want %q", body, "{\"purged\":3}")
+	}
+}
+
+func Test_httpAdapter_ExportContent(t *testing.T) {
+	service := NewMocksService(t)
+	service.EXPECT().ExportContent(mock.Anything).RunAndReturn(func(fn func(ExportRecord) error) error {
+		if err := fn(ExportRecord{Type: RecordPost, Post: &BlogPost{ID: "1", Title: "T", Status: StatusPublished}}); err != nil {
+			return err
+		}
+		return fn(ExportRecord{Type: RecordComment, Comment: &Comment{ID: "5", BlogPostID: "1", CommentText: "hi"}})
+	})
+	a := &httpAdapter{Service: service}
+
+	recorder := httptest.NewRecorder()
+	a.ExportContent(recorder, httptest.NewRequest(http.MethodGet, "/admin/export", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/x-ndjson" {
+		t.Errorf("got content type %q, want %q", contentType, "application/x-ndjson")
+	}
+	wantBody := "{\"type\":\"post\",\"post\":{\"id\":\"1\",\"slug\":\"\",\"title\":\"T\",\"content\":\"\",\"content_format\":\"\",\"content_html\":\"\",\"excerpt\":\"\",\"comment_count\":0,\"status\":\"published\"}}\n" +
+		"{\"type\":\"comment\",\"comment\":{\"id\":\"5\",\"blog_post_id\":\"1\",\"comment_text\":\"hi\"}}\n"
+	if body := recorder.Body.String(); body != wantBody {
+		t.Errorf("got body %q, want %q", body, wantBody)
+	}
+}
+
+func Test_httpAdapter_ImportContent(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200_dry_run",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().ImportContent(mock.Anything, true).Return(&ImportResult{
+					DryRun: true,
+					Posts:  1,
+					Errors: []ImportError{{Line: 2, Message: "bad request: missing comment text"}},
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/admin/import?dry_run=true", strings.NewReader("{}")),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"dry_run\":true,\"posts\":1,\"comments\":0,\"errors\":[{\"line\":2,\"message\":\"bad request: missing comment text\"}]}",
+		},
+		{
+			name: "invalid_dry_run_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/admin/import?dry_run=maybe", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"dry_run must be either true or false\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "service_error_500",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().ImportContent(mock.Anything, false).Return(nil, errors.New("internal error"))
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/admin/import", nil),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "{\"message\":\"unexpected error importing content\",\"cause\":\"internal error\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			a.ImportContent(recorder, tt.request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if body := recorder.Body.String(); body != tt.wantBody {
+				t.Errorf("got body %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_CreatePosts(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_201",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().CreateBlogPosts([]CreatePostRequest{{Title: "A", Content: "a"}}, true).Return([]BatchResult{{ID: "1"}}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			body:       `{"posts":[{"title":"A","content":"a"}]}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   "{\"results\":[{\"index\":0,\"status\":201,\"blog_post_id\":\"1\"}]}",
+		},
+		{
+			name: "partial_207_best_effort",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().CreateBlogPosts([]CreatePostRequest{{Title: "A", Content: "a"}, {Title: "B"}}, false).Return([]BatchResult{
+					{ID: "1"},
+					{Err: fmt.Errorf("%w: missing title or content", ErrorBadRequest)},
+				}, nil)
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			body:       `{"mode":"best_effort","posts":[{"title":"A","content":"a"},{"title":"B"}]}`,
+			wantStatus: http.StatusMultiStatus,
+			wantBody:   "{\"results\":[{\"index\":0,\"status\":201,\"blog_post_id\":\"1\"},{\"index\":1,\"status\":400,\"error\":\"bad request: missing title or content\"}]}",
+		},
+		{
+			name: "aborted_422_atomic",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+
+				service.EXPECT().CreateBlogPosts([]CreatePostRequest{{Title: "A", Content: "a"}, {Title: "B", Content: "b"}}, true).
+					Return(nil, &BatchItemError{Index: 1, Err: &ContentRejectedError{Reasons: []string{"blocked word"}}})
+
+				return &httpAdapter{
+					Service: service,
+				}
+			},
+			body:       `{"mode":"atomic","posts":[{"title":"A","content":"a"},{"title":"B","content":"b"}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "{\"message\":\"post 1 failed, no post was created\",\"cause\":\"post 1: content rejected: blocked word\",\"details\":[\"blocked word\"],\"index\":1}",
+		},
+		{
+			name: "missing_posts_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			body:       `{"posts":[]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"missing posts\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "too_many_posts_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			body:       `{"posts":[` + strings.Repeat(`{"title":"A","content":"a"},`, MaxBatchPosts) + `{"title":"A","content":"a"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"at most 100 posts can be created at once\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "invalid_mode_400",
+			setup: func() *httpAdapter {
+				return &httpAdapter{
+					Service: NewMocksService(t),
+				}
+			},
+			body:       `{"mode":"some","posts":[{"title":"A","content":"a"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"mode must be either atomic or best_effort\",\"cause\":\"bad request\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			a.CreatePosts(recorder, httptest.NewRequest(http.MethodPost, "/posts:batch", strings.NewReader(tt.body)))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if body := recorder.Body.String(); body != tt.wantBody {
+				t.Errorf("got body %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package posts
 
 import (
+	"io"
+	"iter"
 	"net/http"
+	"time"
 
 	"github.com/MatiasKopp/prosig-code-challenge/httputil"
 )
@@ -14,38 +17,276 @@ type HTTPAdapter interface {
 	GetPost(http.ResponseWriter, *http.Request)
 	// CreatePost Creates new post.
 	CreatePost(http.ResponseWriter, *http.Request)
+	// CreatePosts Creates many posts at once.
+	CreatePosts(http.ResponseWriter, *http.Request)
+	// UpdatePost Updates title and content of specific post.
+	UpdatePost(http.ResponseWriter, *http.Request)
+	// GetRevisions Returns content revisions of specific post.
+	GetRevisions(http.ResponseWriter, *http.Request)
+	// GetRevision Returns single content revision of specific post.
+	GetRevision(http.ResponseWriter, *http.Request)
+	// DiffRevisions Returns a unified diff between two content revisions of specific post.
+	DiffRevisions(http.ResponseWriter, *http.Request)
+	// RestoreRevision Restores specific post from one of its revisions.
+	RestoreRevision(http.ResponseWriter, *http.Request)
+	// GetTags Returns all tags in use with their post counts.
+	GetTags(http.ResponseWriter, *http.Request)
 	// CreateComment Creates new comment for specific post.
 	CreateComment(http.ResponseWriter, *http.Request)
+	// GetComments Returns the comment thread of specific post, or the replies of one of its comments.
+	GetComments(http.ResponseWriter, *http.Request)
+	// CreateReply Creates new reply to specific comment.
+	CreateReply(http.ResponseWriter, *http.Request)
+	// GetModerationQueue Returns comments with specific moderation status, pending by default.
+	GetModerationQueue(http.ResponseWriter, *http.Request)
+	// ModerateComments Changes moderation status of many comments at once.
+	ModerateComments(http.ResponseWriter, *http.Request)
+	// PublishPost Publishes or schedules specific post.
+	PublishPost(http.ResponseWriter, *http.Request)
+	// ArchivePost Archives specific post.
+	ArchivePost(http.ResponseWriter, *http.Request)
+	// DeletePost Moves specific post to trash.
+	DeletePost(http.ResponseWriter, *http.Request)
+	// DeleteComment Moves specific comment and its replies to trash.
+	DeleteComment(http.ResponseWriter, *http.Request)
+	// GetDeletedPosts Returns posts in trash.
+	GetDeletedPosts(http.ResponseWriter, *http.Request)
+	// GetDeletedComments Returns comments in trash.
+	GetDeletedComments(http.ResponseWriter, *http.Request)
+	// RestorePost Takes specific post out of trash.
+	RestorePost(http.ResponseWriter, *http.Request)
+	// RestoreComment Takes specific comment out of trash.
+	RestoreComment(http.ResponseWriter, *http.Request)
+	// PurgeTrash Permanently deletes posts and comments in trash for longer than the retention window.
+	PurgeTrash(http.ResponseWriter, *http.Request)
+	// ExportContent Streams all posts and comments as NDJSON.
+	ExportContent(http.ResponseWriter, *http.Request)
+	// ImportContent Imports posts and comments from NDJSON.
+	ImportContent(http.ResponseWriter, *http.Request)
 }
 
 // Service Posts services interface.
 type Service interface {
 	// GetAllBlogPosts Returns all existing blog posts paginated.
-	GetAllBlogPosts(page, limit int) ([]BlogPost, error)
-	// GetBlogPost Returns single blog post with provided ID.
-	GetBlogPost(id string) (*BlogPost, error)
+	GetAllBlogPosts(page, limit int, opts ReadOptions) ([]BlogPost, error)
+	// GetBlogPost Returns single blog post with provided ID or slug, old slugs included.
+	GetBlogPost(id string, opts ReadOptions) (*BlogPost, error)
 	// CreateBlogPost Creates a new blog post and returns its generated ID.
-	CreateBlogPost(title, content string) (int64, error)
+	CreateBlogPost(request CreatePostRequest) (string, error)
+	// CreateBlogPosts Creates many blog posts at once and returns the outcome of every one of them, in request order.
+	// Atomic batches create either every post or none of them, returning a BatchItemError when a post fails.
+	CreateBlogPosts(requests []CreatePostRequest, atomic bool) ([]BatchResult, error)
+	// UpdateBlogPost Updates title and content of a blog post, renaming its slug when the title changes.
+	UpdateBlogPost(id string, request UpdatePostRequest) error
+	// GetRevisions Returns content revisions of a blog post paginated, newest first.
+	GetRevisions(blogPostID string, limit, offset int) ([]Revision, error)
+	// GetRevision Returns single content revision of a blog post.
+	GetRevision(blogPostID string, number int) (*Revision, error)
+	// DiffRevisions Returns a unified diff between two content revisions of a blog post.
+	DiffRevisions(blogPostID string, from, to int) (string, error)
+	// RestoreRevision Restores title and content of a blog post from one of its revisions, saved as a new revision.
+	RestoreRevision(blogPostID string, number int) error
+	// GetTags Returns all tags in use with their post counts.
+	GetTags(opts ReadOptions) ([]Tag, error)
 	// CreateComment Creates a new comment and associates it with a blog post.
-	CreateComment(blogPostID, text string) (int64, error)
+	CreateComment(blogPostID, text string) (string, error)
+	// CreateReply Creates a new comment replying to another comment of the same blog post.
+	CreateReply(blogPostID, parentCommentID, text string) (string, error)
+	// GetComments Returns a blog post comment thread as a flat list ordered depth first.
+	GetComments(blogPostID string, query CommentsQuery) ([]Comment, error)
+	// GetCommentsAfter Returns up to limit approved comments of a published blog post created after one of its comments,
+	// oldest first.
+	GetCommentsAfter(blogPostID, commentID string, limit int) ([]Comment, error)
+	// GetCommentsByBlogPosts Returns a page of top level comments for each of many blog posts at once, by blog post ID.
+	GetCommentsByBlogPosts(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error)
+	// GetRepliesByComments Returns a page of direct replies for each of many comments at once, by comment ID.
+	GetRepliesByComments(commentIDs []string, query CommentsQuery) (map[string][]Comment, error)
+	// GetCommentsByModerationStatus Returns comments with provided moderation status paginated.
+	GetCommentsByModerationStatus(status ModerationStatus, limit, offset int) ([]Comment, error)
+	// ModerateComments Changes moderation status of up to MaxModeratedComments comments and returns how many were updated.
+	ModerateComments(ids []string, status ModerationStatus) (int64, error)
+	// PublishBlogPost Publishes a blog post, or schedules it when publishAt is in the future.
+	PublishBlogPost(id string, publishAt *time.Time) error
+	// ArchiveBlogPost Archives a blog post.
+	ArchiveBlogPost(id string) error
+	// PublishDueBlogPosts Publishes scheduled blog posts due at provided time and returns how many were published.
+	PublishDueBlogPosts(now time.Time) (int64, error)
+	// DeleteBlogPost Moves a blog post to trash.
+	DeleteBlogPost(id string) error
+	// DeleteComment Moves a comment of a blog post to trash along with all its replies.
+	DeleteComment(blogPostID, commentID string) error
+	// GetDeletedBlogPosts Returns blog posts in trash paginated, most recently deleted first.
+	GetDeletedBlogPosts(limit, offset int) ([]BlogPost, error)
+	// GetDeletedComments Returns comments in trash paginated, most recently deleted first.
+	GetDeletedComments(limit, offset int) ([]Comment, error)
+	// RestoreBlogPost Takes a blog post out of trash.
+	RestoreBlogPost(id string) error
+	// RestoreComment Takes a comment out of trash along with the replies deleted with it.
+	RestoreComment(commentID string) error
+	// PurgeTrash Permanently deletes blog posts and comments in trash for longer than the retention window
+	// at provided time, and returns how many were deleted.
+	PurgeTrash(now time.Time) (int64, error)
+	// ReindexBlogPosts Renders again HTML and excerpt of every blog post from its content, trash included, makes
+	// again the slugs backfilled by migrations, and returns how many blog posts changed.
+	ReindexBlogPosts() (int64, error)
+	// ExportContent Calls fn with every blog post followed by its comments, as they are read. Trash is not exported.
+	ExportContent(fn func(ExportRecord) error) error
+	// ImportContent Imports NDJSON records read from r in batches, reporting lines that could not be imported.
+	// Nothing is stored on dry runs.
+	ImportContent(r io.Reader, dryRun bool) (*ImportResult, error)
 }
 
 // Repository Posts repository interface.
 type Repository interface {
 	// GetAllBlogPosts Returns all existing blog posts paginated.
-	GetAllBlogPosts(page, limit int) ([]BlogPost, error)
-	// GetBlogPost Returns single blog post with provided ID.
-	GetBlogPost(id string) (*BlogPost, error)
-	// CreateBlogPost Creates a new blog post and returns its generated ID.
-	CreateBlogPost(title, content string) (int64, error)
+	GetAllBlogPosts(page, limit int, opts ReadOptions) ([]BlogPost, error)
+	// GetBlogPost Returns single blog post with provided ID or slug, old slugs included.
+	GetBlogPost(id string, opts ReadOptions) (*BlogPost, error)
+	// CreateBlogPost Creates a new blog post along with its first revision and returns its generated ID.
+	// The post slug is made unique by appending a numeric suffix when needed.
+	CreateBlogPost(post BlogPost) (string, error)
+	// CreateBlogPosts Creates many blog posts in a single transaction and returns the outcome of every one of them.
+	// Atomic batches are rolled back as soon as a post fails, returning a BatchItemError, otherwise failed posts are
+	// skipped.
+	CreateBlogPosts(posts []BlogPost, atomic bool) ([]BatchResult, error)
+	// UpdateBlogPost Updates title, content, rendered content and slug of a blog post, keeping its previous slug in history.
+	// Every update is saved as a new revision.
+	UpdateBlogPost(post BlogPost) error
+	// GetRevisions Returns content revisions of a blog post paginated, newest first.
+	GetRevisions(blogPostID string, limit, offset int) ([]Revision, error)
+	// GetRevision Returns single content revision of a blog post.
+	GetRevision(blogPostID string, number int) (*Revision, error)
+	// GetTags Returns all tags in use with their post counts.
+	GetTags(opts ReadOptions) ([]Tag, error)
 	// CreateComment Creates a new comment and associates it with a blog post.
-	CreateComment(blogPostID, text string) (int64, error)
+	CreateComment(blogPostID string, comment Comment) (string, error)
+	// HasComment Reports whether a blog post already has a comment with provided text.
+	HasComment(blogPostID, text string) (bool, error)
+	// GetComment Returns single comment of a blog post.
+	GetComment(blogPostID, commentID string) (*Comment, error)
+	// GetComments Returns a blog post comment thread as a flat list ordered depth first.
+	GetComments(blogPostID string, query CommentsQuery) ([]Comment, error)
+	// GetCommentsAfter Returns up to limit approved comments of a blog post created after one of its comments,
+	// oldest first. Comments in trash are skipped, but can still be the one others are read after.
+	GetCommentsAfter(blogPostID, commentID string, limit int) ([]Comment, error)
+	// GetCommentsByBlogPosts Returns a page of top level comments for each of many blog posts with a single query,
+	// by blog post public ID, oldest first.
+	GetCommentsByBlogPosts(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error)
+	// GetRepliesByComments Returns a page of direct replies for each of many comments with a single query,
+	// by comment public ID, oldest first.
+	GetRepliesByComments(commentIDs []string, query CommentsQuery) (map[string][]Comment, error)
+	// GetCommentsByModerationStatus Returns comments with provided moderation status paginated.
+	GetCommentsByModerationStatus(status ModerationStatus, limit, offset int) ([]Comment, error)
+	// UpdateCommentsModerationStatus Changes moderation status of provided comments and returns how many were updated.
+	UpdateCommentsModerationStatus(ids []string, status ModerationStatus) (int64, error)
+	// UpdateBlogPostStatus Updates publication status and date of a blog post.
+	UpdateBlogPostStatus(id string, status Status, publishAt *time.Time) error
+	// PublishDueBlogPosts Publishes scheduled blog posts due at provided time and returns how many were published.
+	PublishDueBlogPosts(now time.Time) (int64, error)
+	// DeleteBlogPost Moves a blog post to trash.
+	DeleteBlogPost(id string, deletedAt time.Time) error
+	// DeleteComment Moves a comment of a blog post to trash along with all its replies.
+	DeleteComment(blogPostID, commentID string, deletedAt time.Time) error
+	// GetDeletedBlogPosts Returns blog posts in trash paginated, most recently deleted first.
+	GetDeletedBlogPosts(limit, offset int) ([]BlogPost, error)
+	// GetDeletedComments Returns comments in trash paginated, most recently deleted first.
+	GetDeletedComments(limit, offset int) ([]Comment, error)
+	// RestoreBlogPost Takes a blog post, found by public ID or slug, out of trash.
+	RestoreBlogPost(id string) error
+	// RestoreComment Takes a comment out of trash along with the replies deleted with it.
+	RestoreComment(commentID string) error
+	// PurgeDeleted Permanently deletes blog posts and comments moved to trash before provided date,
+	// and returns how many were deleted.
+	PurgeDeleted(before time.Time) (int64, error)
+	// ReindexBlogPosts Renders again HTML and excerpt of every blog post, trash included, with render, makes again
+	// the slugs backfilled by migrations, and stores the ones that changed in a single transaction. Returns how many
+	// blog posts were updated.
+	ReindexBlogPosts(render func(post *BlogPost) error) (int64, error)
+	// ExportContent Calls fn with every blog post followed by its comments, as they are read. Trash is not exported.
+	ExportContent(fn func(ExportRecord) error) error
+	// ImportRecords Stores every batch of records in its own transaction, remapping IDs, and calls fn with the
+	// error of every record of a batch, nil for the stored ones, once the batch is stored. Dry runs store every
+	// batch in a single transaction rolled back at the end.
+	ImportRecords(batches iter.Seq[[]ExportRecord], dryRun bool, fn func(records []ExportRecord, errs []error)) error
+
+	Outbox
+}
+
+// Outbox Events recorded along with the writes they are about, waiting to be dispatched.
+type Outbox interface {
+	// GetPendingEvents Returns up to limit outbox events due for publication at provided time, oldest first.
+	// Events waiting for a retry hold back the later events of their aggregate.
+	GetPendingEvents(now time.Time, limit int) ([]Event, error)
+	// MarkEventDispatched Marks an outbox event as published.
+	MarkEventDispatched(id string, at time.Time) error
+	// MarkEventFailed Records why an outbox event could not be published and when to try again.
+	MarkEventFailed(id, cause string, retryAt time.Time) error
+	// PruneDispatchedEvents Deletes outbox events dispatched before provided date, and returns how many were deleted.
+	PruneDispatchedEvents(before time.Time) (int64, error)
+}
+
+// ContentFilter Inspects user content before it is stored.
+type ContentFilter interface {
+	// Check Inspects content and returns the filter verdict.
+	Check(content Content) (FilterResult, error)
+}
+
+// EventPublisher Receives events of successful writes, such as created posts and comments, at least once.
+type EventPublisher interface {
+	// Publish Publishes an event, failing when it must be published again later.
+	Publish(event Event) error
 }
 
 // CreatePostRequest Structure used in new post request.
 type CreatePostRequest struct {
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	Author    string     `json:"author"`
+	Status    Status     `json:"status"`
+	PublishAt *time.Time `json:"publish_at"`
+	Tags      []string   `json:"tags"`
+
+	// ContentFormat Format content is written in, plain text by default.
+	ContentFormat ContentFormat `json:"content_format"`
+	// CommentModeration Moderation status of new comments, empty to use the service default.
+	CommentModeration ModerationStatus `json:"comment_moderation"`
+}
+
+// RequiresAdmin Reports whether creating the post takes admin credentials. Anyone can create drafts, while
+// publishing and scheduling, which publishes once due, are left to admins.
+func (r CreatePostRequest) RequiresAdmin() bool {
+	return r.Status != "" && r.Status != StatusDraft || r.PublishAt != nil
+}
+
+// BatchMode How a batch of posts is created.
+type BatchMode string
+
+const (
+	// BatchAtomic Creates either every post or none of them.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort Creates every valid post, skipping the failed ones.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// CreatePostsRequest Structure used in batch post creation request.
+type CreatePostsRequest struct {
+	Posts []CreatePostRequest `json:"posts"`
+	// Mode How posts are created, atomic by default.
+	Mode BatchMode `json:"mode"`
+}
+
+// UpdatePostRequest Structure used in post update request.
+type UpdatePostRequest struct {
 	Title   string `json:"title"`
 	Content string `json:"content"`
+
+	// ContentFormat Format content is written in, empty to keep the current one.
+	ContentFormat ContentFormat `json:"content_format"`
+}
+
+// PublishPostRequest Structure used in publish post request.
+type PublishPostRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
 }
 
 // CreateCommentRequest Structure used in new comment request.
@@ -53,6 +294,55 @@ type CreateCommentRequest struct {
 	Text string `json:"text"`
 }
 
+// ModerateCommentsRequest Structure used in bulk comment moderation request.
+type ModerateCommentsRequest struct {
+	CommentIDs []string         `json:"comment_ids"`
+	Status     ModerationStatus `json:"status"`
+}
+
+// GetCommentsResponse Get comment thread response
+type GetCommentsResponse struct {
+	Comments   []Comment           `json:"comments"`
+	Pagination httputil.Pagination `json:"pagination"`
+}
+
+// GetRevisionsResponse Get blog post revisions response
+type GetRevisionsResponse struct {
+	Revisions  []Revision          `json:"revisions"`
+	Pagination httputil.Pagination `json:"pagination"`
+}
+
+// DiffRevisionsResponse Blog post revisions diff response
+type DiffRevisionsResponse struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Diff string `json:"diff"`
+}
+
+// GetDeletedPostsResponse Get blog posts in trash response
+type GetDeletedPostsResponse struct {
+	BlogPosts  []BlogPost          `json:"blog_posts"`
+	Pagination httputil.Pagination `json:"pagination"`
+}
+
+// BatchItemResponse Outcome of a single item of a batch request
+type BatchItemResponse struct {
+	Index      int    `json:"index"`
+	Status     int    `json:"status"`
+	BlogPostID string `json:"blog_post_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// CreatePostsResponse Batch post creation response
+type CreatePostsResponse struct {
+	Results []BatchItemResponse `json:"results"`
+}
+
+// GetTagsResponse Get all tags response
+type GetTagsResponse struct {
+	Tags []Tag `json:"tags"`
+}
+
 // GetAllResponse Get all blog posts response
 type GetAllResponse struct {
 	BlogPosts  []BlogPost          `json:"blog_posts"`
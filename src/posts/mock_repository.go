@@ -6,6 +6,8 @@ package posts
 
 import (
 	mock "github.com/stretchr/testify/mock"
+	"iter"
+	"time"
 )
 
 // NewMocksRepository creates a new instance of MocksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -36,25 +38,25 @@ func (_m *MocksRepository) EXPECT() *MocksRepository_Expecter {
 }
 
 // CreateBlogPost provides a mock function for the type MocksRepository
-func (_mock *MocksRepository) CreateBlogPost(title string, content string) (int64, error) {
-	ret := _mock.Called(title, content)
+func (_mock *MocksRepository) CreateBlogPost(post BlogPost) (string, error) {
+	ret := _mock.Called(post)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateBlogPost")
 	}
 
-	var r0 int64
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
-		return returnFunc(title, content)
+	if returnFunc, ok := ret.Get(0).(func(BlogPost) (string, error)); ok {
+		return returnFunc(post)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = returnFunc(title, content)
+	if returnFunc, ok := ret.Get(0).(func(BlogPost) string); ok {
+		r0 = returnFunc(post)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = returnFunc(title, content)
+	if returnFunc, ok := ret.Get(1).(func(BlogPost) error); ok {
+		r1 = returnFunc(post)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -67,21 +69,83 @@ type MocksRepository_CreateBlogPost_Call struct {
 }
 
 // CreateBlogPost is a helper method to define mock.On call
-//   - title string
-//   - content string
-func (_e *MocksRepository_Expecter) CreateBlogPost(title interface{}, content interface{}) *MocksRepository_CreateBlogPost_Call {
-	return &MocksRepository_CreateBlogPost_Call{Call: _e.mock.On("CreateBlogPost", title, content)}
+//   - post BlogPost
+func (_e *MocksRepository_Expecter) CreateBlogPost(post interface{}) *MocksRepository_CreateBlogPost_Call {
+	return &MocksRepository_CreateBlogPost_Call{Call: _e.mock.On("CreateBlogPost", post)}
 }
 
-func (_c *MocksRepository_CreateBlogPost_Call) Run(run func(title string, content string)) *MocksRepository_CreateBlogPost_Call {
+func (_c *MocksRepository_CreateBlogPost_Call) Run(run func(post BlogPost)) *MocksRepository_CreateBlogPost_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 BlogPost
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(BlogPost)
 		}
-		var arg1 string
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_CreateBlogPost_Call) Return(s string, err error) *MocksRepository_CreateBlogPost_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MocksRepository_CreateBlogPost_Call) RunAndReturn(run func(post BlogPost) (string, error)) *MocksRepository_CreateBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBlogPosts provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) CreateBlogPosts(posts []BlogPost, atomic bool) ([]BatchResult, error) {
+	ret := _mock.Called(posts, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBlogPosts")
+	}
+
+	var r0 []BatchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]BlogPost, bool) ([]BatchResult, error)); ok {
+		return returnFunc(posts, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]BlogPost, bool) []BatchResult); ok {
+		r0 = returnFunc(posts, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BatchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]BlogPost, bool) error); ok {
+		r1 = returnFunc(posts, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_CreateBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBlogPosts'
+type MocksRepository_CreateBlogPosts_Call struct {
+	*mock.Call
+}
+
+// CreateBlogPosts is a helper method to define mock.On call
+//   - posts []BlogPost
+//   - atomic bool
+func (_e *MocksRepository_Expecter) CreateBlogPosts(posts interface{}, atomic interface{}) *MocksRepository_CreateBlogPosts_Call {
+	return &MocksRepository_CreateBlogPosts_Call{Call: _e.mock.On("CreateBlogPosts", posts, atomic)}
+}
+
+func (_c *MocksRepository_CreateBlogPosts_Call) Run(run func(posts []BlogPost, atomic bool)) *MocksRepository_CreateBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []BlogPost
+		if args[0] != nil {
+			arg0 = args[0].([]BlogPost)
+		}
+		var arg1 bool
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(bool)
 		}
 		run(
 			arg0,
@@ -91,36 +155,36 @@ func (_c *MocksRepository_CreateBlogPost_Call) Run(run func(title string, conten
 	return _c
 }
 
-func (_c *MocksRepository_CreateBlogPost_Call) Return(n int64, err error) *MocksRepository_CreateBlogPost_Call {
-	_c.Call.Return(n, err)
+func (_c *MocksRepository_CreateBlogPosts_Call) Return(batchResults []BatchResult, err error) *MocksRepository_CreateBlogPosts_Call {
+	_c.Call.Return(batchResults, err)
 	return _c
 }
 
-func (_c *MocksRepository_CreateBlogPost_Call) RunAndReturn(run func(title string, content string) (int64, error)) *MocksRepository_CreateBlogPost_Call {
+func (_c *MocksRepository_CreateBlogPosts_Call) RunAndReturn(run func(posts []BlogPost, atomic bool) ([]BatchResult, error)) *MocksRepository_CreateBlogPosts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // CreateComment provides a mock function for the type MocksRepository
-func (_mock *MocksRepository) CreateComment(blogPostID string, text string) (int64, error) {
-	ret := _mock.Called(blogPostID, text)
+func (_mock *MocksRepository) CreateComment(blogPostID string, comment Comment) (string, error) {
+	ret := _mock.Called(blogPostID, comment)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateComment")
 	}
 
-	var r0 int64
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
-		return returnFunc(blogPostID, text)
+	if returnFunc, ok := ret.Get(0).(func(string, Comment) (string, error)); ok {
+		return returnFunc(blogPostID, comment)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = returnFunc(blogPostID, text)
+	if returnFunc, ok := ret.Get(0).(func(string, Comment) string); ok {
+		r0 = returnFunc(blogPostID, comment)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = returnFunc(blogPostID, text)
+	if returnFunc, ok := ret.Get(1).(func(string, Comment) error); ok {
+		r1 = returnFunc(blogPostID, comment)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,12 +198,127 @@ type MocksRepository_CreateComment_Call struct {
 
 // CreateComment is a helper method to define mock.On call
 //   - blogPostID string
-//   - text string
-func (_e *MocksRepository_Expecter) CreateComment(blogPostID interface{}, text interface{}) *MocksRepository_CreateComment_Call {
-	return &MocksRepository_CreateComment_Call{Call: _e.mock.On("CreateComment", blogPostID, text)}
+//   - comment Comment
+func (_e *MocksRepository_Expecter) CreateComment(blogPostID interface{}, comment interface{}) *MocksRepository_CreateComment_Call {
+	return &MocksRepository_CreateComment_Call{Call: _e.mock.On("CreateComment", blogPostID, comment)}
+}
+
+func (_c *MocksRepository_CreateComment_Call) Run(run func(blogPostID string, comment Comment)) *MocksRepository_CreateComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 Comment
+		if args[1] != nil {
+			arg1 = args[1].(Comment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_CreateComment_Call) Return(s string, err error) *MocksRepository_CreateComment_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MocksRepository_CreateComment_Call) RunAndReturn(run func(blogPostID string, comment Comment) (string, error)) *MocksRepository_CreateComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBlogPost provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) DeleteBlogPost(id string, deletedAt time.Time) error {
+	ret := _mock.Called(id, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBlogPost")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = returnFunc(id, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_DeleteBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBlogPost'
+type MocksRepository_DeleteBlogPost_Call struct {
+	*mock.Call
+}
+
+// DeleteBlogPost is a helper method to define mock.On call
+//   - id string
+//   - deletedAt time.Time
+func (_e *MocksRepository_Expecter) DeleteBlogPost(id interface{}, deletedAt interface{}) *MocksRepository_DeleteBlogPost_Call {
+	return &MocksRepository_DeleteBlogPost_Call{Call: _e.mock.On("DeleteBlogPost", id, deletedAt)}
+}
+
+func (_c *MocksRepository_DeleteBlogPost_Call) Run(run func(id string, deletedAt time.Time)) *MocksRepository_DeleteBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_DeleteBlogPost_Call) Return(err error) *MocksRepository_DeleteBlogPost_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_DeleteBlogPost_Call) RunAndReturn(run func(id string, deletedAt time.Time) error) *MocksRepository_DeleteBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-func (_c *MocksRepository_CreateComment_Call) Run(run func(blogPostID string, text string)) *MocksRepository_CreateComment_Call {
+// DeleteComment provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) DeleteComment(blogPostID string, commentID string, deletedAt time.Time) error {
+	ret := _mock.Called(blogPostID, commentID, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Time) error); ok {
+		r0 = returnFunc(blogPostID, commentID, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type MocksRepository_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - blogPostID string
+//   - commentID string
+//   - deletedAt time.Time
+func (_e *MocksRepository_Expecter) DeleteComment(blogPostID interface{}, commentID interface{}, deletedAt interface{}) *MocksRepository_DeleteComment_Call {
+	return &MocksRepository_DeleteComment_Call{Call: _e.mock.On("DeleteComment", blogPostID, commentID, deletedAt)}
+}
+
+func (_c *MocksRepository_DeleteComment_Call) Run(run func(blogPostID string, commentID string, deletedAt time.Time)) *MocksRepository_DeleteComment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -149,27 +328,83 @@ func (_c *MocksRepository_CreateComment_Call) Run(run func(blogPostID string, te
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MocksRepository_CreateComment_Call) Return(n int64, err error) *MocksRepository_CreateComment_Call {
-	_c.Call.Return(n, err)
+func (_c *MocksRepository_DeleteComment_Call) Return(err error) *MocksRepository_DeleteComment_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MocksRepository_CreateComment_Call) RunAndReturn(run func(blogPostID string, text string) (int64, error)) *MocksRepository_CreateComment_Call {
+func (_c *MocksRepository_DeleteComment_Call) RunAndReturn(run func(blogPostID string, commentID string, deletedAt time.Time) error) *MocksRepository_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportContent provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) ExportContent(fn func(ExportRecord) error) error {
+	ret := _mock.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportContent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(func(ExportRecord) error) error); ok {
+		r0 = returnFunc(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_ExportContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportContent'
+type MocksRepository_ExportContent_Call struct {
+	*mock.Call
+}
+
+// ExportContent is a helper method to define mock.On call
+//   - fn func(ExportRecord) error
+func (_e *MocksRepository_Expecter) ExportContent(fn interface{}) *MocksRepository_ExportContent_Call {
+	return &MocksRepository_ExportContent_Call{Call: _e.mock.On("ExportContent", fn)}
+}
+
+func (_c *MocksRepository_ExportContent_Call) Run(run func(fn func(ExportRecord) error)) *MocksRepository_ExportContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(ExportRecord) error
+		if args[0] != nil {
+			arg0 = args[0].(func(ExportRecord) error)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_ExportContent_Call) Return(err error) *MocksRepository_ExportContent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_ExportContent_Call) RunAndReturn(run func(fn func(ExportRecord) error) error) *MocksRepository_ExportContent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetAllBlogPosts provides a mock function for the type MocksRepository
-func (_mock *MocksRepository) GetAllBlogPosts(page int, limit int) ([]BlogPost, error) {
-	ret := _mock.Called(page, limit)
+func (_mock *MocksRepository) GetAllBlogPosts(page int, limit int, opts ReadOptions) ([]BlogPost, error) {
+	ret := _mock.Called(page, limit, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllBlogPosts")
@@ -177,18 +412,18 @@ func (_mock *MocksRepository) GetAllBlogPosts(page int, limit int) ([]BlogPost,
 
 	var r0 []BlogPost
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, int) ([]BlogPost, error)); ok {
-		return returnFunc(page, limit)
+	if returnFunc, ok := ret.Get(0).(func(int, int, ReadOptions) ([]BlogPost, error)); ok {
+		return returnFunc(page, limit, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int) []BlogPost); ok {
-		r0 = returnFunc(page, limit)
+	if returnFunc, ok := ret.Get(0).(func(int, int, ReadOptions) []BlogPost); ok {
+		r0 = returnFunc(page, limit, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]BlogPost)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = returnFunc(page, limit)
+	if returnFunc, ok := ret.Get(1).(func(int, int, ReadOptions) error); ok {
+		r1 = returnFunc(page, limit, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -203,11 +438,12 @@ type MocksRepository_GetAllBlogPosts_Call struct {
 // GetAllBlogPosts is a helper method to define mock.On call
 //   - page int
 //   - limit int
-func (_e *MocksRepository_Expecter) GetAllBlogPosts(page interface{}, limit interface{}) *MocksRepository_GetAllBlogPosts_Call {
-	return &MocksRepository_GetAllBlogPosts_Call{Call: _e.mock.On("GetAllBlogPosts", page, limit)}
+//   - opts ReadOptions
+func (_e *MocksRepository_Expecter) GetAllBlogPosts(page interface{}, limit interface{}, opts interface{}) *MocksRepository_GetAllBlogPosts_Call {
+	return &MocksRepository_GetAllBlogPosts_Call{Call: _e.mock.On("GetAllBlogPosts", page, limit, opts)}
 }
 
-func (_c *MocksRepository_GetAllBlogPosts_Call) Run(run func(page int, limit int)) *MocksRepository_GetAllBlogPosts_Call {
+func (_c *MocksRepository_GetAllBlogPosts_Call) Run(run func(page int, limit int, opts ReadOptions)) *MocksRepository_GetAllBlogPosts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
@@ -217,9 +453,14 @@ func (_c *MocksRepository_GetAllBlogPosts_Call) Run(run func(page int, limit int
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 ReadOptions
+		if args[2] != nil {
+			arg2 = args[2].(ReadOptions)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -230,14 +471,14 @@ func (_c *MocksRepository_GetAllBlogPosts_Call) Return(blogPosts []BlogPost, err
 	return _c
 }
 
-func (_c *MocksRepository_GetAllBlogPosts_Call) RunAndReturn(run func(page int, limit int) ([]BlogPost, error)) *MocksRepository_GetAllBlogPosts_Call {
+func (_c *MocksRepository_GetAllBlogPosts_Call) RunAndReturn(run func(page int, limit int, opts ReadOptions) ([]BlogPost, error)) *MocksRepository_GetAllBlogPosts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetBlogPost provides a mock function for the type MocksRepository
-func (_mock *MocksRepository) GetBlogPost(id string) (*BlogPost, error) {
-	ret := _mock.Called(id)
+func (_mock *MocksRepository) GetBlogPost(id string, opts ReadOptions) (*BlogPost, error) {
+	ret := _mock.Called(id, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBlogPost")
@@ -245,18 +486,18 @@ func (_mock *MocksRepository) GetBlogPost(id string) (*BlogPost, error) {
 
 	var r0 *BlogPost
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*BlogPost, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(string, ReadOptions) (*BlogPost, error)); ok {
+		return returnFunc(id, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *BlogPost); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(string, ReadOptions) *BlogPost); ok {
+		r0 = returnFunc(id, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*BlogPost)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(string, ReadOptions) error); ok {
+		r1 = returnFunc(id, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -270,18 +511,24 @@ type MocksRepository_GetBlogPost_Call struct {
 
 // GetBlogPost is a helper method to define mock.On call
 //   - id string
-func (_e *MocksRepository_Expecter) GetBlogPost(id interface{}) *MocksRepository_GetBlogPost_Call {
-	return &MocksRepository_GetBlogPost_Call{Call: _e.mock.On("GetBlogPost", id)}
+//   - opts ReadOptions
+func (_e *MocksRepository_Expecter) GetBlogPost(id interface{}, opts interface{}) *MocksRepository_GetBlogPost_Call {
+	return &MocksRepository_GetBlogPost_Call{Call: _e.mock.On("GetBlogPost", id, opts)}
 }
 
-func (_c *MocksRepository_GetBlogPost_Call) Run(run func(id string)) *MocksRepository_GetBlogPost_Call {
+func (_c *MocksRepository_GetBlogPost_Call) Run(run func(id string, opts ReadOptions)) *MocksRepository_GetBlogPost_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
+		var arg1 ReadOptions
+		if args[1] != nil {
+			arg1 = args[1].(ReadOptions)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -292,7 +539,1606 @@ func (_c *MocksRepository_GetBlogPost_Call) Return(blogPost *BlogPost, err error
 	return _c
 }
 
-func (_c *MocksRepository_GetBlogPost_Call) RunAndReturn(run func(id string) (*BlogPost, error)) *MocksRepository_GetBlogPost_Call {
+func (_c *MocksRepository_GetBlogPost_Call) RunAndReturn(run func(id string, opts ReadOptions) (*BlogPost, error)) *MocksRepository_GetBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetComment provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetComment(blogPostID string, commentID string) (*Comment, error) {
+	ret := _mock.Called(blogPostID, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComment")
+	}
+
+	var r0 *Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*Comment, error)); ok {
+		return returnFunc(blogPostID, commentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *Comment); ok {
+		r0 = returnFunc(blogPostID, commentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(blogPostID, commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComment'
+type MocksRepository_GetComment_Call struct {
+	*mock.Call
+}
+
+// GetComment is a helper method to define mock.On call
+//   - blogPostID string
+//   - commentID string
+func (_e *MocksRepository_Expecter) GetComment(blogPostID interface{}, commentID interface{}) *MocksRepository_GetComment_Call {
+	return &MocksRepository_GetComment_Call{Call: _e.mock.On("GetComment", blogPostID, commentID)}
+}
+
+func (_c *MocksRepository_GetComment_Call) Run(run func(blogPostID string, commentID string)) *MocksRepository_GetComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetComment_Call) Return(comment *Comment, err error) *MocksRepository_GetComment_Call {
+	_c.Call.Return(comment, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetComment_Call) RunAndReturn(run func(blogPostID string, commentID string) (*Comment, error)) *MocksRepository_GetComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetComments provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetComments(blogPostID string, query CommentsQuery) ([]Comment, error) {
+	ret := _mock.Called(blogPostID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, CommentsQuery) ([]Comment, error)); ok {
+		return returnFunc(blogPostID, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, CommentsQuery) []Comment); ok {
+		r0 = returnFunc(blogPostID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, CommentsQuery) error); ok {
+		r1 = returnFunc(blogPostID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComments'
+type MocksRepository_GetComments_Call struct {
+	*mock.Call
+}
+
+// GetComments is a helper method to define mock.On call
+//   - blogPostID string
+//   - query CommentsQuery
+func (_e *MocksRepository_Expecter) GetComments(blogPostID interface{}, query interface{}) *MocksRepository_GetComments_Call {
+	return &MocksRepository_GetComments_Call{Call: _e.mock.On("GetComments", blogPostID, query)}
+}
+
+func (_c *MocksRepository_GetComments_Call) Run(run func(blogPostID string, query CommentsQuery)) *MocksRepository_GetComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 CommentsQuery
+		if args[1] != nil {
+			arg1 = args[1].(CommentsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetComments_Call) Return(comments []Comment, err error) *MocksRepository_GetComments_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetComments_Call) RunAndReturn(run func(blogPostID string, query CommentsQuery) ([]Comment, error)) *MocksRepository_GetComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentsAfter provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetCommentsAfter(blogPostID string, commentID string, limit int) ([]Comment, error) {
+	ret := _mock.Called(blogPostID, commentID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentsAfter")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, int) ([]Comment, error)); ok {
+		return returnFunc(blogPostID, commentID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, int) []Comment); ok {
+		r0 = returnFunc(blogPostID, commentID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = returnFunc(blogPostID, commentID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetCommentsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentsAfter'
+type MocksRepository_GetCommentsAfter_Call struct {
+	*mock.Call
+}
+
+// GetCommentsAfter is a helper method to define mock.On call
+//   - blogPostID string
+//   - commentID string
+//   - limit int
+func (_e *MocksRepository_Expecter) GetCommentsAfter(blogPostID interface{}, commentID interface{}, limit interface{}) *MocksRepository_GetCommentsAfter_Call {
+	return &MocksRepository_GetCommentsAfter_Call{Call: _e.mock.On("GetCommentsAfter", blogPostID, commentID, limit)}
+}
+
+func (_c *MocksRepository_GetCommentsAfter_Call) Run(run func(blogPostID string, commentID string, limit int)) *MocksRepository_GetCommentsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetCommentsAfter_Call) Return(comments []Comment, err error) *MocksRepository_GetCommentsAfter_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetCommentsAfter_Call) RunAndReturn(run func(blogPostID string, commentID string, limit int) ([]Comment, error)) *MocksRepository_GetCommentsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentsByBlogPosts provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetCommentsByBlogPosts(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	ret := _mock.Called(blogPostIDs, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentsByBlogPosts")
+	}
+
+	var r0 map[string][]Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) (map[string][]Comment, error)); ok {
+		return returnFunc(blogPostIDs, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) map[string][]Comment); ok {
+		r0 = returnFunc(blogPostIDs, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string, CommentsQuery) error); ok {
+		r1 = returnFunc(blogPostIDs, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetCommentsByBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentsByBlogPosts'
+type MocksRepository_GetCommentsByBlogPosts_Call struct {
+	*mock.Call
+}
+
+// GetCommentsByBlogPosts is a helper method to define mock.On call
+//   - blogPostIDs []string
+//   - query CommentsQuery
+func (_e *MocksRepository_Expecter) GetCommentsByBlogPosts(blogPostIDs interface{}, query interface{}) *MocksRepository_GetCommentsByBlogPosts_Call {
+	return &MocksRepository_GetCommentsByBlogPosts_Call{Call: _e.mock.On("GetCommentsByBlogPosts", blogPostIDs, query)}
+}
+
+func (_c *MocksRepository_GetCommentsByBlogPosts_Call) Run(run func(blogPostIDs []string, query CommentsQuery)) *MocksRepository_GetCommentsByBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		var arg1 CommentsQuery
+		if args[1] != nil {
+			arg1 = args[1].(CommentsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetCommentsByBlogPosts_Call) Return(m map[string][]Comment, err error) *MocksRepository_GetCommentsByBlogPosts_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetCommentsByBlogPosts_Call) RunAndReturn(run func(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error)) *MocksRepository_GetCommentsByBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentsByModerationStatus provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetCommentsByModerationStatus(status ModerationStatus, limit int, offset int) ([]Comment, error) {
+	ret := _mock.Called(status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentsByModerationStatus")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(ModerationStatus, int, int) ([]Comment, error)); ok {
+		return returnFunc(status, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(ModerationStatus, int, int) []Comment); ok {
+		r0 = returnFunc(status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(ModerationStatus, int, int) error); ok {
+		r1 = returnFunc(status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetCommentsByModerationStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentsByModerationStatus'
+type MocksRepository_GetCommentsByModerationStatus_Call struct {
+	*mock.Call
+}
+
+// GetCommentsByModerationStatus is a helper method to define mock.On call
+//   - status ModerationStatus
+//   - limit int
+//   - offset int
+func (_e *MocksRepository_Expecter) GetCommentsByModerationStatus(status interface{}, limit interface{}, offset interface{}) *MocksRepository_GetCommentsByModerationStatus_Call {
+	return &MocksRepository_GetCommentsByModerationStatus_Call{Call: _e.mock.On("GetCommentsByModerationStatus", status, limit, offset)}
+}
+
+func (_c *MocksRepository_GetCommentsByModerationStatus_Call) Run(run func(status ModerationStatus, limit int, offset int)) *MocksRepository_GetCommentsByModerationStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 ModerationStatus
+		if args[0] != nil {
+			arg0 = args[0].(ModerationStatus)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetCommentsByModerationStatus_Call) Return(comments []Comment, err error) *MocksRepository_GetCommentsByModerationStatus_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetCommentsByModerationStatus_Call) RunAndReturn(run func(status ModerationStatus, limit int, offset int) ([]Comment, error)) *MocksRepository_GetCommentsByModerationStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedBlogPosts provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetDeletedBlogPosts(limit int, offset int) ([]BlogPost, error) {
+	ret := _mock.Called(limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedBlogPosts")
+	}
+
+	var r0 []BlogPost
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]BlogPost, error)); ok {
+		return returnFunc(limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []BlogPost); ok {
+		r0 = returnFunc(limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BlogPost)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetDeletedBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedBlogPosts'
+type MocksRepository_GetDeletedBlogPosts_Call struct {
+	*mock.Call
+}
+
+// GetDeletedBlogPosts is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+func (_e *MocksRepository_Expecter) GetDeletedBlogPosts(limit interface{}, offset interface{}) *MocksRepository_GetDeletedBlogPosts_Call {
+	return &MocksRepository_GetDeletedBlogPosts_Call{Call: _e.mock.On("GetDeletedBlogPosts", limit, offset)}
+}
+
+func (_c *MocksRepository_GetDeletedBlogPosts_Call) Run(run func(limit int, offset int)) *MocksRepository_GetDeletedBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetDeletedBlogPosts_Call) Return(blogPosts []BlogPost, err error) *MocksRepository_GetDeletedBlogPosts_Call {
+	_c.Call.Return(blogPosts, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetDeletedBlogPosts_Call) RunAndReturn(run func(limit int, offset int) ([]BlogPost, error)) *MocksRepository_GetDeletedBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedComments provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetDeletedComments(limit int, offset int) ([]Comment, error) {
+	ret := _mock.Called(limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedComments")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]Comment, error)); ok {
+		return returnFunc(limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []Comment); ok {
+		r0 = returnFunc(limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetDeletedComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedComments'
+type MocksRepository_GetDeletedComments_Call struct {
+	*mock.Call
+}
+
+// GetDeletedComments is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+func (_e *MocksRepository_Expecter) GetDeletedComments(limit interface{}, offset interface{}) *MocksRepository_GetDeletedComments_Call {
+	return &MocksRepository_GetDeletedComments_Call{Call: _e.mock.On("GetDeletedComments", limit, offset)}
+}
+
+func (_c *MocksRepository_GetDeletedComments_Call) Run(run func(limit int, offset int)) *MocksRepository_GetDeletedComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetDeletedComments_Call) Return(comments []Comment, err error) *MocksRepository_GetDeletedComments_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetDeletedComments_Call) RunAndReturn(run func(limit int, offset int) ([]Comment, error)) *MocksRepository_GetDeletedComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingEvents provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetPendingEvents(now time.Time, limit int) ([]Event, error) {
+	ret := _mock.Called(now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingEvents")
+	}
+
+	var r0 []Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Time, int) ([]Event, error)); ok {
+		return returnFunc(now, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Time, int) []Event); ok {
+		r0 = returnFunc(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Time, int) error); ok {
+		r1 = returnFunc(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetPendingEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingEvents'
+type MocksRepository_GetPendingEvents_Call struct {
+	*mock.Call
+}
+
+// GetPendingEvents is a helper method to define mock.On call
+//   - now time.Time
+//   - limit int
+func (_e *MocksRepository_Expecter) GetPendingEvents(now interface{}, limit interface{}) *MocksRepository_GetPendingEvents_Call {
+	return &MocksRepository_GetPendingEvents_Call{Call: _e.mock.On("GetPendingEvents", now, limit)}
+}
+
+func (_c *MocksRepository_GetPendingEvents_Call) Run(run func(now time.Time, limit int)) *MocksRepository_GetPendingEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetPendingEvents_Call) Return(events []Event, err error) *MocksRepository_GetPendingEvents_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetPendingEvents_Call) RunAndReturn(run func(now time.Time, limit int) ([]Event, error)) *MocksRepository_GetPendingEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepliesByComments provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetRepliesByComments(commentIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	ret := _mock.Called(commentIDs, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepliesByComments")
+	}
+
+	var r0 map[string][]Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) (map[string][]Comment, error)); ok {
+		return returnFunc(commentIDs, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) map[string][]Comment); ok {
+		r0 = returnFunc(commentIDs, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string, CommentsQuery) error); ok {
+		r1 = returnFunc(commentIDs, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetRepliesByComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepliesByComments'
+type MocksRepository_GetRepliesByComments_Call struct {
+	*mock.Call
+}
+
+// GetRepliesByComments is a helper method to define mock.On call
+//   - commentIDs []string
+//   - query CommentsQuery
+func (_e *MocksRepository_Expecter) GetRepliesByComments(commentIDs interface{}, query interface{}) *MocksRepository_GetRepliesByComments_Call {
+	return &MocksRepository_GetRepliesByComments_Call{Call: _e.mock.On("GetRepliesByComments", commentIDs, query)}
+}
+
+func (_c *MocksRepository_GetRepliesByComments_Call) Run(run func(commentIDs []string, query CommentsQuery)) *MocksRepository_GetRepliesByComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		var arg1 CommentsQuery
+		if args[1] != nil {
+			arg1 = args[1].(CommentsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetRepliesByComments_Call) Return(m map[string][]Comment, err error) *MocksRepository_GetRepliesByComments_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetRepliesByComments_Call) RunAndReturn(run func(commentIDs []string, query CommentsQuery) (map[string][]Comment, error)) *MocksRepository_GetRepliesByComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevision provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetRevision(blogPostID string, number int) (*Revision, error) {
+	ret := _mock.Called(blogPostID, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int) (*Revision, error)); ok {
+		return returnFunc(blogPostID, number)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int) *Revision); ok {
+		r0 = returnFunc(blogPostID, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = returnFunc(blogPostID, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevision'
+type MocksRepository_GetRevision_Call struct {
+	*mock.Call
+}
+
+// GetRevision is a helper method to define mock.On call
+//   - blogPostID string
+//   - number int
+func (_e *MocksRepository_Expecter) GetRevision(blogPostID interface{}, number interface{}) *MocksRepository_GetRevision_Call {
+	return &MocksRepository_GetRevision_Call{Call: _e.mock.On("GetRevision", blogPostID, number)}
+}
+
+func (_c *MocksRepository_GetRevision_Call) Run(run func(blogPostID string, number int)) *MocksRepository_GetRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetRevision_Call) Return(revision *Revision, err error) *MocksRepository_GetRevision_Call {
+	_c.Call.Return(revision, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetRevision_Call) RunAndReturn(run func(blogPostID string, number int) (*Revision, error)) *MocksRepository_GetRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevisions provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetRevisions(blogPostID string, limit int, offset int) ([]Revision, error) {
+	ret := _mock.Called(blogPostID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) ([]Revision, error)); ok {
+		return returnFunc(blogPostID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) []Revision); ok {
+		r0 = returnFunc(blogPostID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = returnFunc(blogPostID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisions'
+type MocksRepository_GetRevisions_Call struct {
+	*mock.Call
+}
+
+// GetRevisions is a helper method to define mock.On call
+//   - blogPostID string
+//   - limit int
+//   - offset int
+func (_e *MocksRepository_Expecter) GetRevisions(blogPostID interface{}, limit interface{}, offset interface{}) *MocksRepository_GetRevisions_Call {
+	return &MocksRepository_GetRevisions_Call{Call: _e.mock.On("GetRevisions", blogPostID, limit, offset)}
+}
+
+func (_c *MocksRepository_GetRevisions_Call) Run(run func(blogPostID string, limit int, offset int)) *MocksRepository_GetRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetRevisions_Call) Return(revisions []Revision, err error) *MocksRepository_GetRevisions_Call {
+	_c.Call.Return(revisions, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetRevisions_Call) RunAndReturn(run func(blogPostID string, limit int, offset int) ([]Revision, error)) *MocksRepository_GetRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTags provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) GetTags(opts ReadOptions) ([]Tag, error) {
+	ret := _mock.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(ReadOptions) ([]Tag, error)); ok {
+		return returnFunc(opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(ReadOptions) []Tag); ok {
+		r0 = returnFunc(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(ReadOptions) error); ok {
+		r1 = returnFunc(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
+type MocksRepository_GetTags_Call struct {
+	*mock.Call
+}
+
+// GetTags is a helper method to define mock.On call
+//   - opts ReadOptions
+func (_e *MocksRepository_Expecter) GetTags(opts interface{}) *MocksRepository_GetTags_Call {
+	return &MocksRepository_GetTags_Call{Call: _e.mock.On("GetTags", opts)}
+}
+
+func (_c *MocksRepository_GetTags_Call) Run(run func(opts ReadOptions)) *MocksRepository_GetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 ReadOptions
+		if args[0] != nil {
+			arg0 = args[0].(ReadOptions)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_GetTags_Call) Return(tags []Tag, err error) *MocksRepository_GetTags_Call {
+	_c.Call.Return(tags, err)
+	return _c
+}
+
+func (_c *MocksRepository_GetTags_Call) RunAndReturn(run func(opts ReadOptions) ([]Tag, error)) *MocksRepository_GetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasComment provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) HasComment(blogPostID string, text string) (bool, error) {
+	ret := _mock.Called(blogPostID, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasComment")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return returnFunc(blogPostID, text)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = returnFunc(blogPostID, text)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(blogPostID, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_HasComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasComment'
+type MocksRepository_HasComment_Call struct {
+	*mock.Call
+}
+
+// HasComment is a helper method to define mock.On call
+//   - blogPostID string
+//   - text string
+func (_e *MocksRepository_Expecter) HasComment(blogPostID interface{}, text interface{}) *MocksRepository_HasComment_Call {
+	return &MocksRepository_HasComment_Call{Call: _e.mock.On("HasComment", blogPostID, text)}
+}
+
+func (_c *MocksRepository_HasComment_Call) Run(run func(blogPostID string, text string)) *MocksRepository_HasComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_HasComment_Call) Return(b bool, err error) *MocksRepository_HasComment_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MocksRepository_HasComment_Call) RunAndReturn(run func(blogPostID string, text string) (bool, error)) *MocksRepository_HasComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportRecords provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) ImportRecords(batches iter.Seq[[]ExportRecord], dryRun bool, fn func(records []ExportRecord, errs []error)) error {
+	ret := _mock.Called(batches, dryRun, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportRecords")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(iter.Seq[[]ExportRecord], bool, func(records []ExportRecord, errs []error)) error); ok {
+		r0 = returnFunc(batches, dryRun, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_ImportRecords_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportRecords'
+type MocksRepository_ImportRecords_Call struct {
+	*mock.Call
+}
+
+// ImportRecords is a helper method to define mock.On call
+//   - batches iter.Seq[[]ExportRecord]
+//   - dryRun bool
+//   - fn func(records []ExportRecord, errs []error)
+func (_e *MocksRepository_Expecter) ImportRecords(batches interface{}, dryRun interface{}, fn interface{}) *MocksRepository_ImportRecords_Call {
+	return &MocksRepository_ImportRecords_Call{Call: _e.mock.On("ImportRecords", batches, dryRun, fn)}
+}
+
+func (_c *MocksRepository_ImportRecords_Call) Run(run func(batches iter.Seq[[]ExportRecord], dryRun bool, fn func(records []ExportRecord, errs []error))) *MocksRepository_ImportRecords_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 iter.Seq[[]ExportRecord]
+		if args[0] != nil {
+			arg0 = args[0].(iter.Seq[[]ExportRecord])
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		var arg2 func(records []ExportRecord, errs []error)
+		if args[2] != nil {
+			arg2 = args[2].(func(records []ExportRecord, errs []error))
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_ImportRecords_Call) Return(err error) *MocksRepository_ImportRecords_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_ImportRecords_Call) RunAndReturn(run func(batches iter.Seq[[]ExportRecord], dryRun bool, fn func(records []ExportRecord, errs []error)) error) *MocksRepository_ImportRecords_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEventDispatched provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) MarkEventDispatched(id string, at time.Time) error {
+	ret := _mock.Called(id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEventDispatched")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = returnFunc(id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_MarkEventDispatched_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEventDispatched'
+type MocksRepository_MarkEventDispatched_Call struct {
+	*mock.Call
+}
+
+// MarkEventDispatched is a helper method to define mock.On call
+//   - id string
+//   - at time.Time
+func (_e *MocksRepository_Expecter) MarkEventDispatched(id interface{}, at interface{}) *MocksRepository_MarkEventDispatched_Call {
+	return &MocksRepository_MarkEventDispatched_Call{Call: _e.mock.On("MarkEventDispatched", id, at)}
+}
+
+func (_c *MocksRepository_MarkEventDispatched_Call) Run(run func(id string, at time.Time)) *MocksRepository_MarkEventDispatched_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_MarkEventDispatched_Call) Return(err error) *MocksRepository_MarkEventDispatched_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_MarkEventDispatched_Call) RunAndReturn(run func(id string, at time.Time) error) *MocksRepository_MarkEventDispatched_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEventFailed provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) MarkEventFailed(id string, cause string, retryAt time.Time) error {
+	ret := _mock.Called(id, cause, retryAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEventFailed")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Time) error); ok {
+		r0 = returnFunc(id, cause, retryAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_MarkEventFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEventFailed'
+type MocksRepository_MarkEventFailed_Call struct {
+	*mock.Call
+}
+
+// MarkEventFailed is a helper method to define mock.On call
+//   - id string
+//   - cause string
+//   - retryAt time.Time
+func (_e *MocksRepository_Expecter) MarkEventFailed(id interface{}, cause interface{}, retryAt interface{}) *MocksRepository_MarkEventFailed_Call {
+	return &MocksRepository_MarkEventFailed_Call{Call: _e.mock.On("MarkEventFailed", id, cause, retryAt)}
+}
+
+func (_c *MocksRepository_MarkEventFailed_Call) Run(run func(id string, cause string, retryAt time.Time)) *MocksRepository_MarkEventFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_MarkEventFailed_Call) Return(err error) *MocksRepository_MarkEventFailed_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_MarkEventFailed_Call) RunAndReturn(run func(id string, cause string, retryAt time.Time) error) *MocksRepository_MarkEventFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneDispatchedEvents provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) PruneDispatchedEvents(before time.Time) (int64, error) {
+	ret := _mock.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneDispatchedEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return returnFunc(before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = returnFunc(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = returnFunc(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_PruneDispatchedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneDispatchedEvents'
+type MocksRepository_PruneDispatchedEvents_Call struct {
+	*mock.Call
+}
+
+// PruneDispatchedEvents is a helper method to define mock.On call
+//   - before time.Time
+func (_e *MocksRepository_Expecter) PruneDispatchedEvents(before interface{}) *MocksRepository_PruneDispatchedEvents_Call {
+	return &MocksRepository_PruneDispatchedEvents_Call{Call: _e.mock.On("PruneDispatchedEvents", before)}
+}
+
+func (_c *MocksRepository_PruneDispatchedEvents_Call) Run(run func(before time.Time)) *MocksRepository_PruneDispatchedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_PruneDispatchedEvents_Call) Return(n int64, err error) *MocksRepository_PruneDispatchedEvents_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksRepository_PruneDispatchedEvents_Call) RunAndReturn(run func(before time.Time) (int64, error)) *MocksRepository_PruneDispatchedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishDueBlogPosts provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) PublishDueBlogPosts(now time.Time) (int64, error) {
+	ret := _mock.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishDueBlogPosts")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return returnFunc(now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = returnFunc(now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = returnFunc(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_PublishDueBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishDueBlogPosts'
+type MocksRepository_PublishDueBlogPosts_Call struct {
+	*mock.Call
+}
+
+// PublishDueBlogPosts is a helper method to define mock.On call
+//   - now time.Time
+func (_e *MocksRepository_Expecter) PublishDueBlogPosts(now interface{}) *MocksRepository_PublishDueBlogPosts_Call {
+	return &MocksRepository_PublishDueBlogPosts_Call{Call: _e.mock.On("PublishDueBlogPosts", now)}
+}
+
+func (_c *MocksRepository_PublishDueBlogPosts_Call) Run(run func(now time.Time)) *MocksRepository_PublishDueBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_PublishDueBlogPosts_Call) Return(n int64, err error) *MocksRepository_PublishDueBlogPosts_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksRepository_PublishDueBlogPosts_Call) RunAndReturn(run func(now time.Time) (int64, error)) *MocksRepository_PublishDueBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeleted provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) PurgeDeleted(before time.Time) (int64, error) {
+	ret := _mock.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return returnFunc(before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = returnFunc(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = returnFunc(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_PurgeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeleted'
+type MocksRepository_PurgeDeleted_Call struct {
+	*mock.Call
+}
+
+// PurgeDeleted is a helper method to define mock.On call
+//   - before time.Time
+func (_e *MocksRepository_Expecter) PurgeDeleted(before interface{}) *MocksRepository_PurgeDeleted_Call {
+	return &MocksRepository_PurgeDeleted_Call{Call: _e.mock.On("PurgeDeleted", before)}
+}
+
+func (_c *MocksRepository_PurgeDeleted_Call) Run(run func(before time.Time)) *MocksRepository_PurgeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_PurgeDeleted_Call) Return(n int64, err error) *MocksRepository_PurgeDeleted_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksRepository_PurgeDeleted_Call) RunAndReturn(run func(before time.Time) (int64, error)) *MocksRepository_PurgeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReindexBlogPosts provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) ReindexBlogPosts(render func(post *BlogPost) error) (int64, error) {
+	ret := _mock.Called(render)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexBlogPosts")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(func(post *BlogPost) error) (int64, error)); ok {
+		return returnFunc(render)
+	}
+	if returnFunc, ok := ret.Get(0).(func(func(post *BlogPost) error) int64); ok {
+		r0 = returnFunc(render)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(func(post *BlogPost) error) error); ok {
+		r1 = returnFunc(render)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_ReindexBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexBlogPosts'
+type MocksRepository_ReindexBlogPosts_Call struct {
+	*mock.Call
+}
+
+// ReindexBlogPosts is a helper method to define mock.On call
+//   - render func(post *BlogPost) error
+func (_e *MocksRepository_Expecter) ReindexBlogPosts(render interface{}) *MocksRepository_ReindexBlogPosts_Call {
+	return &MocksRepository_ReindexBlogPosts_Call{Call: _e.mock.On("ReindexBlogPosts", render)}
+}
+
+func (_c *MocksRepository_ReindexBlogPosts_Call) Run(run func(render func(post *BlogPost) error)) *MocksRepository_ReindexBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(post *BlogPost) error
+		if args[0] != nil {
+			arg0 = args[0].(func(post *BlogPost) error)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_ReindexBlogPosts_Call) Return(n int64, err error) *MocksRepository_ReindexBlogPosts_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksRepository_ReindexBlogPosts_Call) RunAndReturn(run func(render func(post *BlogPost) error) (int64, error)) *MocksRepository_ReindexBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreBlogPost provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) RestoreBlogPost(id string) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBlogPost")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_RestoreBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBlogPost'
+type MocksRepository_RestoreBlogPost_Call struct {
+	*mock.Call
+}
+
+// RestoreBlogPost is a helper method to define mock.On call
+//   - id string
+func (_e *MocksRepository_Expecter) RestoreBlogPost(id interface{}) *MocksRepository_RestoreBlogPost_Call {
+	return &MocksRepository_RestoreBlogPost_Call{Call: _e.mock.On("RestoreBlogPost", id)}
+}
+
+func (_c *MocksRepository_RestoreBlogPost_Call) Run(run func(id string)) *MocksRepository_RestoreBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_RestoreBlogPost_Call) Return(err error) *MocksRepository_RestoreBlogPost_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_RestoreBlogPost_Call) RunAndReturn(run func(id string) error) *MocksRepository_RestoreBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreComment provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) RestoreComment(commentID string) error {
+	ret := _mock.Called(commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_RestoreComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreComment'
+type MocksRepository_RestoreComment_Call struct {
+	*mock.Call
+}
+
+// RestoreComment is a helper method to define mock.On call
+//   - commentID string
+func (_e *MocksRepository_Expecter) RestoreComment(commentID interface{}) *MocksRepository_RestoreComment_Call {
+	return &MocksRepository_RestoreComment_Call{Call: _e.mock.On("RestoreComment", commentID)}
+}
+
+func (_c *MocksRepository_RestoreComment_Call) Run(run func(commentID string)) *MocksRepository_RestoreComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_RestoreComment_Call) Return(err error) *MocksRepository_RestoreComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_RestoreComment_Call) RunAndReturn(run func(commentID string) error) *MocksRepository_RestoreComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBlogPost provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) UpdateBlogPost(post BlogPost) error {
+	ret := _mock.Called(post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBlogPost")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(BlogPost) error); ok {
+		r0 = returnFunc(post)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_UpdateBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBlogPost'
+type MocksRepository_UpdateBlogPost_Call struct {
+	*mock.Call
+}
+
+// UpdateBlogPost is a helper method to define mock.On call
+//   - post BlogPost
+func (_e *MocksRepository_Expecter) UpdateBlogPost(post interface{}) *MocksRepository_UpdateBlogPost_Call {
+	return &MocksRepository_UpdateBlogPost_Call{Call: _e.mock.On("UpdateBlogPost", post)}
+}
+
+func (_c *MocksRepository_UpdateBlogPost_Call) Run(run func(post BlogPost)) *MocksRepository_UpdateBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 BlogPost
+		if args[0] != nil {
+			arg0 = args[0].(BlogPost)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_UpdateBlogPost_Call) Return(err error) *MocksRepository_UpdateBlogPost_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_UpdateBlogPost_Call) RunAndReturn(run func(post BlogPost) error) *MocksRepository_UpdateBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBlogPostStatus provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) UpdateBlogPostStatus(id string, status Status, publishAt *time.Time) error {
+	ret := _mock.Called(id, status, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBlogPostStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, Status, *time.Time) error); ok {
+		r0 = returnFunc(id, status, publishAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksRepository_UpdateBlogPostStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBlogPostStatus'
+type MocksRepository_UpdateBlogPostStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateBlogPostStatus is a helper method to define mock.On call
+//   - id string
+//   - status Status
+//   - publishAt *time.Time
+func (_e *MocksRepository_Expecter) UpdateBlogPostStatus(id interface{}, status interface{}, publishAt interface{}) *MocksRepository_UpdateBlogPostStatus_Call {
+	return &MocksRepository_UpdateBlogPostStatus_Call{Call: _e.mock.On("UpdateBlogPostStatus", id, status, publishAt)}
+}
+
+func (_c *MocksRepository_UpdateBlogPostStatus_Call) Run(run func(id string, status Status, publishAt *time.Time)) *MocksRepository_UpdateBlogPostStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 Status
+		if args[1] != nil {
+			arg1 = args[1].(Status)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_UpdateBlogPostStatus_Call) Return(err error) *MocksRepository_UpdateBlogPostStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksRepository_UpdateBlogPostStatus_Call) RunAndReturn(run func(id string, status Status, publishAt *time.Time) error) *MocksRepository_UpdateBlogPostStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCommentsModerationStatus provides a mock function for the type MocksRepository
+func (_mock *MocksRepository) UpdateCommentsModerationStatus(ids []string, status ModerationStatus) (int64, error) {
+	ret := _mock.Called(ids, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCommentsModerationStatus")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string, ModerationStatus) (int64, error)); ok {
+		return returnFunc(ids, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string, ModerationStatus) int64); ok {
+		r0 = returnFunc(ids, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string, ModerationStatus) error); ok {
+		r1 = returnFunc(ids, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksRepository_UpdateCommentsModerationStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentsModerationStatus'
+type MocksRepository_UpdateCommentsModerationStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCommentsModerationStatus is a helper method to define mock.On call
+//   - ids []string
+//   - status ModerationStatus
+func (_e *MocksRepository_Expecter) UpdateCommentsModerationStatus(ids interface{}, status interface{}) *MocksRepository_UpdateCommentsModerationStatus_Call {
+	return &MocksRepository_UpdateCommentsModerationStatus_Call{Call: _e.mock.On("UpdateCommentsModerationStatus", ids, status)}
+}
+
+func (_c *MocksRepository_UpdateCommentsModerationStatus_Call) Run(run func(ids []string, status ModerationStatus)) *MocksRepository_UpdateCommentsModerationStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		var arg1 ModerationStatus
+		if args[1] != nil {
+			arg1 = args[1].(ModerationStatus)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksRepository_UpdateCommentsModerationStatus_Call) Return(n int64, err error) *MocksRepository_UpdateCommentsModerationStatus_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksRepository_UpdateCommentsModerationStatus_Call) RunAndReturn(run func(ids []string, status ModerationStatus) (int64, error)) *MocksRepository_UpdateCommentsModerationStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,6 +6,8 @@ package posts
 
 import (
 	mock "github.com/stretchr/testify/mock"
+	"io"
+	"time"
 )
 
 // NewMocksService creates a new instance of MocksService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -35,26 +37,77 @@ func (_m *MocksService) EXPECT() *MocksService_Expecter {
 	return &MocksService_Expecter{mock: &_m.Mock}
 }
 
+// ArchiveBlogPost provides a mock function for the type MocksService
+func (_mock *MocksService) ArchiveBlogPost(id string) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveBlogPost")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_ArchiveBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveBlogPost'
+type MocksService_ArchiveBlogPost_Call struct {
+	*mock.Call
+}
+
+// ArchiveBlogPost is a helper method to define mock.On call
+//   - id string
+func (_e *MocksService_Expecter) ArchiveBlogPost(id interface{}) *MocksService_ArchiveBlogPost_Call {
+	return &MocksService_ArchiveBlogPost_Call{Call: _e.mock.On("ArchiveBlogPost", id)}
+}
+
+func (_c *MocksService_ArchiveBlogPost_Call) Run(run func(id string)) *MocksService_ArchiveBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_ArchiveBlogPost_Call) Return(err error) *MocksService_ArchiveBlogPost_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_ArchiveBlogPost_Call) RunAndReturn(run func(id string) error) *MocksService_ArchiveBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateBlogPost provides a mock function for the type MocksService
-func (_mock *MocksService) CreateBlogPost(title string, content string) (int64, error) {
-	ret := _mock.Called(title, content)
+func (_mock *MocksService) CreateBlogPost(request CreatePostRequest) (string, error) {
+	ret := _mock.Called(request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateBlogPost")
 	}
 
-	var r0 int64
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
-		return returnFunc(title, content)
+	if returnFunc, ok := ret.Get(0).(func(CreatePostRequest) (string, error)); ok {
+		return returnFunc(request)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = returnFunc(title, content)
+	if returnFunc, ok := ret.Get(0).(func(CreatePostRequest) string); ok {
+		r0 = returnFunc(request)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = returnFunc(title, content)
+	if returnFunc, ok := ret.Get(1).(func(CreatePostRequest) error); ok {
+		r1 = returnFunc(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -67,21 +120,83 @@ type MocksService_CreateBlogPost_Call struct {
 }
 
 // CreateBlogPost is a helper method to define mock.On call
-//   - title string
-//   - content string
-func (_e *MocksService_Expecter) CreateBlogPost(title interface{}, content interface{}) *MocksService_CreateBlogPost_Call {
-	return &MocksService_CreateBlogPost_Call{Call: _e.mock.On("CreateBlogPost", title, content)}
+//   - request CreatePostRequest
+func (_e *MocksService_Expecter) CreateBlogPost(request interface{}) *MocksService_CreateBlogPost_Call {
+	return &MocksService_CreateBlogPost_Call{Call: _e.mock.On("CreateBlogPost", request)}
 }
 
-func (_c *MocksService_CreateBlogPost_Call) Run(run func(title string, content string)) *MocksService_CreateBlogPost_Call {
+func (_c *MocksService_CreateBlogPost_Call) Run(run func(request CreatePostRequest)) *MocksService_CreateBlogPost_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 CreatePostRequest
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(CreatePostRequest)
 		}
-		var arg1 string
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_CreateBlogPost_Call) Return(s string, err error) *MocksService_CreateBlogPost_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MocksService_CreateBlogPost_Call) RunAndReturn(run func(request CreatePostRequest) (string, error)) *MocksService_CreateBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBlogPosts provides a mock function for the type MocksService
+func (_mock *MocksService) CreateBlogPosts(requests []CreatePostRequest, atomic bool) ([]BatchResult, error) {
+	ret := _mock.Called(requests, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBlogPosts")
+	}
+
+	var r0 []BatchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]CreatePostRequest, bool) ([]BatchResult, error)); ok {
+		return returnFunc(requests, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]CreatePostRequest, bool) []BatchResult); ok {
+		r0 = returnFunc(requests, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BatchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]CreatePostRequest, bool) error); ok {
+		r1 = returnFunc(requests, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_CreateBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBlogPosts'
+type MocksService_CreateBlogPosts_Call struct {
+	*mock.Call
+}
+
+// CreateBlogPosts is a helper method to define mock.On call
+//   - requests []CreatePostRequest
+//   - atomic bool
+func (_e *MocksService_Expecter) CreateBlogPosts(requests interface{}, atomic interface{}) *MocksService_CreateBlogPosts_Call {
+	return &MocksService_CreateBlogPosts_Call{Call: _e.mock.On("CreateBlogPosts", requests, atomic)}
+}
+
+func (_c *MocksService_CreateBlogPosts_Call) Run(run func(requests []CreatePostRequest, atomic bool)) *MocksService_CreateBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []CreatePostRequest
+		if args[0] != nil {
+			arg0 = args[0].([]CreatePostRequest)
+		}
+		var arg1 bool
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(bool)
 		}
 		run(
 			arg0,
@@ -91,33 +206,33 @@ func (_c *MocksService_CreateBlogPost_Call) Run(run func(title string, content s
 	return _c
 }
 
-func (_c *MocksService_CreateBlogPost_Call) Return(n int64, err error) *MocksService_CreateBlogPost_Call {
-	_c.Call.Return(n, err)
+func (_c *MocksService_CreateBlogPosts_Call) Return(batchResults []BatchResult, err error) *MocksService_CreateBlogPosts_Call {
+	_c.Call.Return(batchResults, err)
 	return _c
 }
 
-func (_c *MocksService_CreateBlogPost_Call) RunAndReturn(run func(title string, content string) (int64, error)) *MocksService_CreateBlogPost_Call {
+func (_c *MocksService_CreateBlogPosts_Call) RunAndReturn(run func(requests []CreatePostRequest, atomic bool) ([]BatchResult, error)) *MocksService_CreateBlogPosts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // CreateComment provides a mock function for the type MocksService
-func (_mock *MocksService) CreateComment(blogPostID string, text string) (int64, error) {
+func (_mock *MocksService) CreateComment(blogPostID string, text string) (string, error) {
 	ret := _mock.Called(blogPostID, text)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateComment")
 	}
 
-	var r0 int64
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(string, string) (string, error)); ok {
 		return returnFunc(blogPostID, text)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) int64); ok {
+	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
 		r0 = returnFunc(blogPostID, text)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(string)
 	}
 	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
 		r1 = returnFunc(blogPostID, text)
@@ -157,142 +272,1729 @@ func (_c *MocksService_CreateComment_Call) Run(run func(blogPostID string, text
 	return _c
 }
 
-func (_c *MocksService_CreateComment_Call) Return(n int64, err error) *MocksService_CreateComment_Call {
-	_c.Call.Return(n, err)
+func (_c *MocksService_CreateComment_Call) Return(s string, err error) *MocksService_CreateComment_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MocksService_CreateComment_Call) RunAndReturn(run func(blogPostID string, text string) (int64, error)) *MocksService_CreateComment_Call {
+func (_c *MocksService_CreateComment_Call) RunAndReturn(run func(blogPostID string, text string) (string, error)) *MocksService_CreateComment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllBlogPosts provides a mock function for the type MocksService
-func (_mock *MocksService) GetAllBlogPosts(page int, limit int) ([]BlogPost, error) {
-	ret := _mock.Called(page, limit)
+// CreateReply provides a mock function for the type MocksService
+func (_mock *MocksService) CreateReply(blogPostID string, parentCommentID string, text string) (string, error) {
+	ret := _mock.Called(blogPostID, parentCommentID, text)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllBlogPosts")
+		panic("no return value specified for CreateReply")
 	}
 
-	var r0 []BlogPost
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, int) ([]BlogPost, error)); ok {
-		return returnFunc(page, limit)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
+		return returnFunc(blogPostID, parentCommentID, text)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int) []BlogPost); ok {
-		r0 = returnFunc(page, limit)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = returnFunc(blogPostID, parentCommentID, text)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]BlogPost)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = returnFunc(page, limit)
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = returnFunc(blogPostID, parentCommentID, text)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MocksService_GetAllBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBlogPosts'
-type MocksService_GetAllBlogPosts_Call struct {
+// MocksService_CreateReply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReply'
+type MocksService_CreateReply_Call struct {
 	*mock.Call
 }
 
-// GetAllBlogPosts is a helper method to define mock.On call
-//   - page int
-//   - limit int
-func (_e *MocksService_Expecter) GetAllBlogPosts(page interface{}, limit interface{}) *MocksService_GetAllBlogPosts_Call {
-	return &MocksService_GetAllBlogPosts_Call{Call: _e.mock.On("GetAllBlogPosts", page, limit)}
+// CreateReply is a helper method to define mock.On call
+//   - blogPostID string
+//   - parentCommentID string
+//   - text string
+func (_e *MocksService_Expecter) CreateReply(blogPostID interface{}, parentCommentID interface{}, text interface{}) *MocksService_CreateReply_Call {
+	return &MocksService_CreateReply_Call{Call: _e.mock.On("CreateReply", blogPostID, parentCommentID, text)}
 }
 
-func (_c *MocksService_GetAllBlogPosts_Call) Run(run func(page int, limit int)) *MocksService_GetAllBlogPosts_Call {
+func (_c *MocksService_CreateReply_Call) Run(run func(blogPostID string, parentCommentID string, text string)) *MocksService_CreateReply_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(string)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MocksService_GetAllBlogPosts_Call) Return(blogPosts []BlogPost, err error) *MocksService_GetAllBlogPosts_Call {
-	_c.Call.Return(blogPosts, err)
+func (_c *MocksService_CreateReply_Call) Return(s string, err error) *MocksService_CreateReply_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MocksService_GetAllBlogPosts_Call) RunAndReturn(run func(page int, limit int) ([]BlogPost, error)) *MocksService_GetAllBlogPosts_Call {
+func (_c *MocksService_CreateReply_Call) RunAndReturn(run func(blogPostID string, parentCommentID string, text string) (string, error)) *MocksService_CreateReply_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBlogPost provides a mock function for the type MocksService
-func (_mock *MocksService) GetBlogPost(id string) (*BlogPost, error) {
+// DeleteBlogPost provides a mock function for the type MocksService
+func (_mock *MocksService) DeleteBlogPost(id string) error {
 	ret := _mock.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBlogPost")
+		panic("no return value specified for DeleteBlogPost")
 	}
 
-	var r0 *BlogPost
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*BlogPost, error)); ok {
-		return returnFunc(id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) *BlogPost); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
 		r0 = returnFunc(id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*BlogPost)
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_DeleteBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBlogPost'
+type MocksService_DeleteBlogPost_Call struct {
+	*mock.Call
+}
+
+// DeleteBlogPost is a helper method to define mock.On call
+//   - id string
+func (_e *MocksService_Expecter) DeleteBlogPost(id interface{}) *MocksService_DeleteBlogPost_Call {
+	return &MocksService_DeleteBlogPost_Call{Call: _e.mock.On("DeleteBlogPost", id)}
+}
+
+func (_c *MocksService_DeleteBlogPost_Call) Run(run func(id string)) *MocksService_DeleteBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_DeleteBlogPost_Call) Return(err error) *MocksService_DeleteBlogPost_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_DeleteBlogPost_Call) RunAndReturn(run func(id string) error) *MocksService_DeleteBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteComment provides a mock function for the type MocksService
+func (_mock *MocksService) DeleteComment(blogPostID string, commentID string) error {
+	ret := _mock.Called(blogPostID, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(blogPostID, commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type MocksService_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - blogPostID string
+//   - commentID string
+func (_e *MocksService_Expecter) DeleteComment(blogPostID interface{}, commentID interface{}) *MocksService_DeleteComment_Call {
+	return &MocksService_DeleteComment_Call{Call: _e.mock.On("DeleteComment", blogPostID, commentID)}
+}
+
+func (_c *MocksService_DeleteComment_Call) Run(run func(blogPostID string, commentID string)) *MocksService_DeleteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_DeleteComment_Call) Return(err error) *MocksService_DeleteComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_DeleteComment_Call) RunAndReturn(run func(blogPostID string, commentID string) error) *MocksService_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiffRevisions provides a mock function for the type MocksService
+func (_mock *MocksService) DiffRevisions(blogPostID string, from int, to int) (string, error) {
+	ret := _mock.Called(blogPostID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffRevisions")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) (string, error)); ok {
+		return returnFunc(blogPostID, from, to)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) string); ok {
+		r0 = returnFunc(blogPostID, from, to)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = returnFunc(blogPostID, from, to)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MocksService_GetBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlogPost'
-type MocksService_GetBlogPost_Call struct {
+// MocksService_DiffRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRevisions'
+type MocksService_DiffRevisions_Call struct {
 	*mock.Call
 }
 
-// GetBlogPost is a helper method to define mock.On call
-//   - id string
-func (_e *MocksService_Expecter) GetBlogPost(id interface{}) *MocksService_GetBlogPost_Call {
-	return &MocksService_GetBlogPost_Call{Call: _e.mock.On("GetBlogPost", id)}
+// DiffRevisions is a helper method to define mock.On call
+//   - blogPostID string
+//   - from int
+//   - to int
+func (_e *MocksService_Expecter) DiffRevisions(blogPostID interface{}, from interface{}, to interface{}) *MocksService_DiffRevisions_Call {
+	return &MocksService_DiffRevisions_Call{Call: _e.mock.On("DiffRevisions", blogPostID, from, to)}
 }
 
-func (_c *MocksService_GetBlogPost_Call) Run(run func(id string)) *MocksService_GetBlogPost_Call {
+func (_c *MocksService_DiffRevisions_Call) Run(run func(blogPostID string, from int, to int)) *MocksService_DiffRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MocksService_GetBlogPost_Call) Return(blogPost *BlogPost, err error) *MocksService_GetBlogPost_Call {
-	_c.Call.Return(blogPost, err)
+func (_c *MocksService_DiffRevisions_Call) Return(s string, err error) *MocksService_DiffRevisions_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MocksService_DiffRevisions_Call) RunAndReturn(run func(blogPostID string, from int, to int) (string, error)) *MocksService_DiffRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportContent provides a mock function for the type MocksService
+func (_mock *MocksService) ExportContent(fn func(ExportRecord) error) error {
+	ret := _mock.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportContent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(func(ExportRecord) error) error); ok {
+		r0 = returnFunc(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_ExportContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportContent'
+type MocksService_ExportContent_Call struct {
+	*mock.Call
+}
+
+// ExportContent is a helper method to define mock.On call
+//   - fn func(ExportRecord) error
+func (_e *MocksService_Expecter) ExportContent(fn interface{}) *MocksService_ExportContent_Call {
+	return &MocksService_ExportContent_Call{Call: _e.mock.On("ExportContent", fn)}
+}
+
+func (_c *MocksService_ExportContent_Call) Run(run func(fn func(ExportRecord) error)) *MocksService_ExportContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(ExportRecord) error
+		if args[0] != nil {
+			arg0 = args[0].(func(ExportRecord) error)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_ExportContent_Call) Return(err error) *MocksService_ExportContent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_ExportContent_Call) RunAndReturn(run func(fn func(ExportRecord) error) error) *MocksService_ExportContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllBlogPosts provides a mock function for the type MocksService
+func (_mock *MocksService) GetAllBlogPosts(page int, limit int, opts ReadOptions) ([]BlogPost, error) {
+	ret := _mock.Called(page, limit, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBlogPosts")
+	}
+
+	var r0 []BlogPost
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ReadOptions) ([]BlogPost, error)); ok {
+		return returnFunc(page, limit, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ReadOptions) []BlogPost); ok {
+		r0 = returnFunc(page, limit, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BlogPost)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ReadOptions) error); ok {
+		r1 = returnFunc(page, limit, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetAllBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBlogPosts'
+type MocksService_GetAllBlogPosts_Call struct {
+	*mock.Call
+}
+
+// GetAllBlogPosts is a helper method to define mock.On call
+//   - page int
+//   - limit int
+//   - opts ReadOptions
+func (_e *MocksService_Expecter) GetAllBlogPosts(page interface{}, limit interface{}, opts interface{}) *MocksService_GetAllBlogPosts_Call {
+	return &MocksService_GetAllBlogPosts_Call{Call: _e.mock.On("GetAllBlogPosts", page, limit, opts)}
+}
+
+func (_c *MocksService_GetAllBlogPosts_Call) Run(run func(page int, limit int, opts ReadOptions)) *MocksService_GetAllBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 ReadOptions
+		if args[2] != nil {
+			arg2 = args[2].(ReadOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetAllBlogPosts_Call) Return(blogPosts []BlogPost, err error) *MocksService_GetAllBlogPosts_Call {
+	_c.Call.Return(blogPosts, err)
+	return _c
+}
+
+func (_c *MocksService_GetAllBlogPosts_Call) RunAndReturn(run func(page int, limit int, opts ReadOptions) ([]BlogPost, error)) *MocksService_GetAllBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlogPost provides a mock function for the type MocksService
+func (_mock *MocksService) GetBlogPost(id string, opts ReadOptions) (*BlogPost, error) {
+	ret := _mock.Called(id, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlogPost")
+	}
+
+	var r0 *BlogPost
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, ReadOptions) (*BlogPost, error)); ok {
+		return returnFunc(id, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, ReadOptions) *BlogPost); ok {
+		r0 = returnFunc(id, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BlogPost)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, ReadOptions) error); ok {
+		r1 = returnFunc(id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlogPost'
+type MocksService_GetBlogPost_Call struct {
+	*mock.Call
+}
+
+// GetBlogPost is a helper method to define mock.On call
+//   - id string
+//   - opts ReadOptions
+func (_e *MocksService_Expecter) GetBlogPost(id interface{}, opts interface{}) *MocksService_GetBlogPost_Call {
+	return &MocksService_GetBlogPost_Call{Call: _e.mock.On("GetBlogPost", id, opts)}
+}
+
+func (_c *MocksService_GetBlogPost_Call) Run(run func(id string, opts ReadOptions)) *MocksService_GetBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 ReadOptions
+		if args[1] != nil {
+			arg1 = args[1].(ReadOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetBlogPost_Call) Return(blogPost *BlogPost, err error) *MocksService_GetBlogPost_Call {
+	_c.Call.Return(blogPost, err)
+	return _c
+}
+
+func (_c *MocksService_GetBlogPost_Call) RunAndReturn(run func(id string, opts ReadOptions) (*BlogPost, error)) *MocksService_GetBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetComments provides a mock function for the type MocksService
+func (_mock *MocksService) GetComments(blogPostID string, query CommentsQuery) ([]Comment, error) {
+	ret := _mock.Called(blogPostID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, CommentsQuery) ([]Comment, error)); ok {
+		return returnFunc(blogPostID, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, CommentsQuery) []Comment); ok {
+		r0 = returnFunc(blogPostID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, CommentsQuery) error); ok {
+		r1 = returnFunc(blogPostID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComments'
+type MocksService_GetComments_Call struct {
+	*mock.Call
+}
+
+// GetComments is a helper method to define mock.On call
+//   - blogPostID string
+//   - query CommentsQuery
+func (_e *MocksService_Expecter) GetComments(blogPostID interface{}, query interface{}) *MocksService_GetComments_Call {
+	return &MocksService_GetComments_Call{Call: _e.mock.On("GetComments", blogPostID, query)}
+}
+
+func (_c *MocksService_GetComments_Call) Run(run func(blogPostID string, query CommentsQuery)) *MocksService_GetComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 CommentsQuery
+		if args[1] != nil {
+			arg1 = args[1].(CommentsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetComments_Call) Return(comments []Comment, err error) *MocksService_GetComments_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksService_GetComments_Call) RunAndReturn(run func(blogPostID string, query CommentsQuery) ([]Comment, error)) *MocksService_GetComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentsAfter provides a mock function for the type MocksService
+func (_mock *MocksService) GetCommentsAfter(blogPostID string, commentID string, limit int) ([]Comment, error) {
+	ret := _mock.Called(blogPostID, commentID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentsAfter")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, int) ([]Comment, error)); ok {
+		return returnFunc(blogPostID, commentID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, int) []Comment); ok {
+		r0 = returnFunc(blogPostID, commentID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = returnFunc(blogPostID, commentID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetCommentsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentsAfter'
+type MocksService_GetCommentsAfter_Call struct {
+	*mock.Call
+}
+
+// GetCommentsAfter is a helper method to define mock.On call
+//   - blogPostID string
+//   - commentID string
+//   - limit int
+func (_e *MocksService_Expecter) GetCommentsAfter(blogPostID interface{}, commentID interface{}, limit interface{}) *MocksService_GetCommentsAfter_Call {
+	return &MocksService_GetCommentsAfter_Call{Call: _e.mock.On("GetCommentsAfter", blogPostID, commentID, limit)}
+}
+
+func (_c *MocksService_GetCommentsAfter_Call) Run(run func(blogPostID string, commentID string, limit int)) *MocksService_GetCommentsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetCommentsAfter_Call) Return(comments []Comment, err error) *MocksService_GetCommentsAfter_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksService_GetCommentsAfter_Call) RunAndReturn(run func(blogPostID string, commentID string, limit int) ([]Comment, error)) *MocksService_GetCommentsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentsByBlogPosts provides a mock function for the type MocksService
+func (_mock *MocksService) GetCommentsByBlogPosts(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	ret := _mock.Called(blogPostIDs, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentsByBlogPosts")
+	}
+
+	var r0 map[string][]Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) (map[string][]Comment, error)); ok {
+		return returnFunc(blogPostIDs, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) map[string][]Comment); ok {
+		r0 = returnFunc(blogPostIDs, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string, CommentsQuery) error); ok {
+		r1 = returnFunc(blogPostIDs, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetCommentsByBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentsByBlogPosts'
+type MocksService_GetCommentsByBlogPosts_Call struct {
+	*mock.Call
+}
+
+// GetCommentsByBlogPosts is a helper method to define mock.On call
+//   - blogPostIDs []string
+//   - query CommentsQuery
+func (_e *MocksService_Expecter) GetCommentsByBlogPosts(blogPostIDs interface{}, query interface{}) *MocksService_GetCommentsByBlogPosts_Call {
+	return &MocksService_GetCommentsByBlogPosts_Call{Call: _e.mock.On("GetCommentsByBlogPosts", blogPostIDs, query)}
+}
+
+func (_c *MocksService_GetCommentsByBlogPosts_Call) Run(run func(blogPostIDs []string, query CommentsQuery)) *MocksService_GetCommentsByBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		var arg1 CommentsQuery
+		if args[1] != nil {
+			arg1 = args[1].(CommentsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetCommentsByBlogPosts_Call) Return(m map[string][]Comment, err error) *MocksService_GetCommentsByBlogPosts_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MocksService_GetCommentsByBlogPosts_Call) RunAndReturn(run func(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error)) *MocksService_GetCommentsByBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentsByModerationStatus provides a mock function for the type MocksService
+func (_mock *MocksService) GetCommentsByModerationStatus(status ModerationStatus, limit int, offset int) ([]Comment, error) {
+	ret := _mock.Called(status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentsByModerationStatus")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(ModerationStatus, int, int) ([]Comment, error)); ok {
+		return returnFunc(status, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(ModerationStatus, int, int) []Comment); ok {
+		r0 = returnFunc(status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(ModerationStatus, int, int) error); ok {
+		r1 = returnFunc(status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetCommentsByModerationStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentsByModerationStatus'
+type MocksService_GetCommentsByModerationStatus_Call struct {
+	*mock.Call
+}
+
+// GetCommentsByModerationStatus is a helper method to define mock.On call
+//   - status ModerationStatus
+//   - limit int
+//   - offset int
+func (_e *MocksService_Expecter) GetCommentsByModerationStatus(status interface{}, limit interface{}, offset interface{}) *MocksService_GetCommentsByModerationStatus_Call {
+	return &MocksService_GetCommentsByModerationStatus_Call{Call: _e.mock.On("GetCommentsByModerationStatus", status, limit, offset)}
+}
+
+func (_c *MocksService_GetCommentsByModerationStatus_Call) Run(run func(status ModerationStatus, limit int, offset int)) *MocksService_GetCommentsByModerationStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 ModerationStatus
+		if args[0] != nil {
+			arg0 = args[0].(ModerationStatus)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetCommentsByModerationStatus_Call) Return(comments []Comment, err error) *MocksService_GetCommentsByModerationStatus_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksService_GetCommentsByModerationStatus_Call) RunAndReturn(run func(status ModerationStatus, limit int, offset int) ([]Comment, error)) *MocksService_GetCommentsByModerationStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedBlogPosts provides a mock function for the type MocksService
+func (_mock *MocksService) GetDeletedBlogPosts(limit int, offset int) ([]BlogPost, error) {
+	ret := _mock.Called(limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedBlogPosts")
+	}
+
+	var r0 []BlogPost
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]BlogPost, error)); ok {
+		return returnFunc(limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []BlogPost); ok {
+		r0 = returnFunc(limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BlogPost)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetDeletedBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedBlogPosts'
+type MocksService_GetDeletedBlogPosts_Call struct {
+	*mock.Call
+}
+
+// GetDeletedBlogPosts is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+func (_e *MocksService_Expecter) GetDeletedBlogPosts(limit interface{}, offset interface{}) *MocksService_GetDeletedBlogPosts_Call {
+	return &MocksService_GetDeletedBlogPosts_Call{Call: _e.mock.On("GetDeletedBlogPosts", limit, offset)}
+}
+
+func (_c *MocksService_GetDeletedBlogPosts_Call) Run(run func(limit int, offset int)) *MocksService_GetDeletedBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetDeletedBlogPosts_Call) Return(blogPosts []BlogPost, err error) *MocksService_GetDeletedBlogPosts_Call {
+	_c.Call.Return(blogPosts, err)
+	return _c
+}
+
+func (_c *MocksService_GetDeletedBlogPosts_Call) RunAndReturn(run func(limit int, offset int) ([]BlogPost, error)) *MocksService_GetDeletedBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedComments provides a mock function for the type MocksService
+func (_mock *MocksService) GetDeletedComments(limit int, offset int) ([]Comment, error) {
+	ret := _mock.Called(limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedComments")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]Comment, error)); ok {
+		return returnFunc(limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []Comment); ok {
+		r0 = returnFunc(limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetDeletedComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedComments'
+type MocksService_GetDeletedComments_Call struct {
+	*mock.Call
+}
+
+// GetDeletedComments is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+func (_e *MocksService_Expecter) GetDeletedComments(limit interface{}, offset interface{}) *MocksService_GetDeletedComments_Call {
+	return &MocksService_GetDeletedComments_Call{Call: _e.mock.On("GetDeletedComments", limit, offset)}
+}
+
+func (_c *MocksService_GetDeletedComments_Call) Run(run func(limit int, offset int)) *MocksService_GetDeletedComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetDeletedComments_Call) Return(comments []Comment, err error) *MocksService_GetDeletedComments_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MocksService_GetDeletedComments_Call) RunAndReturn(run func(limit int, offset int) ([]Comment, error)) *MocksService_GetDeletedComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepliesByComments provides a mock function for the type MocksService
+func (_mock *MocksService) GetRepliesByComments(commentIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	ret := _mock.Called(commentIDs, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepliesByComments")
+	}
+
+	var r0 map[string][]Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) (map[string][]Comment, error)); ok {
+		return returnFunc(commentIDs, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string, CommentsQuery) map[string][]Comment); ok {
+		r0 = returnFunc(commentIDs, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string, CommentsQuery) error); ok {
+		r1 = returnFunc(commentIDs, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetRepliesByComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepliesByComments'
+type MocksService_GetRepliesByComments_Call struct {
+	*mock.Call
+}
+
+// GetRepliesByComments is a helper method to define mock.On call
+//   - commentIDs []string
+//   - query CommentsQuery
+func (_e *MocksService_Expecter) GetRepliesByComments(commentIDs interface{}, query interface{}) *MocksService_GetRepliesByComments_Call {
+	return &MocksService_GetRepliesByComments_Call{Call: _e.mock.On("GetRepliesByComments", commentIDs, query)}
+}
+
+func (_c *MocksService_GetRepliesByComments_Call) Run(run func(commentIDs []string, query CommentsQuery)) *MocksService_GetRepliesByComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		var arg1 CommentsQuery
+		if args[1] != nil {
+			arg1 = args[1].(CommentsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetRepliesByComments_Call) Return(m map[string][]Comment, err error) *MocksService_GetRepliesByComments_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MocksService_GetRepliesByComments_Call) RunAndReturn(run func(commentIDs []string, query CommentsQuery) (map[string][]Comment, error)) *MocksService_GetRepliesByComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevision provides a mock function for the type MocksService
+func (_mock *MocksService) GetRevision(blogPostID string, number int) (*Revision, error) {
+	ret := _mock.Called(blogPostID, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int) (*Revision, error)); ok {
+		return returnFunc(blogPostID, number)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int) *Revision); ok {
+		r0 = returnFunc(blogPostID, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = returnFunc(blogPostID, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevision'
+type MocksService_GetRevision_Call struct {
+	*mock.Call
+}
+
+// GetRevision is a helper method to define mock.On call
+//   - blogPostID string
+//   - number int
+func (_e *MocksService_Expecter) GetRevision(blogPostID interface{}, number interface{}) *MocksService_GetRevision_Call {
+	return &MocksService_GetRevision_Call{Call: _e.mock.On("GetRevision", blogPostID, number)}
+}
+
+func (_c *MocksService_GetRevision_Call) Run(run func(blogPostID string, number int)) *MocksService_GetRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetRevision_Call) Return(revision *Revision, err error) *MocksService_GetRevision_Call {
+	_c.Call.Return(revision, err)
+	return _c
+}
+
+func (_c *MocksService_GetRevision_Call) RunAndReturn(run func(blogPostID string, number int) (*Revision, error)) *MocksService_GetRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevisions provides a mock function for the type MocksService
+func (_mock *MocksService) GetRevisions(blogPostID string, limit int, offset int) ([]Revision, error) {
+	ret := _mock.Called(blogPostID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) ([]Revision, error)); ok {
+		return returnFunc(blogPostID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) []Revision); ok {
+		r0 = returnFunc(blogPostID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = returnFunc(blogPostID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisions'
+type MocksService_GetRevisions_Call struct {
+	*mock.Call
+}
+
+// GetRevisions is a helper method to define mock.On call
+//   - blogPostID string
+//   - limit int
+//   - offset int
+func (_e *MocksService_Expecter) GetRevisions(blogPostID interface{}, limit interface{}, offset interface{}) *MocksService_GetRevisions_Call {
+	return &MocksService_GetRevisions_Call{Call: _e.mock.On("GetRevisions", blogPostID, limit, offset)}
+}
+
+func (_c *MocksService_GetRevisions_Call) Run(run func(blogPostID string, limit int, offset int)) *MocksService_GetRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetRevisions_Call) Return(revisions []Revision, err error) *MocksService_GetRevisions_Call {
+	_c.Call.Return(revisions, err)
+	return _c
+}
+
+func (_c *MocksService_GetRevisions_Call) RunAndReturn(run func(blogPostID string, limit int, offset int) ([]Revision, error)) *MocksService_GetRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTags provides a mock function for the type MocksService
+func (_mock *MocksService) GetTags(opts ReadOptions) ([]Tag, error) {
+	ret := _mock.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(ReadOptions) ([]Tag, error)); ok {
+		return returnFunc(opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(ReadOptions) []Tag); ok {
+		r0 = returnFunc(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(ReadOptions) error); ok {
+		r1 = returnFunc(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
+type MocksService_GetTags_Call struct {
+	*mock.Call
+}
+
+// GetTags is a helper method to define mock.On call
+//   - opts ReadOptions
+func (_e *MocksService_Expecter) GetTags(opts interface{}) *MocksService_GetTags_Call {
+	return &MocksService_GetTags_Call{Call: _e.mock.On("GetTags", opts)}
+}
+
+func (_c *MocksService_GetTags_Call) Run(run func(opts ReadOptions)) *MocksService_GetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 ReadOptions
+		if args[0] != nil {
+			arg0 = args[0].(ReadOptions)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_GetTags_Call) Return(tags []Tag, err error) *MocksService_GetTags_Call {
+	_c.Call.Return(tags, err)
+	return _c
+}
+
+func (_c *MocksService_GetTags_Call) RunAndReturn(run func(opts ReadOptions) ([]Tag, error)) *MocksService_GetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportContent provides a mock function for the type MocksService
+func (_mock *MocksService) ImportContent(r io.Reader, dryRun bool) (*ImportResult, error) {
+	ret := _mock.Called(r, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportContent")
+	}
+
+	var r0 *ImportResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, bool) (*ImportResult, error)); ok {
+		return returnFunc(r, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, bool) *ImportResult); ok {
+		r0 = returnFunc(r, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ImportResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(io.Reader, bool) error); ok {
+		r1 = returnFunc(r, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_ImportContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportContent'
+type MocksService_ImportContent_Call struct {
+	*mock.Call
+}
+
+// ImportContent is a helper method to define mock.On call
+//   - r io.Reader
+//   - dryRun bool
+func (_e *MocksService_Expecter) ImportContent(r interface{}, dryRun interface{}) *MocksService_ImportContent_Call {
+	return &MocksService_ImportContent_Call{Call: _e.mock.On("ImportContent", r, dryRun)}
+}
+
+func (_c *MocksService_ImportContent_Call) Run(run func(r io.Reader, dryRun bool)) *MocksService_ImportContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 io.Reader
+		if args[0] != nil {
+			arg0 = args[0].(io.Reader)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_ImportContent_Call) Return(importResult *ImportResult, err error) *MocksService_ImportContent_Call {
+	_c.Call.Return(importResult, err)
+	return _c
+}
+
+func (_c *MocksService_ImportContent_Call) RunAndReturn(run func(r io.Reader, dryRun bool) (*ImportResult, error)) *MocksService_ImportContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModerateComments provides a mock function for the type MocksService
+func (_mock *MocksService) ModerateComments(ids []string, status ModerationStatus) (int64, error) {
+	ret := _mock.Called(ids, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModerateComments")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string, ModerationStatus) (int64, error)); ok {
+		return returnFunc(ids, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string, ModerationStatus) int64); ok {
+		r0 = returnFunc(ids, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string, ModerationStatus) error); ok {
+		r1 = returnFunc(ids, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_ModerateComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModerateComments'
+type MocksService_ModerateComments_Call struct {
+	*mock.Call
+}
+
+// ModerateComments is a helper method to define mock.On call
+//   - ids []string
+//   - status ModerationStatus
+func (_e *MocksService_Expecter) ModerateComments(ids interface{}, status interface{}) *MocksService_ModerateComments_Call {
+	return &MocksService_ModerateComments_Call{Call: _e.mock.On("ModerateComments", ids, status)}
+}
+
+func (_c *MocksService_ModerateComments_Call) Run(run func(ids []string, status ModerationStatus)) *MocksService_ModerateComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		var arg1 ModerationStatus
+		if args[1] != nil {
+			arg1 = args[1].(ModerationStatus)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_ModerateComments_Call) Return(n int64, err error) *MocksService_ModerateComments_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksService_ModerateComments_Call) RunAndReturn(run func(ids []string, status ModerationStatus) (int64, error)) *MocksService_ModerateComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishBlogPost provides a mock function for the type MocksService
+func (_mock *MocksService) PublishBlogPost(id string, publishAt *time.Time) error {
+	ret := _mock.Called(id, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishBlogPost")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, *time.Time) error); ok {
+		r0 = returnFunc(id, publishAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_PublishBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishBlogPost'
+type MocksService_PublishBlogPost_Call struct {
+	*mock.Call
+}
+
+// PublishBlogPost is a helper method to define mock.On call
+//   - id string
+//   - publishAt *time.Time
+func (_e *MocksService_Expecter) PublishBlogPost(id interface{}, publishAt interface{}) *MocksService_PublishBlogPost_Call {
+	return &MocksService_PublishBlogPost_Call{Call: _e.mock.On("PublishBlogPost", id, publishAt)}
+}
+
+func (_c *MocksService_PublishBlogPost_Call) Run(run func(id string, publishAt *time.Time)) *MocksService_PublishBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *time.Time
+		if args[1] != nil {
+			arg1 = args[1].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_PublishBlogPost_Call) Return(err error) *MocksService_PublishBlogPost_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_PublishBlogPost_Call) RunAndReturn(run func(id string, publishAt *time.Time) error) *MocksService_PublishBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishDueBlogPosts provides a mock function for the type MocksService
+func (_mock *MocksService) PublishDueBlogPosts(now time.Time) (int64, error) {
+	ret := _mock.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishDueBlogPosts")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return returnFunc(now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = returnFunc(now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = returnFunc(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_PublishDueBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishDueBlogPosts'
+type MocksService_PublishDueBlogPosts_Call struct {
+	*mock.Call
+}
+
+// PublishDueBlogPosts is a helper method to define mock.On call
+//   - now time.Time
+func (_e *MocksService_Expecter) PublishDueBlogPosts(now interface{}) *MocksService_PublishDueBlogPosts_Call {
+	return &MocksService_PublishDueBlogPosts_Call{Call: _e.mock.On("PublishDueBlogPosts", now)}
+}
+
+func (_c *MocksService_PublishDueBlogPosts_Call) Run(run func(now time.Time)) *MocksService_PublishDueBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_PublishDueBlogPosts_Call) Return(n int64, err error) *MocksService_PublishDueBlogPosts_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksService_PublishDueBlogPosts_Call) RunAndReturn(run func(now time.Time) (int64, error)) *MocksService_PublishDueBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrash provides a mock function for the type MocksService
+func (_mock *MocksService) PurgeTrash(now time.Time) (int64, error) {
+	ret := _mock.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return returnFunc(now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = returnFunc(now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = returnFunc(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_PurgeTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrash'
+type MocksService_PurgeTrash_Call struct {
+	*mock.Call
+}
+
+// PurgeTrash is a helper method to define mock.On call
+//   - now time.Time
+func (_e *MocksService_Expecter) PurgeTrash(now interface{}) *MocksService_PurgeTrash_Call {
+	return &MocksService_PurgeTrash_Call{Call: _e.mock.On("PurgeTrash", now)}
+}
+
+func (_c *MocksService_PurgeTrash_Call) Run(run func(now time.Time)) *MocksService_PurgeTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_PurgeTrash_Call) Return(n int64, err error) *MocksService_PurgeTrash_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksService_PurgeTrash_Call) RunAndReturn(run func(now time.Time) (int64, error)) *MocksService_PurgeTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReindexBlogPosts provides a mock function for the type MocksService
+func (_mock *MocksService) ReindexBlogPosts() (int64, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexBlogPosts")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (int64, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() int64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MocksService_ReindexBlogPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexBlogPosts'
+type MocksService_ReindexBlogPosts_Call struct {
+	*mock.Call
+}
+
+// ReindexBlogPosts is a helper method to define mock.On call
+func (_e *MocksService_Expecter) ReindexBlogPosts() *MocksService_ReindexBlogPosts_Call {
+	return &MocksService_ReindexBlogPosts_Call{Call: _e.mock.On("ReindexBlogPosts")}
+}
+
+func (_c *MocksService_ReindexBlogPosts_Call) Run(run func()) *MocksService_ReindexBlogPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MocksService_ReindexBlogPosts_Call) Return(n int64, err error) *MocksService_ReindexBlogPosts_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MocksService_ReindexBlogPosts_Call) RunAndReturn(run func() (int64, error)) *MocksService_ReindexBlogPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreBlogPost provides a mock function for the type MocksService
+func (_mock *MocksService) RestoreBlogPost(id string) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBlogPost")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_RestoreBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBlogPost'
+type MocksService_RestoreBlogPost_Call struct {
+	*mock.Call
+}
+
+// RestoreBlogPost is a helper method to define mock.On call
+//   - id string
+func (_e *MocksService_Expecter) RestoreBlogPost(id interface{}) *MocksService_RestoreBlogPost_Call {
+	return &MocksService_RestoreBlogPost_Call{Call: _e.mock.On("RestoreBlogPost", id)}
+}
+
+func (_c *MocksService_RestoreBlogPost_Call) Run(run func(id string)) *MocksService_RestoreBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_RestoreBlogPost_Call) Return(err error) *MocksService_RestoreBlogPost_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_RestoreBlogPost_Call) RunAndReturn(run func(id string) error) *MocksService_RestoreBlogPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreComment provides a mock function for the type MocksService
+func (_mock *MocksService) RestoreComment(commentID string) error {
+	ret := _mock.Called(commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_RestoreComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreComment'
+type MocksService_RestoreComment_Call struct {
+	*mock.Call
+}
+
+// RestoreComment is a helper method to define mock.On call
+//   - commentID string
+func (_e *MocksService_Expecter) RestoreComment(commentID interface{}) *MocksService_RestoreComment_Call {
+	return &MocksService_RestoreComment_Call{Call: _e.mock.On("RestoreComment", commentID)}
+}
+
+func (_c *MocksService_RestoreComment_Call) Run(run func(commentID string)) *MocksService_RestoreComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_RestoreComment_Call) Return(err error) *MocksService_RestoreComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_RestoreComment_Call) RunAndReturn(run func(commentID string) error) *MocksService_RestoreComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreRevision provides a mock function for the type MocksService
+func (_mock *MocksService) RestoreRevision(blogPostID string, number int) error {
+	ret := _mock.Called(blogPostID, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRevision")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = returnFunc(blogPostID, number)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_RestoreRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreRevision'
+type MocksService_RestoreRevision_Call struct {
+	*mock.Call
+}
+
+// RestoreRevision is a helper method to define mock.On call
+//   - blogPostID string
+//   - number int
+func (_e *MocksService_Expecter) RestoreRevision(blogPostID interface{}, number interface{}) *MocksService_RestoreRevision_Call {
+	return &MocksService_RestoreRevision_Call{Call: _e.mock.On("RestoreRevision", blogPostID, number)}
+}
+
+func (_c *MocksService_RestoreRevision_Call) Run(run func(blogPostID string, number int)) *MocksService_RestoreRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_RestoreRevision_Call) Return(err error) *MocksService_RestoreRevision_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MocksService_RestoreRevision_Call) RunAndReturn(run func(blogPostID string, number int) error) *MocksService_RestoreRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBlogPost provides a mock function for the type MocksService
+func (_mock *MocksService) UpdateBlogPost(id string, request UpdatePostRequest) error {
+	ret := _mock.Called(id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBlogPost")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, UpdatePostRequest) error); ok {
+		r0 = returnFunc(id, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MocksService_UpdateBlogPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBlogPost'
+type MocksService_UpdateBlogPost_Call struct {
+	*mock.Call
+}
+
+// UpdateBlogPost is a helper method to define mock.On call
+//   - id string
+//   - request UpdatePostRequest
+func (_e *MocksService_Expecter) UpdateBlogPost(id interface{}, request interface{}) *MocksService_UpdateBlogPost_Call {
+	return &MocksService_UpdateBlogPost_Call{Call: _e.mock.On("UpdateBlogPost", id, request)}
+}
+
+func (_c *MocksService_UpdateBlogPost_Call) Run(run func(id string, request UpdatePostRequest)) *MocksService_UpdateBlogPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 UpdatePostRequest
+		if args[1] != nil {
+			arg1 = args[1].(UpdatePostRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MocksService_UpdateBlogPost_Call) Return(err error) *MocksService_UpdateBlogPost_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MocksService_GetBlogPost_Call) RunAndReturn(run func(id string) (*BlogPost, error)) *MocksService_GetBlogPost_Call {
+func (_c *MocksService_UpdateBlogPost_Call) RunAndReturn(run func(id string, request UpdatePostRequest) error) *MocksService_UpdateBlogPost_Call {
 	_c.Call.Return(run)
 	return _c
 }
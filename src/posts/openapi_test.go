@@ -0,0 +1,555 @@
+package posts
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	// Every NDJSON line must match the schema, which describes a single line.
+	openapi3filter.RegisterBodyDecoder("application/x-ndjson",
+		func(body io.Reader, _ http.Header, schema *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (any, error) {
+			var first any
+			scanner := bufio.NewScanner(body)
+			for scanner.Scan() {
+				var line any
+				if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+					return nil, err
+				}
+				if err := schema.Value.VisitJSON(line); err != nil {
+					return nil, err
+				}
+				if first == nil {
+					first = line
+				}
+			}
+			return first, scanner.Err()
+		})
+}
+
+// specRouter Returns a router finding the operations of the OpenAPI document served by the API.
+// The document is not validated as a whole, since kin-openapi only validates OpenAPI 3.0 documents, but loading it
+// resolves every reference, and schemas are checked as requests and responses are validated against them.
+func specRouter(t *testing.T) routers.Router {
+	t.Helper()
+
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.Spec)
+	if err != nil {
+		t.Fatalf("failed to load OpenAPI document: %v", err)
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("failed to route OpenAPI document: %v", err)
+	}
+	return router
+}
+
+// Test_httpAdapter_OpenAPI Checks requests and responses of every handler against the OpenAPI document, so that
+// neither can change without the other. Fixtures set every field, for undocumented ones to be noticed.
+func Test_httpAdapter_OpenAPI(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	comment := Comment{
+		ID:               "c1",
+		BlogPostID:       "1",
+		CommentText:      "Nice post",
+		ModerationStatus: ModerationApproved,
+		ReplyCount:       1,
+		CreatedAt:        now,
+	}
+	reply := Comment{
+		ID:               "c2",
+		BlogPostID:       "1",
+		CommentText:      "Thanks",
+		ModerationStatus: ModerationApproved,
+		ParentID:         "c1",
+		Depth:            1,
+		CreatedAt:        now,
+	}
+	post := BlogPost{
+		ID:                "1",
+		Slug:              "first-post",
+		Title:             "First Post",
+		Content:           "Body",
+		Author:            "ana",
+		ContentFormat:     ContentFormatMarkdown,
+		ContentHTML:       "<p>Body</p>",
+		Excerpt:           "Body",
+		CommentCount:      2,
+		Status:            StatusPublished,
+		PublishAt:         &now,
+		Tags:              []string{"go"},
+		CommentModeration: ModerationPending,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		Comments:          []Comment{comment, reply},
+	}
+	deletedPost := post
+	deletedPost.DeletedAt = &now
+	deletedPost.Comments = nil
+	deletedComment := comment
+	deletedComment.DeletedAt = &now
+
+	tests := []struct {
+		name       string
+		setup      func(s *MocksService)
+		handler    func(a *httpAdapter) http.HandlerFunc
+		method     string
+		target     string
+		body       string
+		admin      bool
+		wantStatus int
+	}{
+		{
+			name: "get_posts_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetAllBlogPosts(10, 0, mock.Anything).Return([]BlogPost{post}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetAllPosts },
+			method:     http.MethodGet,
+			target:     "/api/posts?include=comments&tag=go&tag_match=all&has_comments=true",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get_posts_fields_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetAllBlogPosts(2, 2, mock.Anything).Return([]BlogPost{post}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetAllPosts },
+			method:     http.MethodGet,
+			target:     "/api/posts?page=2&limit=2&fields=id,title&view=summary",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "get_posts_400",
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetAllPosts },
+			method:     http.MethodGet,
+			target:     "/api/posts?created_after=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "get_post_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetBlogPost("first-post", mock.Anything).Return(&post, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetPost },
+			method:     http.MethodGet,
+			target:     "/api/posts/first-post",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get_post_301",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetBlogPost("old-slug", mock.Anything).Return(&post, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetPost },
+			method:     http.MethodGet,
+			target:     "/api/posts/old-slug",
+			wantStatus: http.StatusMovedPermanently,
+		},
+		{
+			name: "get_post_404",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetBlogPost("nope", mock.Anything).Return(nil, ErrBlogPostNotFound)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetPost },
+			method:     http.MethodGet,
+			target:     "/api/posts/nope",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "create_post_201",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateBlogPost(mock.Anything).Return("1", nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.CreatePost },
+			method:     http.MethodPost,
+			target:     "/api/posts",
+			body:       `{"title":"t","content":"c","status":"scheduled","publish_at":"2030-05-01T00:00:00Z","tags":["go"],"content_format":"markdown"}`,
+			admin:      true,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "create_post_422",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateBlogPost(mock.Anything).Return("", &ContentRejectedError{Reasons: []string{"blocked word"}})
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.CreatePost },
+			method:     http.MethodPost,
+			target:     "/api/posts",
+			body:       `{"title":"t","content":"casino"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "create_posts_207",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateBlogPosts(mock.Anything, false).
+					Return([]BatchResult{{ID: "1"}, {Err: fmt.Errorf("%w: missing title", ErrorBadRequest)}}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.CreatePosts },
+			method:     http.MethodPost,
+			target:     "/api/posts:batch",
+			body:       `{"posts":[{"title":"t","content":"c"},{"title":"","content":"c"}],"mode":"best_effort"}`,
+			admin:      true,
+			wantStatus: http.StatusMultiStatus,
+		},
+		{
+			name: "create_posts_atomic_422",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateBlogPosts(mock.Anything, true).
+					Return(nil, &BatchItemError{Index: 1, Err: &ContentRejectedError{Reasons: []string{"blocked word"}}})
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.CreatePosts },
+			method:     http.MethodPost,
+			target:     "/api/posts:batch",
+			body:       `{"posts":[{"title":"t","content":"c"},{"title":"t","content":"casino"}]}`,
+			admin:      true,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "update_post_204",
+			setup: func(s *MocksService) {
+				s.EXPECT().UpdateBlogPost("1", UpdatePostRequest{Title: "t", Content: "c"}).Return(nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.UpdatePost },
+			method:     http.MethodPut,
+			target:     "/api/posts/1",
+			body:       `{"title":"t","content":"c"}`,
+			admin:      true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "get_revisions_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetRevisions("1", 10, 0).Return([]Revision{{Number: 1, Title: "t", Content: "c", ContentFormat: ContentFormatText, CreatedAt: now}}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetRevisions },
+			method:     http.MethodGet,
+			target:     "/api/posts/1/revisions",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get_revision_404",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetRevision("1", 9).Return(nil, ErrRevisionNotFound)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetRevision },
+			method:     http.MethodGet,
+			target:     "/api/posts/1/revisions/9",
+			admin:      true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "diff_revisions_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().DiffRevisions("1", 1, 2).Return("--- a\n+++ b\n", nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.DiffRevisions },
+			method:     http.MethodGet,
+			target:     "/api/posts/1/revisions/diff?from=1&to=2",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "restore_revision_204",
+			setup: func(s *MocksService) {
+				s.EXPECT().RestoreRevision("1", 1).Return(nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.RestoreRevision },
+			method:     http.MethodPost,
+			target:     "/api/posts/1/revisions/1/restore",
+			admin:      true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "get_tags_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetTags(ReadOptions{}).Return([]Tag{{Slug: "go", PostCount: 2}}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetTags },
+			method:     http.MethodGet,
+			target:     "/api/tags",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "create_comment_201",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateComment("1", "hi").Return("c1", nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.CreateComment },
+			method:     http.MethodPost,
+			target:     "/api/posts/1/comments",
+			body:       `{"text":"hi"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "get_comments_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetComments("1", mock.Anything).Return([]Comment{comment, reply}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetComments },
+			method:     http.MethodGet,
+			target:     "/api/posts/1/comments?max_depth=2",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get_replies_flat_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetComments("1", mock.Anything).Return([]Comment{reply}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetComments },
+			method:     http.MethodGet,
+			target:     "/api/posts/1/comments/c1/replies?format=flat",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "create_reply_409",
+			setup: func(s *MocksService) {
+				s.EXPECT().CreateReply("1", "c1", "hi").Return("", ErrParentCommentDeleted)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.CreateReply },
+			method:     http.MethodPost,
+			target:     "/api/posts/1/comments/c1/replies",
+			body:       `{"text":"hi"}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "get_moderation_queue_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetCommentsByModerationStatus(ModerationPending, 10, 0).Return([]Comment{comment}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetModerationQueue },
+			method:     http.MethodGet,
+			target:     "/api/admin/comments",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "moderate_comments_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().ModerateComments([]string{"c1"}, ModerationSpam).Return(1, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.ModerateComments },
+			method:     http.MethodPost,
+			target:     "/api/admin/comments/moderation",
+			body:       `{"comment_ids":["c1"],"status":"spam"}`,
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "publish_post_409",
+			setup: func(s *MocksService) {
+				s.EXPECT().PublishBlogPost("1", mock.Anything).Return(ErrInvalidStatusTransition)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.PublishPost },
+			method:     http.MethodPost,
+			target:     "/api/posts/1/publish",
+			body:       `{"publish_at":"2026-05-01T00:00:00Z"}`,
+			admin:      true,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "archive_post_204",
+			setup: func(s *MocksService) {
+				s.EXPECT().ArchiveBlogPost("1").Return(nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.ArchivePost },
+			method:     http.MethodPost,
+			target:     "/api/posts/1/archive",
+			admin:      true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "delete_post_204",
+			setup: func(s *MocksService) {
+				s.EXPECT().DeleteBlogPost("1").Return(nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.DeletePost },
+			method:     http.MethodDelete,
+			target:     "/api/posts/1",
+			admin:      true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "delete_comment_404",
+			setup: func(s *MocksService) {
+				s.EXPECT().DeleteComment("1", "c9").Return(ErrCommentNotFound)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.DeleteComment },
+			method:     http.MethodDelete,
+			target:     "/api/posts/1/comments/c9",
+			admin:      true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "get_deleted_posts_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetDeletedBlogPosts(10, 0).Return([]BlogPost{deletedPost}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetDeletedPosts },
+			method:     http.MethodGet,
+			target:     "/api/admin/trash/posts",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get_deleted_comments_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetDeletedComments(10, 0).Return([]Comment{deletedComment}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetDeletedComments },
+			method:     http.MethodGet,
+			target:     "/api/admin/trash/comments",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "restore_post_204",
+			setup: func(s *MocksService) {
+				s.EXPECT().RestoreBlogPost("1").Return(nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.RestorePost },
+			method:     http.MethodPost,
+			target:     "/api/admin/trash/posts/1/restore",
+			admin:      true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "restore_comment_409",
+			setup: func(s *MocksService) {
+				s.EXPECT().RestoreComment("c2").Return(ErrParentCommentDeleted)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.RestoreComment },
+			method:     http.MethodPost,
+			target:     "/api/admin/trash/comments/c2/restore",
+			admin:      true,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "purge_trash_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().PurgeTrash(mock.Anything).Return(3, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.PurgeTrash },
+			method:     http.MethodDelete,
+			target:     "/api/admin/trash",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "export_content_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().ExportContent(mock.Anything).RunAndReturn(func(fn func(ExportRecord) error) error {
+					exported := post
+					exported.Comments = nil
+					if err := fn(ExportRecord{Type: RecordPost, Post: &exported}); err != nil {
+						return err
+					}
+					return fn(ExportRecord{Type: RecordComment, Comment: &reply})
+				})
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.ExportContent },
+			method:     http.MethodGet,
+			target:     "/api/admin/export",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "import_content_200",
+			setup: func(s *MocksService) {
+				s.EXPECT().ImportContent(mock.Anything, true).
+					Return(&ImportResult{DryRun: true, Posts: 1, Errors: []ImportError{{Line: 2, Message: "bad"}}}, nil)
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.ImportContent },
+			method:     http.MethodPost,
+			target:     "/api/admin/import?dry_run=true",
+			body:       `{"type":"post","post":{"id":"1","title":"t","content":"c"}}` + "\n",
+			admin:      true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "unexpected_error_500",
+			setup: func(s *MocksService) {
+				s.EXPECT().GetTags(ReadOptions{}).Return(nil, errors.New("boom"))
+			},
+			handler:    func(a *httpAdapter) http.HandlerFunc { return a.GetTags },
+			method:     http.MethodGet,
+			target:     "/api/tags",
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	router := specRouter(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMocksService(t)
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+			adapter := &httpAdapter{Service: service}
+
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				contentType := "application/json"
+				if strings.HasSuffix(tt.body, "\n") {
+					contentType = "application/x-ndjson"
+				}
+				request.Header.Set("Content-Type", contentType)
+			}
+
+			route, pathParams, err := router.FindRoute(request)
+			if err != nil {
+				t.Fatalf("route not documented: %v", err)
+			}
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    request,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+					IncludeResponseStatus: true,
+					MultiError:            true,
+				},
+			}
+			if err := openapi3filter.ValidateRequest(t.Context(), requestInput); err != nil {
+				t.Fatalf("request does not match the OpenAPI document: %v", err)
+			}
+
+			if tt.admin {
+				request = adminRequest(request)
+			}
+			recorder := httptest.NewRecorder()
+			tt.handler(adapter)(recorder, withURLParams(request, pathParams))
+			response := recorder.Result()
+
+			if response.StatusCode != tt.wantStatus {
+				t.Errorf("got status %d, want %d", response.StatusCode, tt.wantStatus)
+			}
+			responseInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: requestInput,
+				Status:                 response.StatusCode,
+				Header:                 response.Header,
+				Options:                requestInput.Options,
+			}
+			responseInput.SetBodyBytes(recorder.Body.Bytes())
+			if err := openapi3filter.ValidateResponse(t.Context(), responseInput); err != nil {
+				t.Errorf("response does not match the OpenAPI document: %v\n%s", err, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package posts
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sink Named event publisher outbox events are dispatched to.
+type Sink struct {
+	Name      string
+	Publisher EventPublisher
+}
+
+// DispatcherConfig Outbox dispatcher configuration.
+type DispatcherConfig struct {
+	// BatchSize Maximum events dispatched by a single Dispatch call.
+	BatchSize int
+	// Backoff Wait before the first retry of an event, doubled on every following retry.
+	Backoff time.Duration
+	// MaxBackoff Longest wait between retries of an event.
+	MaxBackoff time.Duration
+	// Retention How long dispatched events are kept before being pruned.
+	Retention time.Duration
+}
+
+// Dispatcher Publishes outbox events to every sink, at least once and in order within every blog post.
+type Dispatcher struct {
+	Outbox Outbox
+	Sinks  []Sink
+	Config DispatcherConfig
+}
+
+// NewDispatcher Returns new outbox dispatcher publishing events of outbox to sinks.
+func NewDispatcher(outbox Outbox, sinks []Sink, cfg DispatcherConfig) (*Dispatcher, error) {
+	if cfg.BatchSize < 1 {
+		return nil, fmt.Errorf("invalid dispatch batch size (%d)", cfg.BatchSize)
+	}
+	if cfg.Backoff <= 0 || cfg.MaxBackoff < cfg.Backoff {
+		return nil, fmt.Errorf("invalid dispatch backoff (%s up to %s)", cfg.Backoff, cfg.MaxBackoff)
+	}
+	if cfg.Retention <= 0 {
+		return nil, fmt.Errorf("invalid dispatched events retention (%s)", cfg.Retention)
+	}
+
+	return &Dispatcher{
+		Outbox: outbox,
+		Sinks:  sinks,
+		Config: cfg,
+	}, nil
+}
+
+// Dispatch Publishes outbox events due at provided time to every sink and returns how many were published.
+// Events failing in any sink are retried with exponential backoff in every sink, and hold back the
+// later events of their blog post until they are published.
+func (d *Dispatcher) Dispatch(now time.Time) (int, error) {
+	events, err := d.Outbox.GetPendingEvents(now, d.Config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	failed := map[string]bool{}
+	for _, event := range events {
+		if failed[event.AggregateID] {
+			continue
+		}
+
+		if err := d.publish(event); err != nil {
+			failed[event.AggregateID] = true
+			retryAt := now.Add(d.backoff(event.Attempts + 1))
+			if err := d.Outbox.MarkEventFailed(event.ID, err.Error(), retryAt); err != nil {
+				return dispatched, err
+			}
+			continue
+		}
+
+		if err := d.Outbox.MarkEventDispatched(event.ID, now); err != nil {
+			return dispatched, err
+		}
+		dispatched++
+	}
+
+	return dispatched, nil
+}
+
+// Prune Deletes events dispatched longer than the retention window before provided time, and returns how many were
+// deleted. Events not dispatched yet are kept however old they are.
+func (d *Dispatcher) Prune(now time.Time) (int64, error) {
+	return d.Outbox.PruneDispatchedEvents(now.Add(-d.Config.Retention))
+}
+
+// publish Publishes event to every sink, joining the errors of the failed ones.
+func (d *Dispatcher) publish(event Event) error {
+	var errs []error
+	for _, sink := range d.Sinks {
+		if err := sink.Publisher.Publish(event); err != nil {
+			errs = append(errs, fmt.Errorf("%s sink: %w", sink.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backoff Returns the wait before retrying an event after its attempts, doubling from Backoff up to MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.Config.Backoff
+	for range attempts - 1 {
+		wait *= 2
+		if wait >= d.Config.MaxBackoff {
+			return d.Config.MaxBackoff
+		}
+	}
+	return wait
+}
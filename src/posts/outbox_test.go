@@ -0,0 +1,142 @@
+package posts
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testDispatcherConfig = DispatcherConfig{BatchSize: 10, Backoff: time.Second, MaxBackoff: time.Minute, Retention: time.Hour}
+
+func TestNewDispatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     DispatcherConfig
+		wantErr bool
+	}{
+		{name: "valid", cfg: testDispatcherConfig},
+		{name: "missing_batch_size", cfg: DispatcherConfig{Backoff: time.Second, MaxBackoff: time.Minute}, wantErr: true},
+		{name: "max_backoff_below_backoff", cfg: DispatcherConfig{BatchSize: 10, Backoff: time.Minute, MaxBackoff: time.Second, Retention: time.Hour}, wantErr: true},
+		{name: "missing_retention", cfg: DispatcherConfig{BatchSize: 10, Backoff: time.Second, MaxBackoff: time.Minute}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDispatcher(NewMocksOutbox(t), nil, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewDispatcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	postA := Event{ID: "1", Type: EventPostCreated, AggregateID: "a"}
+	commentA := Event{ID: "2", Type: EventCommentCreated, AggregateID: "a"}
+	postB := Event{ID: "3", Type: EventPostCreated, AggregateID: "b", Attempts: 2}
+	tests := []struct {
+		name    string
+		setup   func(o *MocksOutbox, webhook, bus *MocksEventPublisher)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "published_to_every_sink",
+			setup: func(o *MocksOutbox, webhook, bus *MocksEventPublisher) {
+				o.EXPECT().GetPendingEvents(now, 10).Return([]Event{postA, commentA}, nil)
+				webhook.EXPECT().Publish(postA).Return(nil).Once()
+				bus.EXPECT().Publish(postA).Return(nil).Once()
+				o.EXPECT().MarkEventDispatched("1", now).Return(nil)
+				webhook.EXPECT().Publish(commentA).Return(nil).Once()
+				bus.EXPECT().Publish(commentA).Return(nil).Once()
+				o.EXPECT().MarkEventDispatched("2", now).Return(nil)
+			},
+			want: 2,
+		},
+		{
+			name: "failure_holds_back_aggregate_only",
+			setup: func(o *MocksOutbox, webhook, bus *MocksEventPublisher) {
+				o.EXPECT().GetPendingEvents(now, 10).Return([]Event{postA, commentA, postB}, nil)
+				webhook.EXPECT().Publish(postA).Return(errors.New("down")).Once()
+				bus.EXPECT().Publish(postA).Return(nil).Once()
+				o.EXPECT().MarkEventFailed("1", "webhook sink: down", now.Add(time.Second)).Return(nil)
+				webhook.EXPECT().Publish(postB).Return(nil).Once()
+				bus.EXPECT().Publish(postB).Return(nil).Once()
+				o.EXPECT().MarkEventDispatched("3", now).Return(nil)
+			},
+			want: 1,
+		},
+		{
+			name: "retry_backs_off_exponentially",
+			setup: func(o *MocksOutbox, webhook, bus *MocksEventPublisher) {
+				o.EXPECT().GetPendingEvents(now, 10).Return([]Event{postB}, nil)
+				webhook.EXPECT().Publish(postB).Return(errors.New("down")).Once()
+				bus.EXPECT().Publish(postB).Return(errors.New("full")).Once()
+				o.EXPECT().MarkEventFailed("3", "webhook sink: down\nbus sink: full", now.Add(4*time.Second)).Return(nil)
+			},
+		},
+		{
+			name: "outbox_error",
+			setup: func(o *MocksOutbox, webhook, bus *MocksEventPublisher) {
+				o.EXPECT().GetPendingEvents(now, 10).Return(nil, errors.New("fail"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := NewMocksOutbox(t)
+			webhook := NewMocksEventPublisher(t)
+			bus := NewMocksEventPublisher(t)
+			tt.setup(outbox, webhook, bus)
+
+			d, err := NewDispatcher(outbox, []Sink{{Name: "webhook", Publisher: webhook}, {Name: "bus", Publisher: bus}}, testDispatcherConfig)
+			if err != nil {
+				t.Fatalf("NewDispatcher() error = %v", err)
+			}
+			got, err := d.Dispatch(now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Dispatch() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Prune(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	outbox := NewMocksOutbox(t)
+	outbox.EXPECT().PruneDispatchedEvents(now.Add(-time.Hour)).Return(3, nil)
+
+	d, err := NewDispatcher(outbox, nil, testDispatcherConfig)
+	if err != nil {
+		t.Fatalf("NewDispatcher() error = %v", err)
+	}
+	got, err := d.Prune(now)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if got != 3 {
+		t.Errorf("Prune() = %d, want 3", got)
+	}
+}
+
+func TestDispatcher_backoff(t *testing.T) {
+	d := &Dispatcher{Config: testDispatcherConfig}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 7, want: time.Minute},
+		{attempts: 1000, want: time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
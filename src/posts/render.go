@@ -0,0 +1,77 @@
+package posts
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+const (
+	// ExcerptLength Maximum characters of excerpts taken from the beginning of the content.
+	ExcerptLength = 200
+	// ExcerptMarker Marks the end of the excerpt in content, which is then used as is.
+	ExcerptMarker = "<!--more-->"
+)
+
+var (
+	// markdown CommonMark renderer with GitHub Flavored Markdown tables, strikethrough, autolinks and task lists.
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// htmlPolicy Sanitization policy for user generated HTML, keeping code block languages.
+	htmlPolicy = func() *bluemonday.Policy {
+		policy := bluemonday.UGCPolicy()
+		policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+		policy.AllowAttrs("checked", "disabled", "type").OnElements("input")
+		return policy
+	}()
+
+	// textPolicy Sanitization policy stripping every HTML tag.
+	textPolicy = bluemonday.StrictPolicy()
+)
+
+// RenderContent Renders blog post content written in provided format as sanitized HTML.
+func RenderContent(format ContentFormat, content string) (string, error) {
+	switch format {
+	case ContentFormatText:
+		return "<p>" + html.EscapeString(content) + "</p>", nil
+	case ContentFormatMarkdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(content), &buf); err != nil {
+			return "", fmt.Errorf("failed to render markdown: %w", err)
+		}
+		return htmlPolicy.Sanitize(buf.String()), nil
+	}
+	return "", fmt.Errorf("%w: invalid content format (%s)", ErrorBadRequest, format)
+}
+
+// Excerpt Returns the plain text teaser of blog post content written in provided format.
+// Content up to ExcerptMarker is used when marked, otherwise its first ExcerptLength characters cut at a word boundary.
+func Excerpt(format ContentFormat, content string) (string, error) {
+	summary, _, marked := strings.Cut(content, ExcerptMarker)
+
+	if format == ContentFormatMarkdown {
+		rendered, err := RenderContent(format, summary)
+		if err != nil {
+			return "", err
+		}
+		summary = html.UnescapeString(textPolicy.Sanitize(rendered))
+	}
+	summary = strings.Join(strings.Fields(summary), " ")
+
+	if marked || utf8.RuneCountInString(summary) <= ExcerptLength {
+		return summary, nil
+	}
+
+	cut := string([]rune(summary)[:ExcerptLength])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " .,;:") + "…", nil
+}
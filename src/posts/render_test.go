@@ -0,0 +1,117 @@
+package posts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  ContentFormat
+		content string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "text_escaped",
+			format:  ContentFormatText,
+			content: `<b>"Hi" & bye</b>`,
+			want:    "<p>&lt;b&gt;&#34;Hi&#34; &amp; bye&lt;/b&gt;</p>",
+		},
+		{
+			name:    "markdown_emphasis",
+			format:  ContentFormatMarkdown,
+			content: "Some *emphasis* and **strong** text",
+			want:    "<p>Some <em>emphasis</em> and <strong>strong</strong> text</p>\n",
+		},
+		{
+			name:    "markdown_table",
+			format:  ContentFormatMarkdown,
+			content: "| a | b |\n|---|---|\n| 1 | 2 |",
+			want:    "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n<td>2</td>\n</tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name:    "markdown_code_block_language_kept",
+			format:  ContentFormatMarkdown,
+			content: "```go\nfmt.Println(\"<hi>\")\n```",
+			want:    "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>\n",
+		},
+		{
+			name:    "markdown_raw_html_dropped",
+			format:  ContentFormatMarkdown,
+			content: "Hi <script>alert(1)</script> <img src=x onerror=alert(1)>",
+			want:    "<p>Hi alert(1) </p>\n",
+		},
+		{
+			name:    "markdown_unsafe_links_removed",
+			format:  ContentFormatMarkdown,
+			content: "[click](javascript:alert(1)) [home](https://example.com)",
+			want:    "<p>click <a href=\"https://example.com\" rel=\"nofollow\">home</a></p>\n",
+		},
+		{
+			name:    "invalid_format",
+			format:  "html",
+			content: "<p>hi</p>",
+			wantErr: ErrorBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderContent(tt.format, tt.content)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenderContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	long := strings.Repeat("word ", 50)
+	tests := []struct {
+		name    string
+		format  ContentFormat
+		content string
+		want    string
+	}{
+		{
+			name:    "short_text_kept",
+			format:  ContentFormatText,
+			content: "Short  post\nbody",
+			want:    "Short post body",
+		},
+		{
+			name:    "long_text_cut_at_word",
+			format:  ContentFormatText,
+			content: long,
+			want:    strings.TrimSpace(strings.Repeat("word ", 40)) + "…",
+		},
+		{
+			name:    "marker_used_as_is",
+			format:  ContentFormatText,
+			content: "Intro." + ExcerptMarker + "Rest of the post",
+			want:    "Intro.",
+		},
+		{
+			name:    "markdown_stripped",
+			format:  ContentFormatMarkdown,
+			content: "# Title\n\nSome *emphasis* & [a link](https://example.com)",
+			want:    "Title Some emphasis & a link",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Excerpt(tt.format, tt.content)
+			if err != nil {
+				t.Fatalf("Excerpt() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Excerpt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
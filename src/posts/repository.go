@@ -2,15 +2,29 @@ package posts
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"maps"
 	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 var (
 	// ErrBlogPostNotFound Blog post not found error.
 	ErrBlogPostNotFound = errors.New("blog post not found")
+	// ErrCommentNotFound Comment not found error.
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrRevisionNotFound Blog post revision not found error.
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrParentCommentDeleted Comment cannot be restored while its parent is in trash.
+	ErrParentCommentDeleted = errors.New("parent comment is in trash")
+	// ErrInvalidStatusTransition Blog post status cannot be changed to the requested one.
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
 )
 
 // repository Simple productive repository pointing to sqlite db.
@@ -18,6 +32,11 @@ type repository struct {
 	db *sql.DB
 }
 
+// querier Runs queries either on the database or within a transaction.
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
 // NewRepository Returns new productive repository implementation.
 func NewRepository(db *sql.DB) (Repository, error) {
 	return &repository{db: db}, nil
@@ -25,48 +44,176 @@ func NewRepository(db *sql.DB) (Repository, error) {
 
 // blogPostComment Internal struct to flatten blogpost-comment relationship.
 type blogPostComment struct {
-	BlogPostID      string
-	BlogPostTitle   string
-	BlogPostContent string
-	CommentID       sql.NullString
-	CommentText     sql.NullString
+	BlogPostID         string
+	BlogPostSlug       sql.NullString
+	BlogPostTitle      string
+	BlogPostContent    string
+	BlogPostAuthor     sql.NullString
+	BlogPostCreated    sql.NullTime
+	BlogPostUpdated    sql.NullTime
+	BlogPostFormat     string
+	BlogPostHTML       sql.NullString
+	BlogPostExcerpt    sql.NullString
+	BlogPostComments   int
+	BlogPostStatus     string
+	BlogPostPublish    sql.NullTime
+	BlogPostModeration sql.NullString
+	CommentID          sql.NullString
+	CommentText        sql.NullString
+	CommentParentID    sql.NullString
+	CommentModeration  sql.NullString
+	CommentCreated     sql.NullTime
 }
 
 // readBlogPosts Internal reusable function that retrieves blog posts and comments.
-// If `id` is non-empty, it fetches a single post. If not, it fetches all (optionally paginated).
-func (r *repository) readBlogPosts(id string, limit, offset int) ([]BlogPost, error) {
-	query := `
-		SELECT 
-			a.id, 
-			a.title,
-			a.content,
-			c.id,
-			c.comment_text
-		FROM blog_posts a
-			LEFT JOIN blog_posts_comments b
-				ON a.id = b.blog_post_id
-			LEFT JOIN comments c
-				ON b.comment_id = c.id
-	`
-	args := []any{}
+// If `id` is non-empty, it fetches a single post by public ID or slug. If not, it fetches all (optionally paginated).
+// Unless opts says otherwise, only published posts and approved comments are returned, and
+// comments are not even queried. Posts and comments in trash are never returned.
+func readBlogPosts(q querier, id string, limit, offset int, opts ReadOptions) ([]BlogPost, error) {
+	// Posts are filtered and paginated before joining comments, so pagination applies to posts only.
+	var where whereClause
+	where.add("deleted_at IS NULL")
 
 	if id != "" {
-		query += " WHERE a.id = ?"
-		args = append(args, id)
+		where.add("(public_id = ? OR id = (SELECT blog_post_id FROM blog_post_slugs WHERE slug = ?))", id, id)
+	}
+	if !opts.IncludeUnpublished {
+		where.add("status = ?", StatusPublished)
+	}
+	if len(opts.Tags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(opts.Tags)), ",")
+		tagsQuery := `
+			id IN (
+				SELECT bt.blog_post_id
+				FROM blog_posts_tags bt
+					JOIN tags t
+						ON t.id = bt.tag_id
+				WHERE t.slug IN (` + placeholders + `)`
+		tagsArgs := []any{}
+		for _, tag := range opts.Tags {
+			tagsArgs = append(tagsArgs, tag)
+		}
+		if opts.MatchAllTags {
+			tagsQuery += `
+				GROUP BY bt.blog_post_id
+				HAVING COUNT(DISTINCT bt.tag_id) = ?`
+			tagsArgs = append(tagsArgs, len(opts.Tags))
+		}
+		where.add(tagsQuery+")", tagsArgs...)
 	}
+	where.addListFilter(opts.Filter, opts.IncludeUnapproved)
+
+	postsQuery := `
+		SELECT
+			id,
+			public_id,
+			slug,
+			title,
+			content,
+			author,
+			created_at,
+			content_format,
+			content_html,
+			excerpt,
+			status,
+			publish_at,
+			comment_moderation
+		FROM blog_posts
+	` + where.String()
+	args := where.args
 
-	query += " ORDER BY a.id"
+	postsOrder, orderBy := "id", "a.id"
+	if opts.NewestFirst {
+		postsOrder, orderBy = "COALESCE(publish_at, created_at) DESC, id DESC", "COALESCE(a.publish_at, a.created_at) DESC, a.id DESC"
+	}
+	postsQuery += " ORDER BY " + postsOrder
 
 	if limit > 0 {
-		query += " LIMIT ?"
+		postsQuery += " LIMIT ?"
 		args = append(args, limit)
 	}
 	if offset > 0 {
-		query += " OFFSET ?"
+		if limit <= 0 {
+			postsQuery += " LIMIT -1"
+		}
+		postsQuery += " OFFSET ?"
 		args = append(args, offset)
 	}
 
-	rows, err := r.db.Query(query, args...)
+	// Comment counts are queried even when comments are not.
+	args = append(args, opts.IncludeUnapproved, ModerationApproved)
+
+	commentsColumns := "NULL, NULL, NULL, NULL, NULL"
+	commentsJoin := ""
+	if opts.IncludeComments {
+		// Comments are numbered from the latest one per post, so the latest ones can be picked.
+		commentsColumns = "c.public_id, c.comment_text, p.public_id, c.moderation_status, c.created_at"
+		commentsJoin = `
+			LEFT JOIN (
+				SELECT
+					b.blog_post_id,
+					c.id,
+					c.public_id,
+					c.comment_text,
+					c.parent_comment_id,
+					c.moderation_status,
+					c.created_at,
+					ROW_NUMBER() OVER (PARTITION BY b.blog_post_id ORDER BY c.id DESC) AS recency
+				FROM blog_posts_comments b
+					JOIN comments c
+						ON c.id = b.comment_id
+				WHERE b.blog_post_id IN (SELECT id FROM page)
+					AND c.deleted_at IS NULL
+					AND (? OR c.moderation_status = ?)
+			) c
+				ON c.blog_post_id = a.id
+				AND (? <= 0 OR c.recency <= ?)
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id`
+		orderBy += ", c.id"
+		args = append(args, opts.IncludeUnapproved, ModerationApproved, opts.CommentsLimit, opts.CommentsLimit)
+	}
+
+	query := `
+		WITH page AS (` + postsQuery + `)
+		SELECT
+			a.public_id,
+			a.slug,
+			a.title,
+			a.content,
+			a.author,
+			a.created_at,
+			r.created_at,
+			a.content_format,
+			a.content_html,
+			a.excerpt,
+			(
+				SELECT COUNT(*)
+				FROM blog_posts_comments b
+					JOIN comments c
+						ON c.id = b.comment_id
+				WHERE b.blog_post_id = a.id
+					AND c.deleted_at IS NULL
+					AND (? OR c.moderation_status = ?)
+			),
+			a.status,
+			a.publish_at,
+			a.comment_moderation,
+			` + commentsColumns + `
+		FROM page a
+			LEFT JOIN (
+				SELECT blog_post_id, MAX(revision) AS revision
+				FROM blog_post_revisions
+				WHERE blog_post_id IN (SELECT id FROM page)
+				GROUP BY blog_post_id
+			) l
+				ON l.blog_post_id = a.id
+			LEFT JOIN blog_post_revisions r
+				ON r.blog_post_id = l.blog_post_id
+				AND r.revision = l.revision` + commentsJoin + `
+		ORDER BY ` + orderBy
+
+	rows, err := q.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query blog posts: %w", err)
 	}
@@ -77,48 +224,183 @@ func (r *repository) readBlogPosts(id string, limit, offset int) ([]BlogPost, er
 		var i blogPostComment
 		if err := rows.Scan(
 			&i.BlogPostID,
+			&i.BlogPostSlug,
 			&i.BlogPostTitle,
 			&i.BlogPostContent,
+			&i.BlogPostAuthor,
+			&i.BlogPostCreated,
+			&i.BlogPostUpdated,
+			&i.BlogPostFormat,
+			&i.BlogPostHTML,
+			&i.BlogPostExcerpt,
+			&i.BlogPostComments,
+			&i.BlogPostStatus,
+			&i.BlogPostPublish,
+			&i.BlogPostModeration,
 			&i.CommentID,
 			&i.CommentText,
+			&i.CommentParentID,
+			&i.CommentModeration,
+			&i.CommentCreated,
 		); err != nil {
 			return nil, err
 		}
 		blogPostComments = append(blogPostComments, i)
 	}
 
-	res := map[string]BlogPost{}
+	res := []BlogPost{}
 	for _, item := range blogPostComments {
-		blogPost, exists := res[item.BlogPostID]
-		if !exists {
-			blogPost = BlogPost{
+		if len(res) == 0 || res[len(res)-1].ID != item.BlogPostID {
+			blogPost := BlogPost{
 				ID:      item.BlogPostID,
+				Slug:    item.BlogPostSlug.String,
 				Title:   item.BlogPostTitle,
 				Content: item.BlogPostContent,
+				Author:  item.BlogPostAuthor.String,
+				Status:  Status(item.BlogPostStatus),
+
+				ContentFormat: ContentFormat(item.BlogPostFormat),
+				ContentHTML:   item.BlogPostHTML.String,
+				Excerpt:       item.BlogPostExcerpt.String,
+				CommentCount:  item.BlogPostComments,
+
+				CommentModeration: ModerationStatus(item.BlogPostModeration.String),
+				CreatedAt:         item.BlogPostCreated.Time,
+				UpdatedAt:         item.BlogPostUpdated.Time,
+			}
+			if item.BlogPostPublish.Valid {
+				publishAt := item.BlogPostPublish.Time
+				blogPost.PublishAt = &publishAt
 			}
+			res = append(res, blogPost)
 		}
 
 		if item.CommentID.Valid {
+			blogPost := &res[len(res)-1]
 			blogPost.Comments = append(blogPost.Comments, Comment{
 				ID:          item.CommentID.String,
 				CommentText: item.CommentText.String,
+				ParentID:    item.CommentParentID.String,
+				CreatedAt:   item.CommentCreated.Time,
+
+				ModerationStatus: ModerationStatus(item.CommentModeration.String),
 			})
 		}
+	}
 
-		res[item.BlogPostID] = blogPost
+	if err := readBlogPostsTags(q, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// whereClause WHERE clause built out of parameterized conditions joined with AND.
+type whereClause struct {
+	conditions []string
+	args       []any
+}
+
+// likeEscaper Escapes LIKE wildcards so user input is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// add Adds a condition along with the args of its placeholders.
+func (w *whereClause) add(condition string, args ...any) {
+	w.conditions = append(w.conditions, condition)
+	w.args = append(w.args, args...)
+}
+
+// addListFilter Adds a condition for every criteria set in filter.
+// Comments are only counted when visible, approved ones unless includeUnapproved is set.
+func (w *whereClause) addListFilter(filter ListFilter, includeUnapproved bool) {
+	if filter.CreatedAfter != nil {
+		w.add("created_at >= ?", filter.CreatedAfter.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		w.add("created_at < ?", filter.CreatedBefore.UTC())
+	}
+	if filter.Author != "" {
+		w.add("author = ?", filter.Author)
+	}
+	if filter.TitleContains != "" {
+		w.add(`title LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(filter.TitleContains)+"%")
+	}
+	if filter.HasComments != nil {
+		w.add(`
+			EXISTS (
+				SELECT 1
+				FROM blog_posts_comments b
+					JOIN comments c
+						ON c.id = b.comment_id
+				WHERE b.blog_post_id = blog_posts.id
+					AND c.deleted_at IS NULL
+					AND (? OR c.moderation_status = ?)
+			) = ?`,
+			includeUnapproved, ModerationApproved, *filter.HasComments)
+	}
+	if filter.Status != "" {
+		w.add("status = ?", filter.Status)
+	}
+}
+
+// String Returns the WHERE clause, empty when there are no conditions.
+func (w *whereClause) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+// readBlogPostsTags Fills tags of provided blog posts with a single query.
+func readBlogPostsTags(q querier, blogPosts []BlogPost) error {
+	if len(blogPosts) == 0 {
+		return nil
+	}
+
+	index := map[string]*BlogPost{}
+	args := []any{}
+	for i := range blogPosts {
+		index[blogPosts[i].ID] = &blogPosts[i]
+		args = append(args, blogPosts[i].ID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
+
+	rows, err := q.Query(`
+		SELECT a.public_id, t.slug
+		FROM blog_posts_tags bt
+			JOIN blog_posts a
+				ON a.id = bt.blog_post_id
+			JOIN tags t
+				ON t.id = bt.tag_id
+		WHERE a.public_id IN (`+placeholders+`)
+		ORDER BY t.slug`,
+		args...)
+	if err != nil {
+		return fmt.Errorf("failed to query blog post tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blogPostID, slug string
+		if err := rows.Scan(&blogPostID, &slug); err != nil {
+			return err
+		}
+		if blogPost, ok := index[blogPostID]; ok {
+			blogPost.Tags = append(blogPost.Tags, slug)
+		}
 	}
 
-	return slices.Collect(maps.Values(res)), nil
+	return rows.Err()
 }
 
 // GetAllBlogPosts Returns all existing blog posts paginated.
-func (r *repository) GetAllBlogPosts(limit, offset int) ([]BlogPost, error) {
-	return r.readBlogPosts("", limit, offset)
+func (r *repository) GetAllBlogPosts(limit, offset int, opts ReadOptions) ([]BlogPost, error) {
+	return readBlogPosts(r.db, "", limit, offset, opts)
 }
 
 // GetBlogPost Returns a single blog post with its comments.
-func (r *repository) GetBlogPost(id string) (*BlogPost, error) {
-	posts, err := r.readBlogPosts(id, 0, 0)
+func (r *repository) GetBlogPost(id string, opts ReadOptions) (*BlogPost, error) {
+	posts, err := readBlogPosts(r.db, id, 0, 0, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -128,58 +410,1779 @@ func (r *repository) GetBlogPost(id string) (*BlogPost, error) {
 	return &posts[0], nil
 }
 
-// CreateBlogPost Creates a new blog post and returns its generated ID.
-func (r *repository) CreateBlogPost(title, content string) (int64, error) {
-	res, err := r.db.Exec(`
-		INSERT INTO blog_posts (title, content)
-		VALUES (?, ?)`,
-		title, content)
+// CreateBlogPost Creates a new blog post along with its tags and first revision, and returns its generated public ID.
+// Tags are created on first use, and the post slug is made unique by appending a numeric suffix when needed.
+func (r *repository) CreateBlogPost(post BlogPost) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	insert, err := prepareInsertBlogPost(tx)
+	if err != nil {
+		return "", err
+	}
+	defer insert.Close()
+
+	publicID, err := createBlogPost(tx, insert, post)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return publicID, nil
+}
+
+// CreateBlogPosts Creates many blog posts in a single transaction and returns the outcome of every one of them.
+// Atomic batches are rolled back as soon as a post fails, returning a BatchItemError,
+// otherwise every post is created within its own savepoint so failed posts are undone without undoing the others.
+func (r *repository) CreateBlogPosts(posts []BlogPost, atomic bool) ([]BatchResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	insert, err := prepareInsertBlogPost(tx)
+	if err != nil {
+		return nil, err
+	}
+	defer insert.Close()
+
+	results := make([]BatchResult, len(posts))
+	for i, post := range posts {
+		if atomic {
+			results[i].ID, err = createBlogPost(tx, insert, post)
+			if err != nil {
+				return nil, &BatchItemError{Index: i, Err: err}
+			}
+			continue
+		}
+
+		if _, err := tx.Exec("SAVEPOINT batch_item"); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		results[i].ID, results[i].Err = createBlogPost(tx, insert, post)
+		if results[i].Err != nil {
+			if _, err := tx.Exec("ROLLBACK TO batch_item"); err != nil {
+				return nil, fmt.Errorf("failed to roll back savepoint: %w", err)
+			}
+		}
+
+		if _, err := tx.Exec("RELEASE batch_item"); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return results, nil
+}
+
+// prepareInsertBlogPost Prepares the statement inserting blog posts within a transaction.
+func prepareInsertBlogPost(tx *sql.Tx) (*sql.Stmt, error) {
+	insert, err := tx.Prepare(`
+		INSERT INTO blog_posts (public_id, slug, title, content, author, content_format, content_html, excerpt, status, publish_at, comment_moderation, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare blog post insert: %w", err)
+	}
+	return insert, nil
+}
+
+// createBlogPost Inserts a blog post with a statement prepared by prepareInsertBlogPost, recording its creation
+// in the outbox, and returns its generated public ID.
+func createBlogPost(tx *sql.Tx, insert *sql.Stmt, post BlogPost) (string, error) {
+	if err := insertBlogPost(tx, insert, &post); err != nil {
+		return "", err
+	}
+
+	if err := addOutboxEvent(tx, EventPostCreated, post.ID, post); err != nil {
+		return "", err
+	}
+
+	return post.ID, nil
+}
+
+// insertBlogPost Inserts a blog post with a statement prepared by prepareInsertBlogPost, along with its tags and
+// first revision, and sets its generated public ID, stored slug and creation date. Posts without creation date
+// are created now.
+func insertBlogPost(tx *sql.Tx, insert *sql.Stmt, post *BlogPost) error {
+	slug, err := uniqueSlug(tx, post.Slug, nil)
+	if err != nil {
+		return err
+	}
+
+	publicID, err := newPublicID()
+	if err != nil {
+		return err
+	}
+
+	createdAt := post.CreatedAt.UTC()
+	if post.CreatedAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+
+	res, err := insert.Exec(
+		publicID, slug, post.Title, post.Content, nullString(post.Author), post.ContentFormat, post.ContentHTML, post.Excerpt, post.Status, post.PublishAt,
+		nullString(string(post.CommentModeration)), createdAt)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create blog post: %w", err)
+		return fmt.Errorf("failed to create blog post: %w", err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get inserted blog post ID: %w", err)
+		return fmt.Errorf("failed to get inserted blog post ID: %w", err)
+	}
+
+	err = addSlugHistory(tx, slug, id)
+	if err != nil {
+		return err
+	}
+
+	err = addRevision(tx, id, *post)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range post.Tags {
+		_, err = tx.Exec(`
+			INSERT INTO tags (slug)
+			VALUES (?)
+			ON CONFLICT (slug) DO NOTHING`,
+			tag)
+		if err != nil {
+			return fmt.Errorf("failed to create tag: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO blog_posts_tags (blog_post_id, tag_id)
+			SELECT ?, id FROM tags WHERE slug = ?`,
+			id, tag)
+		if err != nil {
+			return fmt.Errorf("failed to link tag: %w", err)
+		}
+	}
+
+	post.ID = publicID
+	post.Slug = slug
+	post.CreatedAt = createdAt
+	return nil
+}
+
+// UpdateBlogPost Updates title, content, rendered content and slug of a blog post, keeping its previous slug in history.
+// Every update is saved as a new revision.
+func (r *repository) UpdateBlogPost(post BlogPost) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, err := blogPostKey(tx, post.ID)
+	if err != nil {
+		return err
+	}
+
+	slug, err := uniqueSlug(tx, post.Slug, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE blog_posts
+		SET slug = ?, title = ?, content = ?, content_format = ?, content_html = ?, excerpt = ?
+		WHERE id = ?`,
+		slug, post.Title, post.Content, post.ContentFormat, post.ContentHTML, post.Excerpt, id)
+	if err != nil {
+		return fmt.Errorf("failed to update blog post: %w", err)
+	}
+
+	err = addSlugHistory(tx, slug, id)
+	if err != nil {
+		return err
+	}
+
+	err = addRevision(tx, id, post)
+	if err != nil {
+		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// newPublicID Returns a new opaque and time ordered public ID.
+func newPublicID() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate public ID: %w", err)
+	}
+	return id.String(), nil
+}
+
+// blogPostKey Returns the internal key of the blog post with provided public ID, unless it is in trash.
+func blogPostKey(tx *sql.Tx, publicID string) (int64, error) {
+	var id int64
+	err := tx.QueryRow(`SELECT id FROM blog_posts WHERE public_id = ? AND deleted_at IS NULL`, publicID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrBlogPostNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query blog post: %w", err)
+	}
 	return id, nil
 }
 
+// uniqueSlug Returns base, or base with the lowest numeric suffix, not used now or before by another blog post.
+// A nil blogPostID checks against every blog post.
+func uniqueSlug(tx *sql.Tx, base string, blogPostID any) (string, error) {
+	rows, err := tx.Query(`
+		SELECT slug
+		FROM blog_post_slugs
+		WHERE (slug = ? OR slug LIKE ? || '-%')
+			AND blog_post_id IS NOT ?`,
+		base, base, blogPostID)
+	if err != nil {
+		return "", fmt.Errorf("failed to query slugs: %w", err)
+	}
+	defer rows.Close()
+
+	taken := map[string]bool{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", err
+		}
+		taken[slug] = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	slug := base
+	for n := 2; taken[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug, nil
+}
+
+// addSlugHistory Records a slug as belonging to a blog post.
+func addSlugHistory(tx *sql.Tx, slug string, blogPostID any) error {
+	_, err := tx.Exec(`
+		INSERT INTO blog_post_slugs (slug, blog_post_id)
+		VALUES (?, ?)
+		ON CONFLICT (slug) DO NOTHING`,
+		slug, blogPostID)
+	if err != nil {
+		return fmt.Errorf("failed to record slug: %w", err)
+	}
+	return nil
+}
+
+// addRevision Saves current title and content of a blog post as its next revision.
+func addRevision(tx *sql.Tx, blogPostID int64, post BlogPost) error {
+	_, err := tx.Exec(`
+		INSERT INTO blog_post_revisions (blog_post_id, revision, title, content, content_format, created_at)
+		SELECT ?, COALESCE(MAX(revision), 0) + 1, ?, ?, ?, ?
+		FROM blog_post_revisions
+		WHERE blog_post_id = ?`,
+		blogPostID, post.Title, post.Content, post.ContentFormat, time.Now().UTC(), blogPostID)
+	if err != nil {
+		return fmt.Errorf("failed to save revision: %w", err)
+	}
+	return nil
+}
+
+// GetRevisions Returns content revisions of a blog post paginated, newest first.
+func (r *repository) GetRevisions(blogPostID string, limit, offset int) ([]Revision, error) {
+	rows, err := r.db.Query(`
+		SELECT v.revision, v.title, v.content, v.content_format, v.created_at
+		FROM blog_post_revisions v
+			JOIN blog_posts a
+				ON a.id = v.blog_post_id
+		WHERE a.public_id = ?
+		ORDER BY v.revision DESC
+		LIMIT ? OFFSET ?`,
+		blogPostID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []Revision{}
+	for rows.Next() {
+		var revision Revision
+		if err := rows.Scan(
+			&revision.Number,
+			&revision.Title,
+			&revision.Content,
+			&revision.ContentFormat,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// GetRevision Returns single content revision of a blog post.
+func (r *repository) GetRevision(blogPostID string, number int) (*Revision, error) {
+	var revision Revision
+	err := r.db.QueryRow(`
+		SELECT v.revision, v.title, v.content, v.content_format, v.created_at
+		FROM blog_post_revisions v
+			JOIN blog_posts a
+				ON a.id = v.blog_post_id
+		WHERE a.public_id = ? AND v.revision = ?`,
+		blogPostID, number).Scan(&revision.Number, &revision.Title, &revision.Content, &revision.ContentFormat, &revision.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revision: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// GetTags Returns every tag in use along with how many blog posts use it.
+func (r *repository) GetTags(opts ReadOptions) ([]Tag, error) {
+	rows, err := r.db.Query(`
+		SELECT t.slug, COUNT(a.id)
+		FROM tags t
+			JOIN blog_posts_tags bt
+				ON bt.tag_id = t.id
+			JOIN blog_posts a
+				ON a.id = bt.blog_post_id
+				AND a.deleted_at IS NULL
+				AND (? OR a.status = ?)
+		GROUP BY t.id
+		ORDER BY COUNT(a.id) DESC, t.slug`,
+		opts.IncludeUnpublished, StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []Tag{}
+	for rows.Next() {
+		var tag Tag
+		if err := rows.Scan(&tag.Slug, &tag.PostCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
 // CreateComment Creates a new comment and associates it with a blog post.
-func (r *repository) CreateComment(blogPostID, text string) (int64, error) {
+func (r *repository) CreateComment(blogPostID string, comment Comment) (string, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return 0, fmt.Errorf("failed to start tx: %w", err)
+		return "", fmt.Errorf("failed to start tx: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := insertComment(tx, blogPostID, &comment); err != nil {
+		return "", err
+	}
+
+	if err := addOutboxEvent(tx, EventCommentCreated, blogPostID, comment); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return comment.ID, nil
+}
+
+// insertComment Inserts a comment, replying to the comment with its parent public ID if any, associates it with
+// a blog post and sets its generated public ID, blog post and creation date. Comments without creation date are
+// created now.
+func insertComment(tx *sql.Tx, blogPostID string, comment *Comment) error {
+	postKey, err := blogPostKey(tx, blogPostID)
+	if err != nil {
+		return err
+	}
+
+	publicID, err := newPublicID()
+	if err != nil {
+		return err
+	}
+
+	createdAt := comment.CreatedAt.UTC()
+	if comment.CreatedAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+
 	// Insert comment
 	res, err := tx.Exec(`
-		INSERT INTO comments (comment_text)
-		VALUES (?)`,
-		text)
+		INSERT INTO comments (public_id, comment_text, parent_comment_id, moderation_status, created_at)
+		VALUES (?, ?, (SELECT id FROM comments WHERE public_id = ?), ?, ?)`,
+		publicID, comment.CommentText, nullString(comment.ParentID), comment.ModerationStatus, createdAt)
 	if err != nil {
-		return 0, fmt.Errorf("failed to insert comment: %w", err)
+		return fmt.Errorf("failed to insert comment: %w", err)
 	}
 
 	commentID, err := res.LastInsertId()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get comment ID: %w", err)
+		return fmt.Errorf("failed to get comment ID: %w", err)
 	}
 
 	// Associate with blog post
 	_, err = tx.Exec(`
 		INSERT INTO blog_posts_comments (blog_post_id, comment_id)
 		VALUES (?, ?)`,
-		blogPostID, commentID)
+		postKey, commentID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to link comment: %w", err)
+		return fmt.Errorf("failed to link comment: %w", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, fmt.Errorf("failed to commit tx: %w", err)
+	comment.ID = publicID
+	comment.BlogPostID = blogPostID
+	comment.CreatedAt = createdAt
+	return nil
+}
+
+// HasComment Reports whether a blog post already has a comment with provided text, ignoring case and surrounding spaces.
+func (r *repository) HasComment(blogPostID, text string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM comments c
+				JOIN blog_posts_comments b
+					ON b.comment_id = c.id
+				JOIN blog_posts a
+					ON a.id = b.blog_post_id
+			WHERE a.public_id = ? AND c.deleted_at IS NULL
+				AND lower(trim(c.comment_text)) = lower(trim(?))
+		)`,
+		blogPostID, text).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to query comment: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetComment Returns single comment of a blog post.
+func (r *repository) GetComment(blogPostID, commentID string) (*Comment, error) {
+	var (
+		comment  Comment
+		parentID sql.NullString
+	)
+	err := r.db.QueryRow(`
+		SELECT c.public_id, a.public_id, c.comment_text, p.public_id, c.moderation_status
+		FROM comments c
+			JOIN blog_posts_comments b
+				ON b.comment_id = c.id
+			JOIN blog_posts a
+				ON a.id = b.blog_post_id
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id
+		WHERE a.public_id = ? AND c.public_id = ? AND c.deleted_at IS NULL`,
+		blogPostID, commentID).Scan(&comment.ID, &comment.BlogPostID, &comment.CommentText, &parentID, &comment.ModerationStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comment: %w", err)
 	}
 
-	return commentID, nil
+	comment.ParentID = parentID.String
+	return &comment, nil
+}
+
+// GetCommentsAfter Returns up to limit approved comments of a blog post created after one of its comments,
+// oldest first. Comments in trash are skipped, but can still be the one others are read after.
+func (r *repository) GetCommentsAfter(blogPostID, commentID string, limit int) ([]Comment, error) {
+	var after int64
+	err := r.db.QueryRow(`
+		SELECT c.id
+		FROM comments c
+			JOIN blog_posts_comments b
+				ON b.comment_id = c.id
+			JOIN blog_posts a
+				ON a.id = b.blog_post_id
+		WHERE a.public_id = ? AND c.public_id = ?`,
+		blogPostID, commentID).Scan(&after)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comment: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT c.public_id, a.public_id, c.comment_text, p.public_id, c.moderation_status, c.created_at
+		FROM comments c
+			JOIN blog_posts_comments b
+				ON b.comment_id = c.id
+			JOIN blog_posts a
+				ON a.id = b.blog_post_id
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id
+		WHERE a.public_id = ? AND c.id > ? AND c.moderation_status = ? AND c.deleted_at IS NULL
+		ORDER BY c.id
+		LIMIT ?`,
+		blogPostID, after, ModerationApproved, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	for rows.Next() {
+		var (
+			comment  Comment
+			parentID sql.NullString
+		)
+		err := rows.Scan(&comment.ID, &comment.BlogPostID, &comment.CommentText, &parentID, &comment.ModerationStatus, &comment.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comment.ParentID = parentID.String
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// GetComments Returns a blog post comment thread as a flat list ordered depth first.
+// Pagination applies to the comments directly below query.ParentID, their replies are
+// resolved with a single recursive query down to query.MaxDepth levels, up to query.MaxReplies below every comment.
+// Unless query says otherwise, only approved comments and their replies are returned.
+func (r *repository) GetComments(blogPostID string, query CommentsQuery) ([]Comment, error) {
+	rows, err := r.db.Query(`
+		WITH RECURSIVE
+			roots AS (
+				SELECT c.id
+				FROM comments c
+					JOIN blog_posts_comments b
+						ON b.comment_id = c.id
+				WHERE b.blog_post_id = (SELECT id FROM blog_posts WHERE public_id = :post)
+					AND c.parent_comment_id IS (SELECT id FROM comments WHERE public_id = :parent)
+					AND c.deleted_at IS NULL
+					AND (:unapproved OR c.moderation_status = :approved)
+				ORDER BY c.id
+				LIMIT :limit OFFSET :offset
+			),
+			replies AS (
+				SELECT c.id, ROW_NUMBER() OVER (PARTITION BY c.parent_comment_id ORDER BY c.id) AS position
+				FROM comments c
+					JOIN blog_posts_comments b
+						ON b.comment_id = c.id
+				WHERE b.blog_post_id = (SELECT id FROM blog_posts WHERE public_id = :post)
+					AND c.parent_comment_id IS NOT NULL
+					AND c.deleted_at IS NULL
+					AND (:unapproved OR c.moderation_status = :approved)
+			),
+			thread (id, comment_text, parent_comment_id, moderation_status, depth, path) AS (
+				SELECT c.id, c.comment_text, c.parent_comment_id, c.moderation_status, 0, printf('%012d', c.id)
+				FROM comments c
+				WHERE c.id IN (SELECT id FROM roots)
+				UNION ALL
+				SELECT c.id, c.comment_text, c.parent_comment_id, c.moderation_status, t.depth + 1, t.path || '/' || printf('%012d', c.id)
+				FROM comments c
+					JOIN thread t
+						ON c.parent_comment_id = t.id
+					JOIN replies r
+						ON r.id = c.id
+				WHERE t.depth < :depth
+					AND r.position <= :replies
+			)
+		SELECT
+			c.public_id,
+			t.comment_text,
+			a.public_id,
+			p.public_id,
+			t.moderation_status,
+			c.created_at,
+			t.depth,
+			(
+				SELECT COUNT(*)
+				FROM comments r
+				WHERE r.parent_comment_id = t.id
+					AND r.deleted_at IS NULL
+					AND (:unapproved OR r.moderation_status = :approved)
+			)
+		FROM thread t
+			JOIN comments c
+				ON c.id = t.id
+			JOIN blog_posts_comments b
+				ON b.comment_id = t.id
+			JOIN blog_posts a
+				ON a.id = b.blog_post_id
+			LEFT JOIN comments p
+				ON p.id = t.parent_comment_id
+		ORDER BY t.path`,
+		sql.Named("post", blogPostID),
+		sql.Named("parent", nullString(query.ParentID)),
+		sql.Named("limit", query.Limit),
+		sql.Named("offset", query.Offset),
+		sql.Named("depth", query.MaxDepth),
+		sql.Named("replies", query.MaxReplies),
+		sql.Named("unapproved", query.IncludeUnapproved),
+		sql.Named("approved", ModerationApproved))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	for rows.Next() {
+		var (
+			comment   Comment
+			parentID  sql.NullString
+			createdAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.CommentText,
+			&comment.BlogPostID,
+			&parentID,
+			&comment.ModerationStatus,
+			&createdAt,
+			&comment.Depth,
+			&comment.ReplyCount,
+		); err != nil {
+			return nil, err
+		}
+		comment.ParentID = parentID.String
+		comment.CreatedAt = createdAt.Time
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// GetCommentsByBlogPosts Returns a page of top level comments for each of many blog posts with a single query,
+// by blog post public ID, oldest first.
+func (r *repository) GetCommentsByBlogPosts(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	return r.readCommentPages("a.public_id", "c.parent_comment_id IS NULL", blogPostIDs, query)
+}
+
+// GetRepliesByComments Returns a page of direct replies for each of many comments with a single query,
+// by comment public ID, oldest first.
+func (r *repository) GetRepliesByComments(commentIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	return r.readCommentPages("p.public_id", "c.parent_comment_id IS NOT NULL", commentIDs, query)
+}
+
+// readCommentPages Internal reusable function that paginates visible comments grouped by the public ID
+// selected by groupColumn, either their blog post (a) or their parent comment (p), with a single query.
+// Comments are numbered within their group, so every group gets its own page.
+func (r *repository) readCommentPages(groupColumn, condition string, ids []string, query CommentsQuery) (map[string][]Comment, error) {
+	res := map[string][]Comment{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	// Reply counts come first in the query, then the groups.
+	args := []any{query.IncludeUnapproved, ModerationApproved}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args = append(args, query.IncludeUnapproved, ModerationApproved, query.Offset, query.Limit, query.Offset+query.Limit)
+
+	rows, err := r.db.Query(`
+		SELECT
+			g.group_id,
+			g.public_id,
+			g.blog_post_id,
+			g.comment_text,
+			g.parent_id,
+			g.moderation_status,
+			g.created_at,
+			(
+				SELECT COUNT(*)
+				FROM comments r
+				WHERE r.parent_comment_id = g.id
+					AND r.deleted_at IS NULL
+					AND (? OR r.moderation_status = ?)
+			)
+		FROM (
+			SELECT
+				`+groupColumn+` AS group_id,
+				c.id,
+				c.public_id,
+				a.public_id AS blog_post_id,
+				c.comment_text,
+				p.public_id AS parent_id,
+				c.moderation_status,
+				c.created_at,
+				ROW_NUMBER() OVER (PARTITION BY `+groupColumn+` ORDER BY c.id) AS position
+			FROM comments c
+				JOIN blog_posts_comments b
+					ON b.comment_id = c.id
+				JOIN blog_posts a
+					ON a.id = b.blog_post_id
+				LEFT JOIN comments p
+					ON p.id = c.parent_comment_id
+			WHERE `+groupColumn+` IN (`+placeholders+`)
+				AND `+condition+`
+				AND c.deleted_at IS NULL
+				AND (? OR c.moderation_status = ?)
+		) g
+		WHERE g.position > ?
+			AND (? <= 0 OR g.position <= ?)
+		ORDER BY g.group_id, g.position`,
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comment pages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			groupID   string
+			comment   Comment
+			parentID  sql.NullString
+			createdAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&groupID,
+			&comment.ID,
+			&comment.BlogPostID,
+			&comment.CommentText,
+			&parentID,
+			&comment.ModerationStatus,
+			&createdAt,
+			&comment.ReplyCount,
+		); err != nil {
+			return nil, err
+		}
+		comment.ParentID = parentID.String
+		comment.CreatedAt = createdAt.Time
+		res[groupID] = append(res[groupID], comment)
+	}
+
+	return res, rows.Err()
+}
+
+// GetCommentsByModerationStatus Returns comments with provided moderation status paginated.
+func (r *repository) GetCommentsByModerationStatus(status ModerationStatus, limit, offset int) ([]Comment, error) {
+	rows, err := r.db.Query(`
+		SELECT c.public_id, a.public_id, c.comment_text, p.public_id, c.moderation_status
+		FROM comments c
+			JOIN blog_posts_comments b
+				ON b.comment_id = c.id
+			JOIN blog_posts a
+				ON a.id = b.blog_post_id
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id
+		WHERE c.moderation_status = ? AND c.deleted_at IS NULL AND a.deleted_at IS NULL
+		ORDER BY c.id
+		LIMIT ? OFFSET ?`,
+		status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	for rows.Next() {
+		var (
+			comment  Comment
+			parentID sql.NullString
+		)
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.BlogPostID,
+			&comment.CommentText,
+			&parentID,
+			&comment.ModerationStatus,
+		); err != nil {
+			return nil, err
+		}
+		comment.ParentID = parentID.String
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// UpdateCommentsModerationStatus Changes moderation status of provided comments and returns how many were updated.
+// Comments approved by the change are recorded in the outbox.
+func (r *repository) UpdateCommentsModerationStatus(ids []string, status ModerationStatus) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	idArgs := []any{}
+	for _, id := range ids {
+		idArgs = append(idArgs, id)
+	}
+
+	var approved []Comment
+	if status == ModerationApproved {
+		approved, err = readComments(tx, `c.public_id IN (`+placeholders+`) AND c.moderation_status != ?`, append(idArgs, ModerationApproved)...)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := tx.Exec(`
+		UPDATE comments
+		SET moderation_status = ?
+		WHERE public_id IN (`+placeholders+`) AND deleted_at IS NULL`,
+		append([]any{status}, idArgs...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to moderate comments: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get moderated comments: %w", err)
+	}
+
+	for _, comment := range approved {
+		comment.ModerationStatus = ModerationApproved
+		if err := addOutboxEvent(tx, EventCommentApproved, comment.BlogPostID, comment); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return affected, nil
+}
+
+// readComments Returns the comments, not in trash, matching a condition on comments c, oldest first.
+func readComments(q querier, condition string, args ...any) ([]Comment, error) {
+	rows, err := q.Query(`
+		SELECT c.public_id, a.public_id, c.comment_text, p.public_id, c.moderation_status, c.created_at
+		FROM comments c
+			JOIN blog_posts_comments b
+				ON b.comment_id = c.id
+			JOIN blog_posts a
+				ON a.id = b.blog_post_id
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id
+		WHERE c.deleted_at IS NULL AND `+condition+`
+		ORDER BY c.id`,
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	for rows.Next() {
+		var (
+			comment   Comment
+			parentID  sql.NullString
+			createdAt sql.NullTime
+		)
+		err := rows.Scan(&comment.ID, &comment.BlogPostID, &comment.CommentText, &parentID, &comment.ModerationStatus, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comment.ParentID = parentID.String
+		comment.CreatedAt = createdAt.Time
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// UpdateBlogPostStatus Updates publication status and date of a blog post.
+// Publishing a blog post not published yet is recorded in the outbox.
+func (r *repository) UpdateBlogPostStatus(id string, status Status, publishAt *time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current Status
+	err = tx.QueryRow(`
+		SELECT status
+		FROM blog_posts
+		WHERE public_id = ? AND deleted_at IS NULL`,
+		id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrBlogPostNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query blog post status: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE blog_posts
+		SET status = ?, publish_at = ?
+		WHERE public_id = ? AND deleted_at IS NULL`,
+		status, publishAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to update blog post status: %w", err)
+	}
+
+	if status == StatusPublished && current != StatusPublished {
+		if err := addPublishedEvents(tx, []string{id}); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// PublishDueBlogPosts Publishes scheduled blog posts due at provided time and returns how many were published.
+// Every publication is recorded in the outbox.
+func (r *repository) PublishDueBlogPosts(now time.Time) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		UPDATE blog_posts
+		SET status = ?
+		WHERE status = ? AND publish_at <= ? AND deleted_at IS NULL
+		RETURNING public_id`,
+		StatusPublished, StatusScheduled, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to publish due blog posts: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan published blog post: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read published blog posts: %w", err)
+	}
+
+	if err := addPublishedEvents(tx, ids); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return int64(len(ids)), nil
+}
+
+// addPublishedEvents Records the publication of blog posts with provided public IDs in the outbox, within the
+// transaction publishing them, along with the blog posts as they are stored.
+func addPublishedEvents(tx *sql.Tx, ids []string) error {
+	for _, id := range ids {
+		posts, err := readBlogPosts(tx, id, 0, 0, ReadOptions{IncludeUnpublished: true})
+		if err != nil {
+			return err
+		}
+		if len(posts) == 0 {
+			return ErrBlogPostNotFound
+		}
+		if err := addOutboxEvent(tx, EventPostPublished, id, posts[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteBlogPost Moves a blog post to trash.
+func (r *repository) DeleteBlogPost(id string, deletedAt time.Time) error {
+	res, err := r.db.Exec(`
+		UPDATE blog_posts
+		SET deleted_at = ?
+		WHERE public_id = ? AND deleted_at IS NULL`,
+		deletedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete blog post: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get deleted blog posts: %w", err)
+	}
+	if affected == 0 {
+		return ErrBlogPostNotFound
+	}
+
+	return nil
+}
+
+// DeleteComment Moves a comment of a blog post to trash along with all its replies.
+func (r *repository) DeleteComment(blogPostID, commentID string, deletedAt time.Time) error {
+	res, err := r.db.Exec(`
+		WITH RECURSIVE subtree (id) AS (
+			SELECT c.id
+			FROM comments c
+				JOIN blog_posts_comments b
+					ON b.comment_id = c.id
+				JOIN blog_posts a
+					ON a.id = b.blog_post_id
+			WHERE a.public_id = :post AND c.public_id = :comment AND c.deleted_at IS NULL
+			UNION ALL
+			SELECT c.id
+			FROM comments c
+				JOIN subtree s
+					ON c.parent_comment_id = s.id
+			WHERE c.deleted_at IS NULL
+		)
+		UPDATE comments
+		SET deleted_at = :deleted
+		WHERE id IN (SELECT id FROM subtree)`,
+		sql.Named("post", blogPostID),
+		sql.Named("comment", commentID),
+		sql.Named("deleted", deletedAt))
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get deleted comments: %w", err)
+	}
+	if affected == 0 {
+		return ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// GetDeletedBlogPosts Returns blog posts in trash paginated, most recently deleted first.
+func (r *repository) GetDeletedBlogPosts(limit, offset int) ([]BlogPost, error) {
+	rows, err := r.db.Query(`
+		SELECT public_id, slug, title, content, content_format, content_html, excerpt, status, publish_at, deleted_at
+		FROM blog_posts
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id
+		LIMIT ? OFFSET ?`,
+		limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted blog posts: %w", err)
+	}
+	defer rows.Close()
+
+	blogPosts := []BlogPost{}
+	for rows.Next() {
+		var (
+			post      BlogPost
+			slug      sql.NullString
+			html      sql.NullString
+			excerpt   sql.NullString
+			publishAt sql.NullTime
+			deletedAt time.Time
+		)
+		if err := rows.Scan(
+			&post.ID,
+			&slug,
+			&post.Title,
+			&post.Content,
+			&post.ContentFormat,
+			&html,
+			&excerpt,
+			&post.Status,
+			&publishAt,
+			&deletedAt,
+		); err != nil {
+			return nil, err
+		}
+		post.Slug = slug.String
+		post.ContentHTML = html.String
+		post.Excerpt = excerpt.String
+		if publishAt.Valid {
+			post.PublishAt = &publishAt.Time
+		}
+		post.DeletedAt = &deletedAt
+		blogPosts = append(blogPosts, post)
+	}
+
+	return blogPosts, rows.Err()
+}
+
+// GetDeletedComments Returns comments in trash paginated, most recently deleted first.
+func (r *repository) GetDeletedComments(limit, offset int) ([]Comment, error) {
+	rows, err := r.db.Query(`
+		SELECT c.public_id, a.public_id, c.comment_text, p.public_id, c.moderation_status, c.deleted_at
+		FROM comments c
+			JOIN blog_posts_comments b
+				ON b.comment_id = c.id
+			JOIN blog_posts a
+				ON a.id = b.blog_post_id
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id
+		WHERE c.deleted_at IS NOT NULL
+		ORDER BY c.deleted_at DESC, c.id
+		LIMIT ? OFFSET ?`,
+		limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	for rows.Next() {
+		var (
+			comment   Comment
+			parentID  sql.NullString
+			deletedAt time.Time
+		)
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.BlogPostID,
+			&comment.CommentText,
+			&parentID,
+			&comment.ModerationStatus,
+			&deletedAt,
+		); err != nil {
+			return nil, err
+		}
+		comment.ParentID = parentID.String
+		comment.DeletedAt = &deletedAt
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// RestoreBlogPost Takes a blog post, found by public ID or slug, out of trash.
+func (r *repository) RestoreBlogPost(id string) error {
+	res, err := r.db.Exec(`
+		UPDATE blog_posts
+		SET deleted_at = NULL
+		WHERE (public_id = ? OR id = (SELECT blog_post_id FROM blog_post_slugs WHERE slug = ?))
+			AND deleted_at IS NOT NULL`,
+		id, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore blog post: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get restored blog posts: %w", err)
+	}
+	if affected == 0 {
+		return ErrBlogPostNotFound
+	}
+
+	return nil
+}
+
+// RestoreComment Takes a comment out of trash along with the replies deleted with it.
+// Comments whose parent is still in trash cannot be restored.
+func (r *repository) RestoreComment(commentID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		id              int64
+		parentDeletedAt sql.NullTime
+	)
+	err = tx.QueryRow(`
+		SELECT c.id, p.deleted_at
+		FROM comments c
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id
+		WHERE c.public_id = ? AND c.deleted_at IS NOT NULL`,
+		commentID).Scan(&id, &parentDeletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCommentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query comment: %w", err)
+	}
+	if parentDeletedAt.Valid {
+		return ErrParentCommentDeleted
+	}
+
+	// Replies deleted along with the comment share its deletion date, replies deleted before keep being deleted.
+	_, err = tx.Exec(`
+		WITH RECURSIVE subtree (id) AS (
+			SELECT :id
+			UNION ALL
+			SELECT c.id
+			FROM comments c
+				JOIN subtree s
+					ON c.parent_comment_id = s.id
+			WHERE c.deleted_at = (SELECT deleted_at FROM comments WHERE id = :id)
+		)
+		UPDATE comments
+		SET deleted_at = NULL
+		WHERE id IN (SELECT id FROM subtree)`,
+		sql.Named("id", id))
+	if err != nil {
+		return fmt.Errorf("failed to restore comment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeleted Permanently deletes blog posts and comments moved to trash before provided date,
+// along with everything that belongs to them, and returns how many posts and comments were deleted.
+func (r *repository) PurgeDeleted(before time.Time) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var purged int64
+	statements := []struct {
+		query   string
+		counted bool
+	}{
+		{query: `
+			DELETE FROM comments
+			WHERE deleted_at <= :before
+				OR id IN (
+					SELECT b.comment_id
+					FROM blog_posts_comments b
+						JOIN blog_posts a
+							ON a.id = b.blog_post_id
+					WHERE a.deleted_at <= :before
+				)`, counted: true},
+		{query: `DELETE FROM blog_posts_comments WHERE comment_id NOT IN (SELECT id FROM comments)`},
+		{query: `DELETE FROM blog_posts_tags WHERE blog_post_id IN (SELECT id FROM blog_posts WHERE deleted_at <= :before)`},
+		{query: `DELETE FROM blog_post_slugs WHERE blog_post_id IN (SELECT id FROM blog_posts WHERE deleted_at <= :before)`},
+		{query: `DELETE FROM blog_post_revisions WHERE blog_post_id IN (SELECT id FROM blog_posts WHERE deleted_at <= :before)`},
+		{query: `DELETE FROM blog_posts WHERE deleted_at <= :before`, counted: true},
+	}
+	for _, statement := range statements {
+		res, err := tx.Exec(statement.query, sql.Named("before", before))
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge deleted items: %w", err)
+		}
+		if !statement.counted {
+			continue
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get purged items: %w", err)
+		}
+		purged += affected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return purged, nil
+}
+
+// ReindexBlogPosts Renders again HTML and excerpt of every blog post, trash included, and stores the ones
+// that changed in a single transaction. Returns how many blog posts were updated.
+func (r *repository) ReindexBlogPosts(render func(post *BlogPost) error) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Every post is read before updating any, so the transaction never writes while a query is open.
+	rows, err := tx.Query(`SELECT id, public_id, slug, title, content, content_format, content_html, excerpt FROM blog_posts ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query blog posts: %w", err)
+	}
+
+	var (
+		keys  []int64
+		posts []BlogPost
+	)
+	for rows.Next() {
+		var (
+			key     int64
+			post    BlogPost
+			html    sql.NullString
+			excerpt sql.NullString
+		)
+		if err := rows.Scan(&key, &post.ID, &post.Slug, &post.Title, &post.Content, &post.ContentFormat, &html, &excerpt); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		post.ContentHTML = html.String
+		post.Excerpt = excerpt.String
+		keys = append(keys, key)
+		posts = append(posts, post)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var updated int64
+	for i, post := range posts {
+		rendered := post
+		if err := render(&rendered); err != nil {
+			return 0, fmt.Errorf("failed to render blog post with ID (%s): %w", post.ID, err)
+		}
+		// Slugs backfilled by migrations are made again as new posts get them, keeping the key suffix they were
+		// made unique with. Previous slugs stay in history so old links redirect.
+		if post.Slug == backfilledSlug(post.Title, keys[i]) {
+			slug, err := uniqueSlug(tx, fmt.Sprintf("%s-%d", postSlug(post.Title), keys[i]), keys[i])
+			if err != nil {
+				return 0, err
+			}
+			rendered.Slug = slug
+		}
+		if rendered.ContentHTML == post.ContentHTML && rendered.Excerpt == post.Excerpt && rendered.Slug == post.Slug {
+			continue
+		}
+
+		_, err := tx.Exec(`UPDATE blog_posts SET content_html = ?, excerpt = ?, slug = ? WHERE id = ?`,
+			rendered.ContentHTML, rendered.Excerpt, rendered.Slug, keys[i])
+		if err != nil {
+			return 0, fmt.Errorf("failed to update blog post: %w", err)
+		}
+		if err := addSlugHistory(tx, rendered.Slug, keys[i]); err != nil {
+			return 0, err
+		}
+		updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ExportContent Calls fn with every blog post followed by its comments, as they are read. Trash is not exported.
+// Everything is read with a single query, so posts are repeated along every comment row and only sent once.
+func (r *repository) ExportContent(fn func(ExportRecord) error) error {
+	rows, err := r.db.Query(`
+		SELECT
+			a.public_id,
+			a.slug,
+			a.title,
+			a.content,
+			a.author,
+			a.created_at,
+			a.content_format,
+			a.content_html,
+			a.excerpt,
+			(
+				SELECT COUNT(*)
+				FROM blog_posts_comments b
+					JOIN comments c
+						ON c.id = b.comment_id
+				WHERE b.blog_post_id = a.id
+					AND c.deleted_at IS NULL
+			),
+			a.status,
+			a.publish_at,
+			a.comment_moderation,
+			(
+				SELECT GROUP_CONCAT(t.slug)
+				FROM blog_posts_tags bt
+					JOIN tags t
+						ON t.id = bt.tag_id
+				WHERE bt.blog_post_id = a.id
+			),
+			c.public_id,
+			c.comment_text,
+			p.public_id,
+			c.moderation_status,
+			c.created_at
+		FROM blog_posts a
+			LEFT JOIN blog_posts_comments b
+				ON b.blog_post_id = a.id
+			LEFT JOIN comments c
+				ON c.id = b.comment_id
+				AND c.deleted_at IS NULL
+			LEFT JOIN comments p
+				ON p.id = c.parent_comment_id
+		WHERE a.deleted_at IS NULL
+		ORDER BY a.id, c.id`)
+	if err != nil {
+		return fmt.Errorf("failed to query export: %w", err)
+	}
+	defer rows.Close()
+
+	lastPostID := ""
+	for rows.Next() {
+		var (
+			i    blogPostComment
+			tags sql.NullString
+		)
+		if err := rows.Scan(
+			&i.BlogPostID,
+			&i.BlogPostSlug,
+			&i.BlogPostTitle,
+			&i.BlogPostContent,
+			&i.BlogPostAuthor,
+			&i.BlogPostCreated,
+			&i.BlogPostFormat,
+			&i.BlogPostHTML,
+			&i.BlogPostExcerpt,
+			&i.BlogPostComments,
+			&i.BlogPostStatus,
+			&i.BlogPostPublish,
+			&i.BlogPostModeration,
+			&tags,
+			&i.CommentID,
+			&i.CommentText,
+			&i.CommentParentID,
+			&i.CommentModeration,
+			&i.CommentCreated,
+		); err != nil {
+			return err
+		}
+
+		if i.BlogPostID != lastPostID {
+			lastPostID = i.BlogPostID
+			post := BlogPost{
+				ID:      i.BlogPostID,
+				Slug:    i.BlogPostSlug.String,
+				Title:   i.BlogPostTitle,
+				Content: i.BlogPostContent,
+				Author:  i.BlogPostAuthor.String,
+				Status:  Status(i.BlogPostStatus),
+
+				ContentFormat: ContentFormat(i.BlogPostFormat),
+				ContentHTML:   i.BlogPostHTML.String,
+				Excerpt:       i.BlogPostExcerpt.String,
+				CommentCount:  i.BlogPostComments,
+
+				CommentModeration: ModerationStatus(i.BlogPostModeration.String),
+				CreatedAt:         i.BlogPostCreated.Time,
+			}
+			if i.BlogPostPublish.Valid {
+				publishAt := i.BlogPostPublish.Time
+				post.PublishAt = &publishAt
+			}
+			if tags.Valid {
+				post.Tags = strings.Split(tags.String, ",")
+				slices.Sort(post.Tags)
+			}
+			if err := fn(ExportRecord{Type: RecordPost, Post: &post}); err != nil {
+				return err
+			}
+		}
+
+		if i.CommentID.Valid {
+			err := fn(ExportRecord{Type: RecordComment, Comment: &Comment{
+				ID:          i.CommentID.String,
+				BlogPostID:  i.BlogPostID,
+				CommentText: i.CommentText.String,
+				ParentID:    i.CommentParentID.String,
+				CreatedAt:   i.CommentCreated.Time,
+
+				ModerationStatus: ModerationStatus(i.CommentModeration.String),
+			}})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+// importIDs Public IDs given to imported blog posts and comments, by the ID they had in the import.
+type importIDs struct {
+	posts    map[string]string
+	comments map[string]string
+}
+
+// newImportIDs Returns empty import IDs.
+func newImportIDs() importIDs {
+	return importIDs{posts: map[string]string{}, comments: map[string]string{}}
+}
+
+// ImportRecords Stores every batch of records, remapping IDs, and calls fn with the error of every record of
+// a batch, nil for the stored ones, once the batch is stored. Each batch is stored in its own transaction, and
+// comments are only linked to records of committed batches. Dry runs store every batch in a single transaction
+// rolled back at the end, so later batches see the records of the earlier ones.
+func (r *repository) ImportRecords(batches iter.Seq[[]ExportRecord], dryRun bool, fn func(records []ExportRecord, errs []error)) error {
+	ids := newImportIDs()
+	if !dryRun {
+		for records := range batches {
+			errs, err := r.importBatch(records, ids)
+			if err != nil {
+				return err
+			}
+			fn(records, errs)
+		}
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for records := range batches {
+		errs, err := importRecords(tx, records, ids, ids)
+		if err != nil {
+			return err
+		}
+		fn(records, errs)
+	}
+
+	return nil
+}
+
+// importBatch Stores records in a single transaction, and adds the IDs they were given to ids once committed.
+func (r *repository) importBatch(records []ExportRecord, ids importIDs) ([]error, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	added := newImportIDs()
+	errs, err := importRecords(tx, records, ids, added)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	maps.Copy(ids.posts, added.posts)
+	maps.Copy(ids.comments, added.comments)
+	return errs, nil
+}
+
+// importRecords Stores records within tx, linking them through ids and added, and returns the error of every
+// record. Every record is stored within its own savepoint, so failed records are undone without undoing the others.
+func importRecords(tx *sql.Tx, records []ExportRecord, ids, added importIDs) ([]error, error) {
+	insert, err := prepareInsertBlogPost(tx)
+	if err != nil {
+		return nil, err
+	}
+	defer insert.Close()
+
+	errs := make([]error, len(records))
+	for i, record := range records {
+		if _, err := tx.Exec("SAVEPOINT import_record"); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		errs[i] = importRecord(tx, insert, record, ids, added)
+		if errs[i] != nil {
+			if _, err := tx.Exec("ROLLBACK TO import_record"); err != nil {
+				return nil, fmt.Errorf("failed to roll back savepoint: %w", err)
+			}
+		}
+
+		if _, err := tx.Exec("RELEASE import_record"); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+	}
+
+	return errs, nil
+}
+
+// importRecord Stores a single imported record, linking comments to the posts and comments imported before them,
+// either in ids or added. IDs given to the record are recorded in added.
+func importRecord(tx *sql.Tx, insert *sql.Stmt, record ExportRecord, ids, added importIDs) error {
+	switch record.Type {
+	case RecordPost:
+		post := *record.Post
+		if err := insertBlogPost(tx, insert, &post); err != nil {
+			return err
+		}
+		if record.Post.ID != "" {
+			added.posts[record.Post.ID] = post.ID
+		}
+	case RecordComment:
+		comment := *record.Comment
+
+		blogPostID, ok := importedID(ids.posts, added.posts, comment.BlogPostID)
+		if !ok {
+			return fmt.Errorf("%w (%s)", ErrBlogPostNotFound, comment.BlogPostID)
+		}
+		if comment.ParentID != "" {
+			parentID, ok := importedID(ids.comments, added.comments, comment.ParentID)
+			if !ok {
+				return fmt.Errorf("%w (%s)", ErrCommentNotFound, comment.ParentID)
+			}
+			comment.ParentID = parentID
+		}
+
+		if err := insertComment(tx, blogPostID, &comment); err != nil {
+			return err
+		}
+		if record.Comment.ID != "" {
+			added.comments[record.Comment.ID] = comment.ID
+		}
+	default:
+		return fmt.Errorf("%w: unknown record type (%s)", ErrorBadRequest, record.Type)
+	}
+	return nil
+}
+
+// importedID Returns the public ID given to the record imported with provided ID, either earlier or in added.
+func importedID(ids, added map[string]string, id string) (string, bool) {
+	if publicID, ok := added[id]; ok {
+		return publicID, true
+	}
+	publicID, ok := ids[id]
+	return publicID, ok
+}
+
+// addOutboxEvent Records an event about the blog post with provided public ID in the outbox, within the transaction
+// of the write it reports, so the event is stored if and only if the write is, along with the status the blog post
+// has at that point. Events are due right away.
+func addOutboxEvent(tx *sql.Tx, eventType EventType, blogPostID string, data any) error {
+	eventID, err := newPublicID()
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	now := time.Now().UTC()
+	_, err = tx.Exec(`
+		INSERT INTO outbox (event_id, event_type, aggregate_id, post_status, payload, occurred_at, next_attempt_at)
+		VALUES (?, ?, ?, (SELECT status FROM blog_posts WHERE public_id = ? AND deleted_at IS NULL), ?, ?, ?)`,
+		eventID, eventType, blogPostID, blogPostID, string(payload), now, now)
+	if err != nil {
+		return fmt.Errorf("failed to insert %s event: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// GetPendingEvents Returns up to limit outbox events due for publication at provided time, oldest first.
+// Events waiting for a retry hold back the later events of their aggregate.
+func (r *repository) GetPendingEvents(now time.Time, limit int) ([]Event, error) {
+	now = now.UTC()
+	rows, err := r.db.Query(`
+		SELECT o.event_id, o.event_type, o.aggregate_id, COALESCE(o.post_status, ''), o.payload, o.occurred_at, o.attempts
+		FROM outbox o
+		WHERE o.dispatched_at IS NULL AND o.next_attempt_at <= ?
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox p
+				WHERE p.aggregate_id = o.aggregate_id AND p.id < o.id
+					AND p.dispatched_at IS NULL AND p.next_attempt_at > ?
+			)
+		ORDER BY o.id
+		LIMIT ?`,
+		now, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []Event{}
+	for rows.Next() {
+		var (
+			event   Event
+			payload string
+		)
+		err := rows.Scan(&event.ID, &event.Type, &event.AggregateID, &event.PostStatus, &payload, &event.OccurredAt, &event.Attempts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending event: %w", err)
+		}
+
+		switch event.Type {
+		case EventPostCreated, EventPostPublished:
+			event.BlogPost = &BlogPost{}
+			err = json.Unmarshal([]byte(payload), event.BlogPost)
+		case EventCommentCreated, EventCommentApproved:
+			event.Comment = &Comment{}
+			err = json.Unmarshal([]byte(payload), event.Comment)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s event (%s): %w", event.Type, event.ID, err)
+		}
+
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read pending events: %w", err)
+	}
+
+	return events, nil
+}
+
+// MarkEventDispatched Marks an outbox event as published.
+func (r *repository) MarkEventDispatched(id string, at time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE outbox
+		SET dispatched_at = ?, attempts = attempts + 1, last_error = NULL
+		WHERE event_id = ?`,
+		at.UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark event as dispatched: %w", err)
+	}
+	return nil
+}
+
+// MarkEventFailed Records why an outbox event could not be published and when to try again.
+func (r *repository) MarkEventFailed(id, cause string, retryAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+		WHERE event_id = ?`,
+		cause, retryAt.UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark event as failed: %w", err)
+	}
+	return nil
+}
+
+// PruneDispatchedEvents Deletes outbox events dispatched before provided date, and returns how many were deleted.
+func (r *repository) PruneDispatchedEvents(before time.Time) (int64, error) {
+	res, err := r.db.Exec(`
+		DELETE FROM outbox
+		WHERE dispatched_at IS NOT NULL AND dispatched_at < ?`,
+		before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune dispatched events: %w", err)
+	}
+
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pruned events: %w", err)
+	}
+	return pruned, nil
+}
+
+// nullString Maps empty strings to SQL NULL values.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
 }
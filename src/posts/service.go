@@ -1,37 +1,716 @@
 package posts
 
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	// ImportBatchSize Records stored per transaction on imports.
+	ImportBatchSize = 100
+	// MaxImportLineSize Maximum size in bytes of a single imported line.
+	MaxImportLineSize = 1 << 20
+)
+
+// ServiceConfig Posts service configuration.
+type ServiceConfig struct {
+	// DefaultCommentModeration Moderation status of new comments on posts without their own default.
+	DefaultCommentModeration ModerationStatus
+	// ContentFilter Filter run on posts and comments before they are stored, nil to store everything.
+	ContentFilter ContentFilter
+	// TrashRetention How long deleted posts and comments are kept in trash before being purged.
+	TrashRetention time.Duration
+}
+
 type service struct {
 	Repository Repository
+	Config     ServiceConfig
 }
 
 // NewService Returns new productive blog post service implementation.
-func NewService(repository Repository) (Service, error) {
+func NewService(repository Repository, cfg ServiceConfig) (Service, error) {
+	if cfg.DefaultCommentModeration != "" && !cfg.DefaultCommentModeration.Valid() {
+		return nil, fmt.Errorf("invalid default comment moderation (%s)", cfg.DefaultCommentModeration)
+	}
+	if cfg.TrashRetention < 0 {
+		return nil, fmt.Errorf("invalid trash retention (%s)", cfg.TrashRetention)
+	}
+
 	return &service{
 		Repository: repository,
+		Config:     cfg,
 	}, nil
 }
 
 // GetAllBlogPosts Returns all existing blog posts paginated.
-func (s *service) GetAllBlogPosts(limit, offset int) ([]BlogPost, error) {
-	return s.Repository.GetAllBlogPosts(limit, offset)
+func (s *service) GetAllBlogPosts(limit, offset int, opts ReadOptions) ([]BlogPost, error) {
+	filter := opts.Filter
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("%w: invalid status (%s)", ErrorBadRequest, filter.Status)
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, fmt.Errorf("%w: created_after must be before created_before", ErrorBadRequest)
+	}
+
+	for _, tag := range opts.Tags {
+		if Slugify(tag) == "" {
+			return nil, fmt.Errorf("%w: invalid tag (%s)", ErrorBadRequest, tag)
+		}
+	}
+
+	opts.Tags = slugifyTags(opts.Tags)
+	return s.Repository.GetAllBlogPosts(limit, offset, opts)
 }
 
-// GetBlogPost Returns single blog post with provided ID.
-func (s *service) GetBlogPost(id string) (*BlogPost, error) {
-	return s.Repository.GetBlogPost(id)
+// GetBlogPost Returns single blog post with provided ID or slug, old slugs included.
+func (s *service) GetBlogPost(id string, opts ReadOptions) (*BlogPost, error) {
+	return s.Repository.GetBlogPost(id, opts)
 }
 
 // CreateBlogPost Creates a new blog post and returns its generated ID.
-func (s *service) CreateBlogPost(title, content string) (int64, error) {
-	return s.Repository.CreateBlogPost(title, content)
+func (s *service) CreateBlogPost(request CreatePostRequest) (string, error) {
+	post, err := s.newBlogPost(request)
+	if err != nil {
+		return "", err
+	}
+
+	return s.Repository.CreateBlogPost(post)
+}
+
+// CreateBlogPosts Creates many blog posts at once and returns the outcome of every one of them, in request order.
+// Atomic batches create either every post or none of them, returning a BatchItemError for the first post that fails.
+func (s *service) CreateBlogPosts(requests []CreatePostRequest, atomic bool) ([]BatchResult, error) {
+	results := make([]BatchResult, len(requests))
+	posts := make([]BlogPost, 0, len(requests))
+	indexes := make([]int, 0, len(requests))
+	for i, request := range requests {
+		post, err := s.newBlogPost(request)
+		if err != nil {
+			if atomic {
+				return nil, &BatchItemError{Index: i, Err: err}
+			}
+			results[i].Err = err
+			continue
+		}
+		posts = append(posts, post)
+		indexes = append(indexes, i)
+	}
+
+	if len(posts) == 0 {
+		return results, nil
+	}
+
+	created, err := s.Repository.CreateBlogPosts(posts, atomic)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range indexes {
+		results[i] = created[j]
+	}
+
+	return results, nil
+}
+
+// newBlogPost Returns the blog post to be created for a request, validated and rendered.
+// Posts without explicit status are created as drafts, or scheduled when a publish date is provided.
+func (s *service) newBlogPost(request CreatePostRequest) (BlogPost, error) {
+	if request.Title == "" || request.Content == "" {
+		return BlogPost{}, fmt.Errorf("%w: missing title or content", ErrorBadRequest)
+	}
+
+	post := BlogPost{
+		Slug:              postSlug(request.Title),
+		Title:             request.Title,
+		Content:           request.Content,
+		Author:            strings.TrimSpace(request.Author),
+		Status:            request.Status,
+		ContentFormat:     request.ContentFormat,
+		Tags:              slugifyTags(request.Tags),
+		CommentModeration: request.CommentModeration,
+	}
+
+	if request.PublishAt != nil {
+		// Publish dates are compared as stored, so they are always stored in UTC.
+		publishAt := request.PublishAt.UTC()
+		if !publishAt.After(time.Now()) {
+			return BlogPost{}, fmt.Errorf("%w: publish_at must be in the future", ErrorBadRequest)
+		}
+		post.PublishAt = &publishAt
+	}
+
+	if post.ContentFormat == "" {
+		post.ContentFormat = ContentFormatText
+	}
+	if !post.ContentFormat.Valid() {
+		return BlogPost{}, fmt.Errorf("%w: invalid content format (%s)", ErrorBadRequest, post.ContentFormat)
+	}
+
+	if post.CommentModeration != "" && !post.CommentModeration.Valid() {
+		return BlogPost{}, fmt.Errorf("%w: invalid comment moderation (%s)", ErrorBadRequest, post.CommentModeration)
+	}
+
+	if post.Status == "" {
+		post.Status = StatusDraft
+		if post.PublishAt != nil {
+			post.Status = StatusScheduled
+		}
+	}
+
+	switch post.Status {
+	case StatusDraft:
+	case StatusScheduled:
+		if post.PublishAt == nil {
+			return BlogPost{}, fmt.Errorf("%w: scheduled posts require publish_at", ErrorBadRequest)
+		}
+	case StatusPublished:
+		if post.PublishAt == nil {
+			now := time.Now().UTC()
+			post.PublishAt = &now
+		}
+	default:
+		return BlogPost{}, fmt.Errorf("%w: invalid initial status (%s)", ErrorBadRequest, post.Status)
+	}
+
+	action, err := s.checkContent(Content{Kind: ContentPost, Title: post.Title, Text: post.Content})
+	if err != nil {
+		return BlogPost{}, err
+	}
+	if action == FilterModerate {
+		// Posts sent to moderation are kept as drafts until reviewed.
+		post.Status = StatusDraft
+		post.PublishAt = nil
+	}
+
+	if err := renderPost(&post); err != nil {
+		return BlogPost{}, err
+	}
+
+	return post, nil
+}
+
+// renderPost Renders HTML and excerpt of a blog post from its content.
+// They are stored along with the content so reads never render them again.
+func renderPost(post *BlogPost) error {
+	var err error
+	post.ContentHTML, err = RenderContent(post.ContentFormat, post.Content)
+	if err != nil {
+		return err
+	}
+	post.Excerpt, err = Excerpt(post.ContentFormat, post.Content)
+	return err
+}
+
+// UpdateBlogPost Updates title and content of a blog post, renaming its slug when the title changes.
+// Content keeps its current format unless a new one is requested.
+func (s *service) UpdateBlogPost(id string, request UpdatePostRequest) error {
+	post, err := s.Repository.GetBlogPost(id, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return err
+	}
+
+	if request.ContentFormat != "" && !request.ContentFormat.Valid() {
+		return fmt.Errorf("%w: invalid content format (%s)", ErrorBadRequest, request.ContentFormat)
+	}
+
+	// Updates are made by admins, so only rejections apply.
+	_, err = s.checkContent(Content{Kind: ContentPost, BlogPostID: post.ID, Title: request.Title, Text: request.Content})
+	if err != nil {
+		return err
+	}
+
+	// Slugs only change along with the words of the title, so typo fixes in punctuation keep links stable.
+	if postSlug(request.Title) != postSlug(post.Title) {
+		post.Slug = postSlug(request.Title)
+	}
+	post.Title = request.Title
+	post.Content = request.Content
+	if request.ContentFormat != "" {
+		post.ContentFormat = request.ContentFormat
+	}
+
+	if err := renderPost(post); err != nil {
+		return err
+	}
+
+	return s.Repository.UpdateBlogPost(*post)
+}
+
+// GetRevisions Returns content revisions of a blog post paginated, newest first.
+func (s *service) GetRevisions(blogPostID string, limit, offset int) ([]Revision, error) {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repository.GetRevisions(post.ID, limit, offset)
+}
+
+// GetRevision Returns single content revision of a blog post.
+func (s *service) GetRevision(blogPostID string, number int) (*Revision, error) {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repository.GetRevision(post.ID, number)
+}
+
+// DiffRevisions Returns a unified diff between two content revisions of a blog post.
+// Both title and content are compared, the title being the first line of each revision.
+func (s *service) DiffRevisions(blogPostID string, from, to int) (string, error) {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return "", err
+	}
+
+	fromRevision, err := s.Repository.GetRevision(post.ID, from)
+	if err != nil {
+		return "", err
+	}
+	toRevision, err := s.Repository.GetRevision(post.ID, to)
+	if err != nil {
+		return "", err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromRevision.Title + "\n\n" + fromRevision.Content),
+		B:        difflib.SplitLines(toRevision.Title + "\n\n" + toRevision.Content),
+		FromFile: fmt.Sprintf("revision %d", from),
+		ToFile:   fmt.Sprintf("revision %d", to),
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff revisions: %w", err)
+	}
+
+	return diff, nil
+}
+
+// RestoreRevision Restores title and content of a blog post from one of its revisions, saved as a new revision.
+func (s *service) RestoreRevision(blogPostID string, number int) error {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return err
+	}
+
+	revision, err := s.Repository.GetRevision(post.ID, number)
+	if err != nil {
+		return err
+	}
+
+	return s.UpdateBlogPost(post.ID, UpdatePostRequest{
+		Title:         revision.Title,
+		Content:       revision.Content,
+		ContentFormat: revision.ContentFormat,
+	})
+}
+
+// GetTags Returns all tags in use with their post counts.
+func (s *service) GetTags(opts ReadOptions) ([]Tag, error) {
+	return s.Repository.GetTags(opts)
 }
 
 // CreateComment Creates a new comment and associates it with a blog post.
-func (s *service) CreateComment(blogPostID, text string) (int64, error) {
-	_, err := s.Repository.GetBlogPost(blogPostID)
+func (s *service) CreateComment(blogPostID, text string) (string, error) {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	action, err := s.checkContent(Content{Kind: ContentComment, BlogPostID: post.ID, Text: text})
+	if err != nil {
+		return "", err
+	}
+
+	return s.Repository.CreateComment(post.ID, Comment{
+		CommentText:      text,
+		ModerationStatus: s.moderationStatus(post, action),
+	})
+}
+
+// CreateReply Creates a new comment replying to another comment of the same blog post.
+func (s *service) CreateReply(blogPostID, parentCommentID, text string) (string, error) {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	parent, err := s.Repository.GetComment(post.ID, parentCommentID)
+	if err != nil {
+		return "", err
+	}
+	if parent.ModerationStatus != ModerationApproved {
+		return "", ErrCommentNotFound
+	}
+
+	action, err := s.checkContent(Content{Kind: ContentComment, BlogPostID: post.ID, Text: text})
+	if err != nil {
+		return "", err
+	}
+
+	return s.Repository.CreateComment(post.ID, Comment{
+		ParentID:         parentCommentID,
+		CommentText:      text,
+		ModerationStatus: s.moderationStatus(post, action),
+	})
+}
+
+// checkContent Runs the configured content filter, failing when content gets rejected.
+func (s *service) checkContent(content Content) (FilterAction, error) {
+	if s.Config.ContentFilter == nil {
+		return FilterAllow, nil
+	}
+
+	res, err := s.Config.ContentFilter.Check(content)
+	if err != nil {
+		return FilterAllow, fmt.Errorf("failed to filter content: %w", err)
+	}
+	if res.Action == FilterReject {
+		return FilterReject, &ContentRejectedError{Reasons: res.Reasons}
+	}
+
+	return res.Action, nil
+}
+
+// moderationStatus Returns the moderation status of new comments on provided post.
+// Comments flagged by content filters always go to moderation.
+func (s *service) moderationStatus(post *BlogPost, action FilterAction) ModerationStatus {
+	if action == FilterModerate {
+		return ModerationPending
+	}
+	if post.CommentModeration != "" {
+		return post.CommentModeration
+	}
+	if s.Config.DefaultCommentModeration != "" {
+		return s.Config.DefaultCommentModeration
+	}
+	return ModerationApproved
+}
+
+// GetComments Returns a blog post comment thread as a flat list ordered depth first.
+func (s *service) GetComments(blogPostID string, query CommentsQuery) ([]Comment, error) {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if query.ParentID != "" {
+		parent, err := s.Repository.GetComment(post.ID, query.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if !query.IncludeUnapproved && parent.ModerationStatus != ModerationApproved {
+			return nil, ErrCommentNotFound
+		}
+	}
+
+	// Replies are capped so a single busy comment cannot blow up the whole thread.
+	if query.MaxReplies < 1 || query.MaxReplies > MaxCommentReplies {
+		query.MaxReplies = MaxCommentReplies
+	}
+	return s.Repository.GetComments(post.ID, query)
+}
+
+// GetCommentsAfter Returns up to limit approved comments of a published blog post created after one of its comments,
+// oldest first.
+func (s *service) GetCommentsAfter(blogPostID, commentID string, limit int) ([]Comment, error) {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repository.GetCommentsAfter(post.ID, commentID, limit)
+}
+
+// GetCommentsByBlogPosts Returns a page of top level comments for each of many blog posts at once, by blog post ID.
+// Blog posts are expected to be already read, so neither their existence nor their visibility is checked,
+// and only Limit, Offset and IncludeUnapproved of the query apply.
+func (s *service) GetCommentsByBlogPosts(blogPostIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	if len(blogPostIDs) == 0 {
+		return map[string][]Comment{}, nil
+	}
+	return s.Repository.GetCommentsByBlogPosts(blogPostIDs, query)
+}
+
+// GetRepliesByComments Returns a page of direct replies for each of many comments at once, by comment ID.
+// Comments are expected to be already read, so neither their existence nor their visibility is checked,
+// and only Limit, Offset and IncludeUnapproved of the query apply.
+func (s *service) GetRepliesByComments(commentIDs []string, query CommentsQuery) (map[string][]Comment, error) {
+	if len(commentIDs) == 0 {
+		return map[string][]Comment{}, nil
+	}
+	return s.Repository.GetRepliesByComments(commentIDs, query)
+}
+
+// GetCommentsByModerationStatus Returns comments with provided moderation status paginated.
+func (s *service) GetCommentsByModerationStatus(status ModerationStatus, limit, offset int) ([]Comment, error) {
+	if !status.Valid() {
+		return nil, fmt.Errorf("%w: invalid moderation status (%s)", ErrorBadRequest, status)
+	}
+
+	return s.Repository.GetCommentsByModerationStatus(status, limit, offset)
+}
+
+// ModerateComments Changes moderation status of up to MaxModeratedComments comments and returns how many were updated.
+func (s *service) ModerateComments(ids []string, status ModerationStatus) (int64, error) {
+	if !status.Valid() {
+		return 0, fmt.Errorf("%w: invalid moderation status (%s)", ErrorBadRequest, status)
+	}
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("%w: no comments to moderate", ErrorBadRequest)
+	}
+	if len(ids) > MaxModeratedComments {
+		return 0, fmt.Errorf("%w: at most %d comments can be moderated at once", ErrorBadRequest, MaxModeratedComments)
+	}
+
+	return s.Repository.UpdateCommentsModerationStatus(ids, status)
+}
+
+// PublishBlogPost Publishes a blog post, or schedules it when publishAt is in the future.
+func (s *service) PublishBlogPost(id string, publishAt *time.Time) error {
+	post, err := s.Repository.GetBlogPost(id, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return err
+	}
+
+	status := StatusPublished
+	at := time.Now().UTC()
+	if publishAt != nil && publishAt.After(at) {
+		status = StatusScheduled
+		at = publishAt.UTC()
+	}
+
+	if !post.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, post.Status, status)
+	}
+
+	return s.Repository.UpdateBlogPostStatus(post.ID, status, &at)
+}
+
+// ArchiveBlogPost Archives a blog post.
+func (s *service) ArchiveBlogPost(id string) error {
+	post, err := s.Repository.GetBlogPost(id, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return err
+	}
+
+	if !post.Status.CanTransitionTo(StatusArchived) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, post.Status, StatusArchived)
+	}
+
+	return s.Repository.UpdateBlogPostStatus(post.ID, StatusArchived, post.PublishAt)
+}
+
+// PublishDueBlogPosts Publishes scheduled blog posts due at provided time and returns how many were published.
+func (s *service) PublishDueBlogPosts(now time.Time) (int64, error) {
+	return s.Repository.PublishDueBlogPosts(now.UTC())
+}
+
+// DeleteBlogPost Moves a blog post to trash.
+func (s *service) DeleteBlogPost(id string) error {
+	post, err := s.Repository.GetBlogPost(id, ReadOptions{IncludeUnpublished: true})
+	if err != nil {
+		return err
+	}
+
+	return s.Repository.DeleteBlogPost(post.ID, time.Now().UTC())
+}
+
+// DeleteComment Moves a comment of a blog post to trash along with all its replies.
+func (s *service) DeleteComment(blogPostID, commentID string) error {
+	post, err := s.Repository.GetBlogPost(blogPostID, ReadOptions{IncludeUnpublished: true})
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	return s.Repository.CreateComment(blogPostID, text)
+	return s.Repository.DeleteComment(post.ID, commentID, time.Now().UTC())
+}
+
+// GetDeletedBlogPosts Returns blog posts in trash paginated, most recently deleted first.
+func (s *service) GetDeletedBlogPosts(limit, offset int) ([]BlogPost, error) {
+	return s.Repository.GetDeletedBlogPosts(limit, offset)
+}
+
+// GetDeletedComments Returns comments in trash paginated, most recently deleted first.
+func (s *service) GetDeletedComments(limit, offset int) ([]Comment, error) {
+	return s.Repository.GetDeletedComments(limit, offset)
+}
+
+// RestoreBlogPost Takes a blog post out of trash.
+func (s *service) RestoreBlogPost(id string) error {
+	return s.Repository.RestoreBlogPost(id)
+}
+
+// RestoreComment Takes a comment out of trash along with the replies deleted with it.
+func (s *service) RestoreComment(commentID string) error {
+	return s.Repository.RestoreComment(commentID)
+}
+
+// PurgeTrash Permanently deletes blog posts and comments in trash for longer than the retention window
+// at provided time, and returns how many were deleted.
+func (s *service) PurgeTrash(now time.Time) (int64, error) {
+	return s.Repository.PurgeDeleted(now.UTC().Add(-s.Config.TrashRetention))
+}
+
+// ReindexBlogPosts Renders again HTML and excerpt of every blog post from its content, trash included, makes
+// again the slugs backfilled by migrations, and returns how many blog posts changed. Used when rendering or slugs
+// change, or for posts rendered by migrations.
+func (s *service) ReindexBlogPosts() (int64, error) {
+	return s.Repository.ReindexBlogPosts(renderPost)
+}
+
+// ExportContent Calls fn with every blog post followed by its comments, as they are read. Trash is not exported.
+func (s *service) ExportContent(fn func(ExportRecord) error) error {
+	return s.Repository.ExportContent(fn)
+}
+
+// ImportContent Imports NDJSON records read from r in batches of ImportBatchSize lines, reporting lines that
+// could not be imported. Records are validated and rendered again, and imported posts and comments are given
+// new IDs while keeping them linked. Nothing is stored on dry runs.
+func (s *service) ImportContent(r io.Reader, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{DryRun: dryRun, Errors: []ImportError{}}
+
+	// lines Line of every record of the batch being stored.
+	var lines []int
+	batches := func(yield func([]ExportRecord) bool) {
+		var batch []ExportRecord
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, MaxImportLineSize)
+		line := 0
+		for scanner.Scan() {
+			line++
+			data := bytes.TrimSpace(scanner.Bytes())
+			if len(data) == 0 {
+				continue
+			}
+
+			var record ExportRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				result.Errors = append(result.Errors, ImportError{Line: line, Message: fmt.Sprintf("invalid JSON: %s", err)})
+				continue
+			}
+			if err := prepareImportRecord(&record); err != nil {
+				result.Errors = append(result.Errors, ImportError{Line: line, Message: err.Error()})
+				continue
+			}
+
+			batch = append(batch, record)
+			lines = append(lines, line)
+			if len(batch) == ImportBatchSize {
+				if !yield(batch) {
+					return
+				}
+				batch, lines = batch[:0], lines[:0]
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			// Lines after an unreadable one cannot be told apart, so the import stops there.
+			result.Errors = append(result.Errors, ImportError{Line: line + 1, Message: err.Error()})
+		}
+
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+
+	err := s.Repository.ImportRecords(batches, dryRun, func(records []ExportRecord, errs []error) {
+		for i, err := range errs {
+			switch {
+			case err != nil:
+				result.Errors = append(result.Errors, ImportError{Line: lines[i], Message: err.Error()})
+			case records[i].Type == RecordPost:
+				result.Posts++
+			default:
+				result.Comments++
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage errors are only known once their batch is stored, after the errors of later invalid lines.
+	slices.SortStableFunc(result.Errors, func(a, b ImportError) int {
+		return a.Line - b.Line
+	})
+
+	return result, nil
+}
+
+// prepareImportRecord Validates an imported record, filling the defaults of new posts and comments.
+// Imported HTML and excerpts are never trusted, they are rendered from the content again.
+func prepareImportRecord(record *ExportRecord) error {
+	switch record.Type {
+	case RecordPost:
+		post := record.Post
+		if post == nil {
+			return fmt.Errorf("%w: post record without post", ErrorBadRequest)
+		}
+		if post.Title == "" || post.Content == "" {
+			return fmt.Errorf("%w: missing title or content", ErrorBadRequest)
+		}
+
+		if post.ContentFormat == "" {
+			post.ContentFormat = ContentFormatText
+		}
+		if !post.ContentFormat.Valid() {
+			return fmt.Errorf("%w: invalid content format (%s)", ErrorBadRequest, post.ContentFormat)
+		}
+		if post.CommentModeration != "" && !post.CommentModeration.Valid() {
+			return fmt.Errorf("%w: invalid comment moderation (%s)", ErrorBadRequest, post.CommentModeration)
+		}
+
+		if post.Status == "" {
+			post.Status = StatusDraft
+		}
+		if !post.Status.Valid() {
+			return fmt.Errorf("%w: invalid status (%s)", ErrorBadRequest, post.Status)
+		}
+		if post.Status == StatusScheduled && post.PublishAt == nil {
+			return fmt.Errorf("%w: scheduled posts require publish_at", ErrorBadRequest)
+		}
+		if post.PublishAt != nil {
+			publishAt := post.PublishAt.UTC()
+			post.PublishAt = &publishAt
+		}
+
+		if post.Slug == "" {
+			post.Slug = post.Title
+		}
+		post.Slug = postSlug(post.Slug)
+		post.Author = strings.TrimSpace(post.Author)
+		post.Tags = slugifyTags(post.Tags)
+		post.DeletedAt = nil
+		post.Comments = nil
+
+		return renderPost(post)
+	case RecordComment:
+		comment := record.Comment
+		if comment == nil {
+			return fmt.Errorf("%w: comment record without comment", ErrorBadRequest)
+		}
+		if strings.TrimSpace(comment.CommentText) == "" {
+			return fmt.Errorf("%w: missing comment text", ErrorBadRequest)
+		}
+		if comment.BlogPostID == "" {
+			return fmt.Errorf("%w: missing blog_post_id", ErrorBadRequest)
+		}
+
+		if comment.ModerationStatus == "" {
+			comment.ModerationStatus = ModerationApproved
+		}
+		if !comment.ModerationStatus.Valid() {
+			return fmt.Errorf("%w: invalid moderation status (%s)", ErrorBadRequest, comment.ModerationStatus)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown record type (%s)", ErrorBadRequest, record.Type)
 }
@@ -2,14 +2,22 @@ package posts
 
 import (
 	"errors"
+	"fmt"
+	"iter"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_service_GetAllBlogPosts(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name    string
 		setup   func(m *MocksRepository)
+		opts    ReadOptions
 		limit   int
 		offset  int
 		want    []BlogPost
@@ -18,17 +26,46 @@ func Test_service_GetAllBlogPosts(t *testing.T) {
 		{
 			name: "success",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().GetAllBlogPosts(10, 0).Return([]BlogPost{{ID: "1", Title: "A", Content: "B"}}, nil)
+				m.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return([]BlogPost{{ID: "1", Title: "A", Content: "B"}}, nil)
+			},
+			limit:   10,
+			offset:  0,
+			want:    []BlogPost{{ID: "1", Title: "A", Content: "B"}},
+			wantErr: false,
+		},
+		{
+			name: "success_tags_normalized",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{Tags: []string{"go"}, MatchAllTags: true}).Return([]BlogPost{{ID: "1", Title: "A", Content: "B"}}, nil)
 			},
+			opts:    ReadOptions{Tags: []string{"Go"}, MatchAllTags: true},
 			limit:   10,
 			offset:  0,
 			want:    []BlogPost{{ID: "1", Title: "A", Content: "B"}},
 			wantErr: false,
 		},
+		{
+			name:    "invalid_tag_filter",
+			opts:    ReadOptions{Tags: []string{"go", "!!"}},
+			limit:   10,
+			wantErr: true,
+		},
+		{
+			name:    "invalid_status_filter",
+			opts:    ReadOptions{Filter: ListFilter{Status: "hidden"}},
+			limit:   10,
+			wantErr: true,
+		},
+		{
+			name:    "invalid_created_range",
+			opts:    ReadOptions{Filter: ListFilter{CreatedAfter: &after, CreatedBefore: &after}},
+			limit:   10,
+			wantErr: true,
+		},
 		{
 			name: "repo error",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().GetAllBlogPosts(10, 0).Return(nil, errors.New("fail"))
+				m.EXPECT().GetAllBlogPosts(10, 0, ReadOptions{}).Return(nil, errors.New("fail"))
 			},
 			limit:   10,
 			offset:  0,
@@ -43,7 +80,7 @@ func Test_service_GetAllBlogPosts(t *testing.T) {
 				tt.setup(repo)
 			}
 			s := &service{Repository: repo}
-			got, err := s.GetAllBlogPosts(tt.limit, tt.offset)
+			got, err := s.GetAllBlogPosts(tt.limit, tt.offset, tt.opts)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetAllBlogPosts() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -65,7 +102,7 @@ func Test_service_GetBlogPost(t *testing.T) {
 		{
 			name: "success",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().GetBlogPost("1").Return(&BlogPost{ID: "1", Title: "T", Content: "C"}, nil)
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1", Title: "T", Content: "C"}, nil)
 			},
 			id:      "1",
 			want:    &BlogPost{ID: "1", Title: "T", Content: "C"},
@@ -74,7 +111,7 @@ func Test_service_GetBlogPost(t *testing.T) {
 		{
 			name: "repo error",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().GetBlogPost("1").Return(nil, errors.New("fail"))
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(nil, errors.New("fail"))
 			},
 			id:      "1",
 			want:    nil,
@@ -88,7 +125,7 @@ func Test_service_GetBlogPost(t *testing.T) {
 				tt.setup(repo)
 			}
 			s := &service{Repository: repo}
-			got, err := s.GetBlogPost(tt.id)
+			got, err := s.GetBlogPost(tt.id, ReadOptions{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetBlogPost() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -100,32 +137,105 @@ func Test_service_GetBlogPost(t *testing.T) {
 }
 
 func Test_service_CreateBlogPost(t *testing.T) {
+	publishAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	localPublishAt := publishAt.In(time.FixedZone("CEST", 2*60*60))
+	pastPublishAt := time.Now().Add(-time.Minute)
+
 	tests := []struct {
 		name    string
 		setup   func(m *MocksRepository)
-		title   string
-		content string
-		want    int64
+		request CreatePostRequest
+		want    string
 		wantErr bool
 	}{
 		{
-			name: "success",
+			name: "success_draft_by_default",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPost(BlogPost{Slug: "t", Title: "T", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C", Status: StatusDraft}).Return("42", nil)
+			},
+			request: CreatePostRequest{Title: "T", Content: "C"},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name: "success_tags_normalized",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPost(BlogPost{Slug: "t", Title: "T", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C", Status: StatusDraft, Tags: []string{"go", "sq-lite"}}).Return("42", nil)
+			},
+			request: CreatePostRequest{Title: "T", Content: "C", Tags: []string{"Go", " go ", "Sq Lite", "!!"}},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name: "success_markdown_rendered",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPost(BlogPost{Slug: "t", Title: "T", Content: "# C", ContentFormat: ContentFormatMarkdown, ContentHTML: "<h1>C</h1>\n", Excerpt: "C", Status: StatusDraft}).Return("42", nil)
+			},
+			request: CreatePostRequest{Title: "T", Content: "# C", ContentFormat: ContentFormatMarkdown},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name:    "invalid_content_format",
+			request: CreatePostRequest{Title: "T", Content: "C", ContentFormat: "html"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "success_scheduled_when_publish_at_provided",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPost(BlogPost{Slug: "t", Title: "T", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C", Status: StatusScheduled, PublishAt: &publishAt}).Return("42", nil)
+			},
+			request: CreatePostRequest{Title: "T", Content: "C", PublishAt: &publishAt},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name:    "publish_at_in_the_past",
+			request: CreatePostRequest{Title: "T", Content: "C", Status: StatusScheduled, PublishAt: &pastPublishAt},
+			wantErr: true,
+		},
+		{
+			name: "success_publish_at_stored_in_utc",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPost(mock.MatchedBy(func(post BlogPost) bool {
+					return post.PublishAt.Location() == time.UTC && post.PublishAt.Equal(publishAt)
+				})).Return("42", nil)
+			},
+			request: CreatePostRequest{Title: "T", Content: "C", PublishAt: &localPublishAt},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name: "success_published_now",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().CreateBlogPost("T", "C").Return(int64(42), nil)
+				m.EXPECT().CreateBlogPost(mock.MatchedBy(func(post BlogPost) bool {
+					return post.Status == StatusPublished && post.PublishAt != nil
+				})).Return("42", nil)
 			},
-			title:   "T",
-			content: "C",
-			want:    42,
+			request: CreatePostRequest{Title: "T", Content: "C", Status: StatusPublished},
+			want:    "42",
 			wantErr: false,
 		},
+		{
+			name:    "scheduled_without_publish_at",
+			request: CreatePostRequest{Title: "T", Content: "C", Status: StatusScheduled},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_status",
+			request: CreatePostRequest{Title: "T", Content: "C", Status: StatusArchived},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "repo error",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().CreateBlogPost("T", "C").Return(int64(0), errors.New("fail"))
+				m.EXPECT().CreateBlogPost(BlogPost{Slug: "t", Title: "T", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C", Status: StatusDraft}).Return("", errors.New("fail"))
 			},
-			title:   "T",
-			content: "C",
-			want:    0,
+			request: CreatePostRequest{Title: "T", Content: "C"},
+			want:    "",
 			wantErr: true,
 		},
 	}
@@ -136,7 +246,7 @@ func Test_service_CreateBlogPost(t *testing.T) {
 				tt.setup(repo)
 			}
 			s := &service{Repository: repo}
-			got, err := s.CreateBlogPost(tt.title, tt.content)
+			got, err := s.CreateBlogPost(tt.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateBlogPost() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -153,39 +263,39 @@ func Test_service_CreateComment(t *testing.T) {
 		setup      func(m *MocksRepository)
 		blogPostID string
 		text       string
-		want       int64
+		want       string
 		wantErr    bool
 	}{
 		{
 			name: "success",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().GetBlogPost("1").Return(&BlogPost{ID: "1"}, nil)
-				m.EXPECT().CreateComment("1", "comment").Return(int64(99), nil)
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().CreateComment("1", Comment{CommentText: "comment", ModerationStatus: ModerationApproved}).Return("99", nil)
 			},
 			blogPostID: "1",
 			text:       "comment",
-			want:       99,
+			want:       "99",
 			wantErr:    false,
 		},
 		{
 			name: "get post error",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().GetBlogPost("1").Return(nil, errors.New("not found"))
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(nil, errors.New("not found"))
 			},
 			blogPostID: "1",
 			text:       "comment",
-			want:       0,
+			want:       "",
 			wantErr:    true,
 		},
 		{
 			name: "create comment error",
 			setup: func(m *MocksRepository) {
-				m.EXPECT().GetBlogPost("1").Return(&BlogPost{ID: "1"}, nil)
-				m.EXPECT().CreateComment("1", "comment").Return(int64(0), errors.New("fail"))
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().CreateComment("1", Comment{CommentText: "comment", ModerationStatus: ModerationApproved}).Return("", errors.New("fail"))
 			},
 			blogPostID: "1",
 			text:       "comment",
-			want:       0,
+			want:       "",
 			wantErr:    true,
 		},
 	}
@@ -206,3 +316,985 @@ func Test_service_CreateComment(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_PublishBlogPost(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC()
+
+	tests := []struct {
+		name      string
+		setup     func(m *MocksRepository)
+		id        string
+		publishAt *time.Time
+		wantErr   error
+	}{
+		{
+			name: "success_publish_now",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Status: StatusDraft}, nil)
+				m.EXPECT().UpdateBlogPostStatus("1", StatusPublished, mock.Anything).Return(nil)
+			},
+			id:      "1",
+			wantErr: nil,
+		},
+		{
+			name: "success_schedule",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Status: StatusDraft}, nil)
+				m.EXPECT().UpdateBlogPostStatus("1", StatusScheduled, &future).Return(nil)
+			},
+			id:        "1",
+			publishAt: &future,
+			wantErr:   nil,
+		},
+		{
+			name: "already_published",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Status: StatusPublished}, nil)
+			},
+			id:      "1",
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name: "not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true}).Return(nil, ErrBlogPostNotFound)
+			},
+			id:      "1",
+			wantErr: ErrBlogPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo}
+			err := s.PublishBlogPost(tt.id, tt.publishAt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PublishBlogPost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_ArchiveBlogPost(t *testing.T) {
+	publishAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		id      string
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Status: StatusPublished, PublishAt: &publishAt}, nil)
+				m.EXPECT().UpdateBlogPostStatus("1", StatusArchived, &publishAt).Return(nil)
+			},
+			id:      "1",
+			wantErr: nil,
+		},
+		{
+			name: "already_archived",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Status: StatusArchived}, nil)
+			},
+			id:      "1",
+			wantErr: ErrInvalidStatusTransition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo}
+			err := s.ArchiveBlogPost(tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ArchiveBlogPost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_PublishDueBlogPosts(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := NewMocksRepository(t)
+	repo.EXPECT().PublishDueBlogPosts(now).Return(int64(3), nil)
+
+	s := &service{Repository: repo}
+	got, err := s.PublishDueBlogPosts(now)
+	if err != nil {
+		t.Errorf("PublishDueBlogPosts() error = %v", err)
+	}
+	if got != 3 {
+		t.Errorf("PublishDueBlogPosts() = %v, want %v", got, 3)
+	}
+}
+
+func Test_service_CreateReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		want    string
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetComment("1", "5").Return(&Comment{ID: "5", ModerationStatus: ModerationApproved}, nil)
+				m.EXPECT().CreateComment("1", Comment{ParentID: "5", CommentText: "reply", ModerationStatus: ModerationApproved}).Return("6", nil)
+			},
+			want:    "6",
+			wantErr: nil,
+		},
+		{
+			name: "parent_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetComment("1", "5").Return(nil, ErrCommentNotFound)
+			},
+			want:    "",
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name: "parent_pending_moderation",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetComment("1", "5").Return(&Comment{ID: "5", ModerationStatus: ModerationPending}, nil)
+			},
+			want:    "",
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name: "post_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(nil, ErrBlogPostNotFound)
+			},
+			want:    "",
+			wantErr: ErrBlogPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo}
+			got, err := s.CreateReply("1", "5", "reply")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateReply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CreateReply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetComments(t *testing.T) {
+	thread := []Comment{
+		{ID: "5", CommentText: "root", ReplyCount: 1},
+		{ID: "6", CommentText: "reply", ParentID: "5", Depth: 1},
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		query   CommentsQuery
+		want    []Comment
+		wantErr error
+	}{
+		{
+			name: "success_top_level",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetComments("1", CommentsQuery{MaxDepth: 3, MaxReplies: MaxCommentReplies, Limit: 10}).Return(thread, nil)
+			},
+			query:   CommentsQuery{MaxDepth: 3, Limit: 10},
+			want:    thread,
+			wantErr: nil,
+		},
+		{
+			name: "success_replies",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetComment("1", "5").Return(&Comment{ID: "5", ModerationStatus: ModerationApproved}, nil)
+				m.EXPECT().GetComments("1", CommentsQuery{ParentID: "5", MaxDepth: 3, MaxReplies: MaxCommentReplies, Limit: 10}).Return(thread[1:], nil)
+			},
+			query:   CommentsQuery{ParentID: "5", MaxDepth: 3, Limit: 10},
+			want:    thread[1:],
+			wantErr: nil,
+		},
+		{
+			name: "success_replies_capped",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetComments("1", CommentsQuery{MaxDepth: 3, MaxReplies: MaxCommentReplies, Limit: 10}).Return(thread, nil)
+			},
+			query:   CommentsQuery{MaxDepth: 3, MaxReplies: 1000, Limit: 10},
+			want:    thread,
+			wantErr: nil,
+		},
+		{
+			name: "parent_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetComment("1", "5").Return(nil, ErrCommentNotFound)
+			},
+			query:   CommentsQuery{ParentID: "5", MaxDepth: 3, Limit: 10},
+			want:    nil,
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo}
+			got, err := s.GetComments("1", tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetComments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetComments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_CreateComment_moderation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ServiceConfig
+		post   *BlogPost
+		want   ModerationStatus
+	}{
+		{
+			name: "approved_without_defaults",
+			post: &BlogPost{ID: "1"},
+			want: ModerationApproved,
+		},
+		{
+			name:   "global_default",
+			config: ServiceConfig{DefaultCommentModeration: ModerationPending},
+			post:   &BlogPost{ID: "1"},
+			want:   ModerationPending,
+		},
+		{
+			name:   "post_default_overrides_global_default",
+			config: ServiceConfig{DefaultCommentModeration: ModerationPending},
+			post:   &BlogPost{ID: "1", CommentModeration: ModerationApproved},
+			want:   ModerationApproved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			repo.EXPECT().GetBlogPost("1", ReadOptions{}).Return(tt.post, nil)
+			repo.EXPECT().CreateComment("1", Comment{CommentText: "comment", ModerationStatus: tt.want}).Return("1", nil)
+
+			s := &service{Repository: repo, Config: tt.config}
+			if _, err := s.CreateComment("1", "comment"); err != nil {
+				t.Errorf("CreateComment() error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_service_GetCommentsByModerationStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		status  ModerationStatus
+		want    []Comment
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetCommentsByModerationStatus(ModerationPending, 10, 0).Return([]Comment{{ID: "5"}}, nil)
+			},
+			status:  ModerationPending,
+			want:    []Comment{{ID: "5"}},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid_status",
+			status:  ModerationStatus("unknown"),
+			want:    nil,
+			wantErr: ErrorBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo}
+			got, err := s.GetCommentsByModerationStatus(tt.status, 10, 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCommentsByModerationStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCommentsByModerationStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_ModerateComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		ids     []string
+		status  ModerationStatus
+		want    int64
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().UpdateCommentsModerationStatus([]string{"5", "6"}, ModerationSpam).Return(int64(2), nil)
+			},
+			ids:     []string{"5", "6"},
+			status:  ModerationSpam,
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name:    "no_ids",
+			status:  ModerationApproved,
+			want:    0,
+			wantErr: ErrorBadRequest,
+		},
+		{
+			name:    "invalid_status",
+			ids:     []string{"5"},
+			status:  ModerationStatus("unknown"),
+			want:    0,
+			wantErr: ErrorBadRequest,
+		},
+		{
+			name:    "too_many_ids",
+			ids:     make([]string, MaxModeratedComments+1),
+			status:  ModerationApproved,
+			want:    0,
+			wantErr: ErrorBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo}
+			got, err := s.ModerateComments(tt.ids, tt.status)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ModerateComments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ModerateComments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_content_filters(t *testing.T) {
+	t.Run("rejected_post", func(t *testing.T) {
+		repo := NewMocksRepository(t)
+		filter := NewMocksContentFilter(t)
+		filter.EXPECT().Check(Content{Kind: ContentPost, Title: "T", Text: "C"}).Return(FilterResult{Action: FilterReject, Reasons: []string{"nope"}}, nil)
+
+		s := &service{Repository: repo, Config: ServiceConfig{ContentFilter: filter}}
+		_, err := s.CreateBlogPost(CreatePostRequest{Title: "T", Content: "C", Status: StatusPublished})
+
+		var rejectedErr *ContentRejectedError
+		if !errors.As(err, &rejectedErr) || !reflect.DeepEqual(rejectedErr.Reasons, []string{"nope"}) {
+			t.Errorf("CreateBlogPost() error = %v, want content rejected", err)
+		}
+	})
+
+	t.Run("moderated_post_is_kept_as_draft", func(t *testing.T) {
+		repo := NewMocksRepository(t)
+		repo.EXPECT().CreateBlogPost(BlogPost{Slug: "t", Title: "T", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C", Status: StatusDraft}).Return("1", nil)
+		filter := NewMocksContentFilter(t)
+		filter.EXPECT().Check(Content{Kind: ContentPost, Title: "T", Text: "C"}).Return(FilterResult{Action: FilterModerate}, nil)
+
+		s := &service{Repository: repo, Config: ServiceConfig{ContentFilter: filter}}
+		if _, err := s.CreateBlogPost(CreatePostRequest{Title: "T", Content: "C", Status: StatusPublished}); err != nil {
+			t.Errorf("CreateBlogPost() error = %v", err)
+		}
+	})
+
+	t.Run("moderated_comment_is_pending", func(t *testing.T) {
+		repo := NewMocksRepository(t)
+		repo.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1", CommentModeration: ModerationApproved}, nil)
+		repo.EXPECT().CreateComment("1", Comment{CommentText: "C", ModerationStatus: ModerationPending}).Return("1", nil)
+		filter := NewMocksContentFilter(t)
+		filter.EXPECT().Check(Content{Kind: ContentComment, BlogPostID: "1", Text: "C"}).Return(FilterResult{Action: FilterModerate}, nil)
+
+		s := &service{Repository: repo, Config: ServiceConfig{ContentFilter: filter}}
+		if _, err := s.CreateComment("1", "C"); err != nil {
+			t.Errorf("CreateComment() error = %v", err)
+		}
+	})
+
+	t.Run("rejected_reply", func(t *testing.T) {
+		repo := NewMocksRepository(t)
+		repo.EXPECT().GetBlogPost("1", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+		repo.EXPECT().GetComment("1", "5").Return(&Comment{ID: "5", ModerationStatus: ModerationApproved}, nil)
+		filter := NewMocksContentFilter(t)
+		filter.EXPECT().Check(Content{Kind: ContentComment, BlogPostID: "1", Text: "C"}).Return(FilterResult{Action: FilterReject}, nil)
+
+		s := &service{Repository: repo, Config: ServiceConfig{ContentFilter: filter}}
+		if _, err := s.CreateReply("1", "5", "C"); !errors.Is(err, ErrContentRejected) {
+			t.Errorf("CreateReply() error = %v, want %v", err, ErrContentRejected)
+		}
+	})
+}
+
+func Test_service_GetTags(t *testing.T) {
+	repo := NewMocksRepository(t)
+	repo.EXPECT().GetTags(ReadOptions{}).Return([]Tag{{Slug: "go", PostCount: 2}}, nil)
+
+	s := &service{Repository: repo}
+	got, err := s.GetTags(ReadOptions{})
+	if err != nil {
+		t.Errorf("GetTags() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []Tag{{Slug: "go", PostCount: 2}}) {
+		t.Errorf("GetTags() = %v", got)
+	}
+}
+
+func Test_service_UpdateBlogPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		request UpdatePostRequest
+		wantErr error
+	}{
+		{
+			name: "success_renamed",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("old-title", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Slug: "old-title", Title: "Old title", ContentFormat: ContentFormatText}, nil)
+				m.EXPECT().UpdateBlogPost(BlogPost{ID: "1", Slug: "new-title", Title: "New title", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C"}).Return(nil)
+			},
+			request: UpdatePostRequest{Title: "New title", Content: "C"},
+			wantErr: nil,
+		},
+		{
+			name: "success_slug_kept_when_words_do_not_change",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("old-title", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Slug: "old-title-2", Title: "Old title", ContentFormat: ContentFormatText}, nil)
+				m.EXPECT().UpdateBlogPost(BlogPost{ID: "1", Slug: "old-title-2", Title: "Old title!", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C"}).Return(nil)
+			},
+			request: UpdatePostRequest{Title: "Old title!", Content: "C"},
+			wantErr: nil,
+		},
+		{
+			name: "success_format_changed",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("old-title", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Slug: "old-title", Title: "Old title", ContentFormat: ContentFormatText}, nil)
+				m.EXPECT().UpdateBlogPost(BlogPost{ID: "1", Slug: "old-title", Title: "Old title", Content: "**C**", ContentFormat: ContentFormatMarkdown, ContentHTML: "<p><strong>C</strong></p>\n", Excerpt: "C"}).Return(nil)
+			},
+			request: UpdatePostRequest{Title: "Old title", Content: "**C**", ContentFormat: ContentFormatMarkdown},
+			wantErr: nil,
+		},
+		{
+			name: "invalid_format",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("old-title", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1", Slug: "old-title", Title: "Old title", ContentFormat: ContentFormatText}, nil)
+			},
+			request: UpdatePostRequest{Title: "Old title", Content: "C", ContentFormat: "html"},
+			wantErr: ErrorBadRequest,
+		},
+		{
+			name: "not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("old-title", ReadOptions{IncludeUnpublished: true}).Return(nil, ErrBlogPostNotFound)
+			},
+			request: UpdatePostRequest{Title: "New title", Content: "C"},
+			wantErr: ErrBlogPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo}
+			err := s.UpdateBlogPost("old-title", tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateBlogPost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_DiffRevisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		want    string
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("my-post", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetRevision("1", 1).Return(&Revision{Number: 1, Title: "T", Content: "a\nb\nc"}, nil)
+				m.EXPECT().GetRevision("1", 2).Return(&Revision{Number: 2, Title: "T2", Content: "a\nB\nc"}, nil)
+			},
+			want: "--- revision 1\n+++ revision 2\n@@ -1,5 +1,5 @@\n-T\n+T2\n \n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "revision_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("my-post", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetRevision("1", 1).Return(nil, ErrRevisionNotFound)
+			},
+			wantErr: ErrRevisionNotFound,
+		},
+		{
+			name: "post_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("my-post", ReadOptions{IncludeUnpublished: true}).Return(nil, ErrBlogPostNotFound)
+			},
+			wantErr: ErrBlogPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo}
+			got, err := s.DiffRevisions("my-post", 1, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DiffRevisions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DiffRevisions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_RestoreRevision(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				post := &BlogPost{ID: "1", Slug: "new-title", Title: "New title", Content: "new", ContentFormat: ContentFormatText}
+				m.EXPECT().GetBlogPost("new-title", ReadOptions{IncludeUnpublished: true}).Return(post, nil)
+				m.EXPECT().GetRevision("1", 1).Return(&Revision{Number: 1, Title: "Old title", Content: "*old*", ContentFormat: ContentFormatMarkdown}, nil)
+				m.EXPECT().GetBlogPost("1", ReadOptions{IncludeUnpublished: true}).Return(post, nil)
+				m.EXPECT().UpdateBlogPost(BlogPost{
+					ID:            "1",
+					Slug:          "old-title",
+					Title:         "Old title",
+					Content:       "*old*",
+					ContentFormat: ContentFormatMarkdown,
+					ContentHTML:   "<p><em>old</em></p>\n",
+					Excerpt:       "old",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "revision_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("new-title", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetRevision("1", 1).Return(nil, ErrRevisionNotFound)
+			},
+			wantErr: ErrRevisionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo}
+			err := s.RestoreRevision("new-title", 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RestoreRevision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_DeleteBlogPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("my-post", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().DeleteBlogPost("1", mock.AnythingOfType("time.Time")).Return(nil)
+			},
+		},
+		{
+			name: "not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("my-post", ReadOptions{IncludeUnpublished: true}).Return(nil, ErrBlogPostNotFound)
+			},
+			wantErr: ErrBlogPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo}
+			err := s.DeleteBlogPost("my-post")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteBlogPost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_DeleteComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("my-post", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().DeleteComment("1", "5", mock.AnythingOfType("time.Time")).Return(nil)
+			},
+		},
+		{
+			name: "comment_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("my-post", ReadOptions{IncludeUnpublished: true}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().DeleteComment("1", "5", mock.AnythingOfType("time.Time")).Return(ErrCommentNotFound)
+			},
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo}
+			err := s.DeleteComment("my-post", "5")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteComment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_PurgeTrash(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	repo := NewMocksRepository(t)
+	repo.EXPECT().PurgeDeleted(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)).Return(int64(4), nil)
+
+	s := &service{Repository: repo, Config: ServiceConfig{TrashRetention: 30 * 24 * time.Hour}}
+	got, err := s.PurgeTrash(now)
+	if err != nil {
+		t.Fatalf("PurgeTrash() error = %v", err)
+	}
+	if got != 4 {
+		t.Errorf("PurgeTrash() = %d, want 4", got)
+	}
+}
+
+func Test_service_ReindexBlogPosts(t *testing.T) {
+	repo := NewMocksRepository(t)
+	repo.EXPECT().ReindexBlogPosts(mock.Anything).RunAndReturn(func(render func(post *BlogPost) error) (int64, error) {
+		post := BlogPost{Content: "# Title\n\nSome *text*", ContentFormat: ContentFormatMarkdown, ContentHTML: "<p># Title</p>"}
+		if err := render(&post); err != nil {
+			return 0, err
+		}
+		if post.ContentHTML != "<h1>Title</h1>\n<p>Some <em>text</em></p>\n" || post.Excerpt != "Title Some text" {
+			t.Errorf("got rendered post %+v", post)
+		}
+		return 1, nil
+	})
+
+	s := &service{Repository: repo}
+	got, err := s.ReindexBlogPosts()
+	if err != nil {
+		t.Fatalf("ReindexBlogPosts() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("ReindexBlogPosts() = %d, want 1", got)
+	}
+}
+
+// importBatches Returns an ImportRecords stand-in storing the batches it reads with results, in order, and
+// checking their records with check when set.
+func importBatches(t *testing.T, check func(batch int, records []ExportRecord) bool, results ...[]error) func(iter.Seq[[]ExportRecord], bool, func([]ExportRecord, []error)) error {
+	return func(batches iter.Seq[[]ExportRecord], _ bool, fn func([]ExportRecord, []error)) error {
+		i := 0
+		for records := range batches {
+			if i == len(results) {
+				t.Fatalf("got batch %d, want %d batches", i, len(results))
+			}
+			if check != nil && !check(i, records) {
+				t.Errorf("got batch %d %+v", i, records)
+			}
+			if results[i] == nil {
+				return errors.New("fail")
+			}
+			fn(records, results[i])
+			i++
+		}
+		if i != len(results) {
+			t.Errorf("got %d batches, want %d", i, len(results))
+		}
+		return nil
+	}
+}
+
+func Test_service_ImportContent(t *testing.T) {
+	posts := strings.Repeat(`{"type":"post","post":{"id":"p","title":"T","content":"C"}}`+"\n", ImportBatchSize+1)
+	tests := []struct {
+		name    string
+		input   string
+		dryRun  bool
+		setup   func(t *testing.T, m *MocksRepository)
+		want    *ImportResult
+		wantErr bool
+	}{
+		{
+			name: "invalid_lines_reported",
+			input: `{"type":"post","post":{"id":"p1","title":"T","content":"C","content_html":"<script></script>"}}
+not json
+{"type":"post","post":{"title":"T","content":"C","status":"gone"}}
+
+{"type":"comment","comment":{"blog_post_id":"p9","comment_text":"hi"}}
+`,
+			dryRun: true,
+			setup: func(t *testing.T, m *MocksRepository) {
+				m.EXPECT().ImportRecords(mock.Anything, true, mock.Anything).RunAndReturn(importBatches(t,
+					func(_ int, records []ExportRecord) bool {
+						return len(records) == 2 &&
+							records[0].Post.ContentHTML == "<p>C</p>" && records[0].Post.Status == StatusDraft && records[0].Post.Slug == "t" &&
+							records[1].Comment.ModerationStatus == ModerationApproved
+					},
+					[]error{nil, fmt.Errorf("%w (p9)", ErrBlogPostNotFound)}))
+			},
+			want: &ImportResult{
+				DryRun: true,
+				Posts:  1,
+				Errors: []ImportError{
+					{Line: 2, Message: "invalid JSON: invalid character 'o' in literal null (expecting 'u')"},
+					{Line: 3, Message: "bad request: invalid status (gone)"},
+					{Line: 5, Message: "blog post not found (p9)"},
+				},
+			},
+		},
+		{
+			name:  "stored_in_batches",
+			input: posts + "not json\n" + `{"type":"comment","comment":{"blog_post_id":"p9","comment_text":"hi"}}`,
+			setup: func(t *testing.T, m *MocksRepository) {
+				m.EXPECT().ImportRecords(mock.Anything, false, mock.Anything).RunAndReturn(importBatches(t,
+					func(batch int, records []ExportRecord) bool {
+						return batch == 0 && len(records) == ImportBatchSize || batch == 1 && len(records) == 2
+					},
+					make([]error, ImportBatchSize), []error{nil, ErrBlogPostNotFound}))
+			},
+			want: &ImportResult{Posts: ImportBatchSize + 1, Errors: []ImportError{
+				{Line: ImportBatchSize + 2, Message: "invalid JSON: invalid character 'o' in literal null (expecting 'u')"},
+				{Line: ImportBatchSize + 3, Message: "blog post not found"},
+			}},
+		},
+		{
+			name:  "repo_error",
+			input: posts,
+			setup: func(t *testing.T, m *MocksRepository) {
+				m.EXPECT().ImportRecords(mock.Anything, false, mock.Anything).RunAndReturn(importBatches(t, nil, nil))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(t, repo)
+			s := &service{Repository: repo}
+			got, err := s.ImportContent(strings.NewReader(tt.input), tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ImportContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ImportContent() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_CreateBlogPosts(t *testing.T) {
+	valid := CreatePostRequest{Title: "T", Content: "C"}
+	post := BlogPost{Slug: "t", Title: "T", Content: "C", ContentFormat: ContentFormatText, ContentHTML: "<p>C</p>", Excerpt: "C", Status: StatusDraft}
+	tests := []struct {
+		name     string
+		requests []CreatePostRequest
+		atomic   bool
+		setup    func(m *MocksRepository)
+		want     []BatchResult
+		wantErr  error
+	}{
+		{
+			name:     "atomic_success",
+			requests: []CreatePostRequest{valid, valid},
+			atomic:   true,
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPosts([]BlogPost{post, post}, true).Return([]BatchResult{{ID: "1"}, {ID: "2"}}, nil)
+			},
+			want: []BatchResult{{ID: "1"}, {ID: "2"}},
+		},
+		{
+			name:     "atomic_aborted_by_invalid_post",
+			requests: []CreatePostRequest{valid, {Title: "T"}, {Content: "C"}},
+			atomic:   true,
+			setup:    func(m *MocksRepository) {},
+			wantErr:  &BatchItemError{Index: 1, Err: fmt.Errorf("%w: missing title or content", ErrorBadRequest)},
+		},
+		{
+			name:     "atomic_aborted_by_repo",
+			requests: []CreatePostRequest{valid, valid},
+			atomic:   true,
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPosts([]BlogPost{post, post}, true).Return(nil, &BatchItemError{Index: 1, Err: errors.New("fail")})
+			},
+			wantErr: &BatchItemError{Index: 1, Err: errors.New("fail")},
+		},
+		{
+			name:     "best_effort_skips_invalid_post",
+			requests: []CreatePostRequest{{Title: "T"}, valid},
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPosts([]BlogPost{post}, false).Return([]BatchResult{{ID: "2"}}, nil)
+			},
+			want: []BatchResult{
+				{Err: fmt.Errorf("%w: missing title or content", ErrorBadRequest)},
+				{ID: "2"},
+			},
+		},
+		{
+			name:     "repo_error",
+			requests: []CreatePostRequest{valid},
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateBlogPosts([]BlogPost{post}, false).Return(nil, errors.New("fail"))
+			},
+			wantErr: errors.New("fail"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo}
+			got, err := s.CreateBlogPosts(tt.requests, tt.atomic)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("CreateBlogPosts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateBlogPosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetCommentsAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		want    []Comment
+		wantErr error
+	}{
+		{
+			name: "success_by_slug",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("hello", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetCommentsAfter("1", "5", 10).Return([]Comment{{ID: "6"}}, nil)
+			},
+			want: []Comment{{ID: "6"}},
+		},
+		{
+			name: "post_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("hello", ReadOptions{}).Return(nil, ErrBlogPostNotFound)
+			},
+			wantErr: ErrBlogPostNotFound,
+		},
+		{
+			name: "comment_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetBlogPost("hello", ReadOptions{}).Return(&BlogPost{ID: "1"}, nil)
+				m.EXPECT().GetCommentsAfter("1", "5", 10).Return(nil, ErrCommentNotFound)
+			},
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo}
+			got, err := s.GetCommentsAfter("hello", "5", 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetCommentsAfter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCommentsAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetCommentsByBlogPosts(t *testing.T) {
+	query := CommentsQuery{Limit: 3, Offset: 1}
+	repo := NewMocksRepository(t)
+	repo.EXPECT().GetCommentsByBlogPosts([]string{"1", "2"}, query).Return(map[string][]Comment{"1": {{ID: "5"}}}, nil).Once()
+	s := &service{Repository: repo}
+
+	got, err := s.GetCommentsByBlogPosts([]string{"1", "2"}, query)
+	if err != nil || !reflect.DeepEqual(got, map[string][]Comment{"1": {{ID: "5"}}}) {
+		t.Errorf("GetCommentsByBlogPosts() = %v, %v", got, err)
+	}
+
+	// Nothing to read does not reach the repository.
+	got, err = s.GetCommentsByBlogPosts(nil, query)
+	if err != nil || len(got) != 0 {
+		t.Errorf("GetCommentsByBlogPosts(nil) = %v, %v", got, err)
+	}
+}
+
+func Test_service_GetRepliesByComments(t *testing.T) {
+	query := CommentsQuery{Limit: 3, IncludeUnapproved: true}
+	repo := NewMocksRepository(t)
+	repo.EXPECT().GetRepliesByComments([]string{"5"}, query).Return(nil, errors.New("boom")).Once()
+	s := &service{Repository: repo}
+
+	if _, err := s.GetRepliesByComments([]string{"5"}, query); err == nil {
+		t.Error("GetRepliesByComments() expected error")
+	}
+	if got, err := s.GetRepliesByComments([]string{}, query); err != nil || len(got) != 0 {
+		t.Errorf("GetRepliesByComments(empty) = %v, %v", got, err)
+	}
+}
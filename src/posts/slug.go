@@ -0,0 +1,145 @@
+package posts
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// fallbackSlug Slug used when nothing of the original text can be kept.
+	fallbackSlug = "post"
+)
+
+// transliterations Latin approximations of letters that do not decompose into a base ASCII letter.
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i", 'ħ': "h",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t",
+	'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Slugify Normalizes text into a lowercase and hyphen separated slug.
+// Accented Latin letters lose their accents and Cyrillic and Greek letters are transliterated, while letters and
+// digits of other scripts, such as CJK, are kept as they are. Other characters act as separators.
+func Slugify(text string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	write := func(s string) {
+		if s == "" {
+			return
+		}
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteString(s)
+	}
+
+	// kept Whether the last rune written was kept as is, so the marks following it belong to it.
+	kept := false
+	for _, r := range norm.NFC.String(strings.ToLower(text)) {
+		if latin, ok := transliterations[r]; ok {
+			write(latin)
+			kept = false
+			continue
+		}
+
+		// Decomposing splits accented letters into their base letter and combining marks, which are dropped.
+		decomposed := norm.NFD.String(string(r))
+		base, _ := utf8.DecodeRuneInString(decomposed)
+		_, transliterated := transliterations[base]
+		switch {
+		case unicode.IsMark(r):
+			// Marks of scripts kept as they are, such as Devanagari vowel signs, are part of their letter.
+			if kept && !pendingHyphen {
+				write(string(r))
+			}
+			continue
+		case (unicode.IsLetter(r) || unicode.IsDigit(r)) && base >= unicode.MaxASCII && !transliterated:
+			write(string(r))
+			kept = true
+			continue
+		}
+
+		kept = false
+		for _, d := range decomposed {
+			if latin, ok := transliterations[d]; ok {
+				write(latin)
+				continue
+			}
+			switch {
+			case d < unicode.MaxASCII && (unicode.IsLetter(d) || unicode.IsDigit(d)):
+				write(string(d))
+			case !unicode.Is(unicode.Mn, d):
+				pendingHyphen = b.Len() > 0
+			}
+		}
+	}
+	return b.String()
+}
+
+// postSlug Returns the base slug of a blog post title.
+// Numeric slugs are prefixed so they can never be mistaken for blog post IDs.
+func postSlug(title string) string {
+	slug := Slugify(title)
+	if slug == "" {
+		return fallbackSlug
+	}
+	if isNumeric(slug) {
+		return fallbackSlug + "-" + slug
+	}
+	return slug
+}
+
+// backfilledSlug Returns the slug the post slugs migration gave a blog post created before slugs, with the SQLite
+// string functions it was approximated with: spaces and slashes replaced and ASCII letters lowered, made unique with
+// the blog post key.
+func backfilledSlug(title string, key int64) string {
+	slug := strings.NewReplacer(" ", "-", "/", "-").Replace(strings.Trim(title, " "))
+	slug = strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, slug)
+	return fmt.Sprintf("%s-%d", strings.Trim(slug, "-"), key)
+}
+
+// isNumeric Reports whether s is made of ASCII digits only.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// slugifyTags Normalizes tags into unique slugs, dropping empty ones.
+func slugifyTags(tags []string) []string {
+	var slugs []string
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		slug := Slugify(tag)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+	return slugs
+}
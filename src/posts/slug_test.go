@@ -0,0 +1,52 @@
+package posts
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "lowercase", text: "Go", want: "go"},
+		{name: "separators_collapse", text: "  SQLite -- Tips & Tricks! ", want: "sqlite-tips-tricks"},
+		{name: "digits", text: "Go 1.25", want: "go-1-25"},
+		{name: "empty", text: " !? ", want: ""},
+		{name: "accents_removed", text: "Crème Brûlée à la Française", want: "creme-brulee-a-la-francaise"},
+		{name: "decomposed_accents_removed", text: "Cafe\u0301", want: "cafe"},
+		{name: "special_latin_letters", text: "Straße Øresund Łódź", want: "strasse-oresund-lodz"},
+		{name: "cyrillic", text: "Привет, мир", want: "privet-mir"},
+		{name: "cyrillic_decomposable", text: "Ёлка Йошкар", want: "yolka-yoshkar"},
+		{name: "greek", text: "Καλημέρα", want: "kalimera"},
+		{name: "other_scripts_kept", text: "Go 日本語 tips", want: "go-日本語-tips"},
+		{name: "hangul_kept_composed", text: "한국어 블로그", want: "한국어-블로그"},
+		{name: "arabic_kept", text: "مرحبا، بالعالم", want: "مرحبا-بالعالم"},
+		{name: "devanagari_marks_kept", text: "हिन्दी ब्लॉग", want: "हिन्दी-ब्लॉग"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slugify(tt.text); got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Hello World", want: "hello-world"},
+		{title: "2024", want: "post-2024"},
+		{title: "日本語", want: "日本語"},
+		{title: "!?", want: "post"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := postSlug(tt.title); got != tt.want {
+				t.Errorf("postSlug(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
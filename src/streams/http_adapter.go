@@ -0,0 +1,149 @@
+package streams
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// EventStreamContentType Content type of Server-Sent Events streams.
+	EventStreamContentType = "text/event-stream"
+	// ReplayBatchSize Comments read at once when resuming a stream.
+	ReplayBatchSize = 100
+)
+
+var (
+	errMapper = map[error]int{
+		posts.ErrBlogPostNotFound: http.StatusNotFound,
+		posts.ErrCommentNotFound:  http.StatusNotFound,
+		posts.ErrorBadRequest:     http.StatusBadRequest,
+	}
+)
+
+// httpAdapter Productive streams http adapter implementation
+type httpAdapter struct {
+	Service posts.Service
+	Hub     *Hub
+	Config  Config
+}
+
+// NewHTTPAdapter Returns new productive streams HTTP adapter implementation.
+func NewHTTPAdapter(service posts.Service, hub *Hub, cfg Config) (HTTPAdapter, error) {
+	if cfg.Heartbeat <= 0 {
+		return nil, fmt.Errorf("invalid heartbeat interval (%s)", cfg.Heartbeat)
+	}
+
+	return &httpAdapter{
+		Service: service,
+		Hub:     hub,
+		Config:  cfg,
+	}, nil
+}
+
+// StreamComments Streams new approved comments of specific published post as Server-Sent Events, each with the
+// comment ID as event ID. Streams resumed with `Last-Event-ID` first replay the comments created after that one.
+// Streams of clients falling behind are ended, so they resume where they left.
+func (a *httpAdapter) StreamComments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httputil.HandlerHTTPError(w, "streaming unsupported", fmt.Errorf("response writer cannot flush"), errMapper)
+		return
+	}
+
+	post, err := a.Service.GetBlogPost(id, posts.ReadOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error getting post with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	// Subscribing before replaying misses nothing, comments both replayed and received are sent once.
+	comments, unsubscribe := a.Hub.Subscribe(post.ID)
+	defer unsubscribe()
+
+	var replay []posts.Comment
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		replay, err = a.replay(post.ID, lastEventID)
+		if err != nil {
+			msg := fmt.Sprintf("unexpected error resuming comments of post with ID (%s) after (%s)", id, lastEventID)
+			httputil.HandlerHTTPError(w, msg, err, errMapper)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", EventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	sent := map[string]bool{}
+	for _, comment := range replay {
+		if err := writeComment(w, comment); err != nil {
+			return
+		}
+		sent[comment.ID] = true
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(a.Config.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case comment, ok := <-comments:
+			if !ok {
+				return
+			}
+			if sent[comment.ID] {
+				continue
+			}
+			if err := writeComment(w, comment); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// replay Returns every approved comment of a blog post created after the one with provided ID.
+func (a *httpAdapter) replay(blogPostID, lastEventID string) ([]posts.Comment, error) {
+	var replay []posts.Comment
+	for after := lastEventID; ; {
+		comments, err := a.Service.GetCommentsAfter(blogPostID, after, ReplayBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		replay = append(replay, comments...)
+		if len(comments) < ReplayBatchSize {
+			return replay, nil
+		}
+		after = comments[len(comments)-1].ID
+	}
+}
+
+// writeComment Writes a comment as a `comment` event identified by the comment ID.
+func writeComment(w http.ResponseWriter, comment posts.Comment) error {
+	data, err := json.Marshal(comment)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: comment\ndata: %s\n\n", comment.ID, data)
+	return err
+}
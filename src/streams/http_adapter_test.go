@@ -0,0 +1,162 @@
+package streams
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/go-chi/chi/v5"
+)
+
+// withURLParams Returns a copy of the request carrying chi URL params.
+func withURLParams(r *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func Test_httpAdapter_StreamComments(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(s *posts.MocksService)
+		lastEventID string
+		publish     []posts.Event
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name: "live_comments_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("hello", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1"}, nil)
+			},
+			publish:    []posts.Event{commentEvent("1", posts.ModerationApproved), commentEvent("2", posts.ModerationPending)},
+			wantStatus: http.StatusOK,
+			wantBody:   "id: 1\nevent: comment\ndata: {\"id\":\"1\",\"blog_post_id\":\"p1\",\"comment_text\":\"\",\"moderation_status\":\"approved\"}\n\n",
+		},
+		{
+			name: "resumed_without_duplicates_200",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("hello", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1"}, nil)
+				s.EXPECT().GetCommentsAfter("p1", "0", ReplayBatchSize).Return([]posts.Comment{{ID: "1", BlogPostID: "p1", ModerationStatus: posts.ModerationApproved}}, nil)
+			},
+			lastEventID: "0",
+			publish:     []posts.Event{commentEvent("1", posts.ModerationApproved), commentEvent("2", posts.ModerationApproved)},
+			wantStatus:  http.StatusOK,
+			wantBody: "id: 1\nevent: comment\ndata: {\"id\":\"1\",\"blog_post_id\":\"p1\",\"comment_text\":\"\",\"moderation_status\":\"approved\"}\n\n" +
+				"id: 2\nevent: comment\ndata: {\"id\":\"2\",\"blog_post_id\":\"p1\",\"comment_text\":\"\",\"moderation_status\":\"approved\"}\n\n",
+		},
+		{
+			name: "unknown_last_event_id_404",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("hello", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1"}, nil)
+				s.EXPECT().GetCommentsAfter("p1", "x", ReplayBatchSize).Return(nil, posts.ErrCommentNotFound)
+			},
+			lastEventID: "x",
+			wantStatus:  http.StatusNotFound,
+			wantBody:    "{\"message\":\"unexpected error resuming comments of post with ID (hello) after (x)\",\"cause\":\"comment not found\"}",
+		},
+		{
+			name: "post_not_found_404",
+			setup: func(s *posts.MocksService) {
+				s.EXPECT().GetBlogPost("hello", posts.ReadOptions{}).Return(nil, posts.ErrBlogPostNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error getting post with ID (hello)\",\"cause\":\"blog post not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := posts.NewMocksService(t)
+			tt.setup(service)
+			hub := NewHub(8)
+			a := &httpAdapter{Service: service, Hub: hub, Config: Config{Heartbeat: time.Hour}}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequestWithContext(ctx, http.MethodGet, "/posts/hello/comments/stream", nil)
+			if tt.lastEventID != "" {
+				request.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				a.StreamComments(recorder, withURLParams(request, map[string]string{"id": "hello"}))
+			}()
+
+			if tt.wantStatus == http.StatusOK {
+				waitForSubscriber(t, hub, "p1")
+				for _, event := range tt.publish {
+					hub.Handle(event)
+				}
+				// Dropping the subscriber ends the stream once it has written everything published.
+				hub.mu.Lock()
+				for ch := range hub.subscribers["p1"] {
+					hub.remove("p1", ch)
+				}
+				hub.mu.Unlock()
+			}
+			<-done
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusOK && !strings.HasPrefix(recorder.Header().Get("Content-Type"), EventStreamContentType) {
+				t.Errorf("got content type %q, want %q", recorder.Header().Get("Content-Type"), EventStreamContentType)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_StreamComments_heartbeat(t *testing.T) {
+	service := posts.NewMocksService(t)
+	service.EXPECT().GetBlogPost("p1", posts.ReadOptions{}).Return(&posts.BlogPost{ID: "p1"}, nil)
+	a := &httpAdapter{Service: service, Hub: NewHub(1), Config: Config{Heartbeat: 10 * time.Millisecond}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.StreamComments(w, withURLParams(r, map[string]string{"id": "p1"}))
+	}))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer res.Body.Close()
+
+	line := make([]byte, len(": heartbeat\n"))
+	if _, err := io.ReadFull(res.Body, line); err != nil {
+		t.Fatalf("failed to read heartbeat: %v", err)
+	}
+	if string(line) != ": heartbeat\n" {
+		t.Errorf("got %q, want heartbeat", line)
+	}
+}
+
+// waitForSubscriber Waits until the hub has a subscriber to a blog post.
+func waitForSubscriber(t *testing.T, hub *Hub, blogPostID string) {
+	t.Helper()
+	for range 100 {
+		hub.mu.Lock()
+		subscribed := len(hub.subscribers[blogPostID]) > 0
+		hub.mu.Unlock()
+		if subscribed {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no subscriber to blog post %s", blogPostID)
+}
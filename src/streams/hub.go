@@ -0,0 +1,75 @@
+package streams
+
+import (
+	"sync"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// Hub In-process pub/sub of comments as they are approved, by the public ID of their blog post.
+// Publishing never blocks: subscribers whose buffer is full are dropped, their channel closed,
+// so they can resume from the last comment they got.
+type Hub struct {
+	mu          sync.Mutex
+	buffer      int
+	subscribers map[string]map[chan posts.Comment]struct{}
+}
+
+// NewHub Returns new hub buffering up to buffer comments per subscriber.
+func NewHub(buffer int) *Hub {
+	return &Hub{
+		buffer:      buffer,
+		subscribers: map[string]map[chan posts.Comment]struct{}{},
+	}
+}
+
+// Subscribe Returns a channel receiving new comments of a blog post until the returned function is called,
+// or until the subscriber falls behind and its channel gets closed.
+func (h *Hub) Subscribe(blogPostID string) (<-chan posts.Comment, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan posts.Comment, h.buffer)
+	if h.subscribers[blogPostID] == nil {
+		h.subscribers[blogPostID] = map[chan posts.Comment]struct{}{}
+	}
+	h.subscribers[blogPostID][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(blogPostID, ch)
+	}
+}
+
+// Handle Fans comments out to the subscribers of their blog post once they are approved, either on creation or
+// by a moderator later, meant to be subscribed to the posts event bus.
+func (h *Hub) Handle(event posts.Event) {
+	if event.Type != posts.EventCommentCreated && event.Type != posts.EventCommentApproved || event.Comment == nil || !event.Public() {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[event.AggregateID] {
+		select {
+		case ch <- *event.Comment:
+		default:
+			h.remove(event.AggregateID, ch)
+		}
+	}
+}
+
+// remove Unsubscribes and closes a channel, unless already done. Callers must hold the lock.
+func (h *Hub) remove(blogPostID string, ch chan posts.Comment) {
+	if _, ok := h.subscribers[blogPostID][ch]; !ok {
+		return
+	}
+
+	delete(h.subscribers[blogPostID], ch)
+	if len(h.subscribers[blogPostID]) == 0 {
+		delete(h.subscribers, blogPostID)
+	}
+	close(ch)
+}
@@ -0,0 +1,71 @@
+package streams
+
+import (
+	"testing"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// commentEvent Returns the event of a created comment of published blog post "p1".
+func commentEvent(id string, status posts.ModerationStatus) posts.Event {
+	return posts.Event{
+		Type:        posts.EventCommentCreated,
+		AggregateID: "p1",
+		PostStatus:  posts.StatusPublished,
+		Comment:     &posts.Comment{ID: id, BlogPostID: "p1", ModerationStatus: status},
+	}
+}
+
+func TestHub_Handle(t *testing.T) {
+	hub := NewHub(2)
+	comments, unsubscribe := hub.Subscribe("p1")
+	defer unsubscribe()
+	other, unsubscribeOther := hub.Subscribe("p2")
+	defer unsubscribeOther()
+
+	approved := commentEvent("2", posts.ModerationApproved)
+	approved.Type = posts.EventCommentApproved
+
+	hub.Handle(commentEvent("1", posts.ModerationApproved))
+	hub.Handle(commentEvent("2", posts.ModerationPending))
+	hub.Handle(posts.Event{Type: posts.EventPostCreated, AggregateID: "p1", BlogPost: &posts.BlogPost{ID: "p1"}})
+	hub.Handle(approved)
+
+	for _, want := range []string{"1", "2"} {
+		if got := <-comments; got.ID != want {
+			t.Errorf("got comment %s, want %s", got.ID, want)
+		}
+	}
+	select {
+	case got := <-comments:
+		t.Errorf("got unexpected comment %s", got.ID)
+	case got := <-other:
+		t.Errorf("got comment %s of another post", got.ID)
+	default:
+	}
+}
+
+func TestHub_slowSubscriberDropped(t *testing.T) {
+	hub := NewHub(1)
+	slow, unsubscribeSlow := hub.Subscribe("p1")
+	fast, unsubscribeFast := hub.Subscribe("p1")
+	defer unsubscribeFast()
+
+	hub.Handle(commentEvent("1", posts.ModerationApproved))
+	<-fast
+	// Slow never reads, so its full buffer gets it dropped instead of blocking the publisher.
+	hub.Handle(commentEvent("2", posts.ModerationApproved))
+
+	if got := <-fast; got.ID != "2" {
+		t.Errorf("fast subscriber got comment %s, want 2", got.ID)
+	}
+	if got := <-slow; got.ID != "1" {
+		t.Errorf("slow subscriber got comment %s, want 1", got.ID)
+	}
+	if _, ok := <-slow; ok {
+		t.Error("slow subscriber channel not closed")
+	}
+
+	// Unsubscribing a dropped subscriber is harmless.
+	unsubscribeSlow()
+}
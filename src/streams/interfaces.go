@@ -0,0 +1,18 @@
+package streams
+
+import (
+	"net/http"
+	"time"
+)
+
+// HTTPAdapter Streams http adapter interface.
+type HTTPAdapter interface {
+	// StreamComments Streams new comments of specific post as Server-Sent Events.
+	StreamComments(http.ResponseWriter, *http.Request)
+}
+
+// Config Streams configuration.
+type Config struct {
+	// Heartbeat How often idle streams send a comment line, keeping connections and proxies alive.
+	Heartbeat time.Duration
+}
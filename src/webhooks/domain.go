@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// DeliveryStatus Stage of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending Delivery waiting for its next attempt.
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliveryDelivered Delivery acknowledged by its receiver with a 2xx response.
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed Delivery given up after running out of attempts.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Valid Reports whether delivery status is one of the known ones.
+func (s DeliveryStatus) Valid() bool {
+	switch s {
+	case DeliveryPending, DeliveryDelivered, DeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// Subscription Receiver URL subscribed to some event types.
+// Its secret signs every payload sent to it and is only exposed when the subscription is created.
+type Subscription struct {
+	ID        string            `json:"id"`
+	URL       string            `json:"url"`
+	Events    []posts.EventType `json:"events"`
+	Active    bool              `json:"active"`
+	Secret    string            `json:"secret,omitempty"`
+	CreatedAt time.Time         `json:"created_at,omitzero"`
+}
+
+// Delivery Event payload sent to a subscription, along with the outcome of its latest attempt.
+type Delivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      posts.EventType `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at,omitzero"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// DueDelivery Delivery due for an attempt along with where and how it is sent.
+type DueDelivery struct {
+	Delivery Delivery
+	URL      string
+	Secret   string
+}
+
+// Payload Body sent to receivers, the same for every subscription of an event.
+type Payload struct {
+	ID        string          `json:"id"`
+	Type      posts.EventType `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      any             `json:"data"`
+}
@@ -0,0 +1,149 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	errMapper = map[error]int{
+		ErrSubscriptionNotFound: http.StatusNotFound,
+		ErrDeliveryNotFound:     http.StatusNotFound,
+		ErrSubscriptionInactive: http.StatusConflict,
+		posts.ErrorBadRequest:   http.StatusBadRequest,
+	}
+)
+
+// httpAdapter Productive webhooks http adapter implementation
+type httpAdapter struct {
+	Service Service
+}
+
+// NewHTTPAdapter Returns new productive webhooks HTTP adapter implementation.
+func NewHTTPAdapter(service Service) (HTTPAdapter, error) {
+	return &httpAdapter{
+		Service: service,
+	}, nil
+}
+
+// CreateSubscription Creates new webhook subscription. The response is the only one carrying its secret.
+func (a *httpAdapter) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var requestBody SubscriptionRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error reading subscription creation body", err, errMapper)
+		return
+	}
+
+	subscription, err := a.Service.CreateSubscription(requestBody)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error creating subscription", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusCreated, subscription)
+}
+
+// GetSubscriptions Returns all webhook subscriptions.
+func (a *httpAdapter) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	p := httputil.GetPaginationParams(r)
+
+	subscriptions, err := a.Service.GetSubscriptions(p.Limit, p.Offset)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error getting subscriptions", err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetSubscriptionsResponse{
+		Subscriptions: subscriptions,
+		Pagination:    p,
+	})
+}
+
+// GetSubscription Returns single specific webhook subscription.
+func (a *httpAdapter) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	subscription, err := a.Service.GetSubscription(id)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error getting subscription with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, subscription)
+}
+
+// UpdateSubscription Updates specific webhook subscription. Its secret is rotated when a new one is provided.
+func (a *httpAdapter) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var requestBody SubscriptionRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		httputil.HandlerHTTPError(w, "unexpected error reading subscription update body", err, errMapper)
+		return
+	}
+
+	subscription, err := a.Service.UpdateSubscription(id, requestBody)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error updating subscription with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, subscription)
+}
+
+// DeleteSubscription Deletes specific webhook subscription along with its delivery log.
+func (a *httpAdapter) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := a.Service.DeleteSubscription(id)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error deleting subscription with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusNoContent, nil)
+}
+
+// GetDeliveries Returns the delivery log of specific webhook subscription, newest first, optionally by `status`.
+func (a *httpAdapter) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	p := httputil.GetPaginationParams(r)
+	status := DeliveryStatus(r.URL.Query().Get("status"))
+
+	deliveries, err := a.Service.GetDeliveries(id, status, p.Limit, p.Offset)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error getting deliveries of subscription with ID (%s)", id)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusOK, GetDeliveriesResponse{
+		Deliveries: deliveries,
+		Pagination: p,
+	})
+}
+
+// Redeliver Sends specific delivery again, as a new delivery attempted by the next delivery run.
+func (a *httpAdapter) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	deliveryID := chi.URLParam(r, "deliveryId")
+
+	delivery, err := a.Service.Redeliver(id, deliveryID)
+	if err != nil {
+		msg := fmt.Sprintf("unexpected error redelivering delivery with ID (%s)", deliveryID)
+		httputil.HandlerHTTPError(w, msg, err, errMapper)
+		return
+	}
+
+	httputil.HandlerHTTPResponse(w, http.StatusAccepted, delivery)
+}
@@ -0,0 +1,232 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/go-chi/chi/v5"
+)
+
+// withURLParams Returns a copy of the request carrying chi URL params.
+func withURLParams(r *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func Test_httpAdapter_CreateSubscription(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_201",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().CreateSubscription(SubscriptionRequest{URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}}).
+					Return(&Subscription{ID: "1", URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}, Active: true, Secret: "s3cr3t"}, nil)
+				return &httpAdapter{Service: service}
+			},
+			body:       `{"url":"https://example.com","events":["post.created"]}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   "{\"id\":\"1\",\"url\":\"https://example.com\",\"events\":[\"post.created\"],\"active\":true,\"secret\":\"s3cr3t\"}",
+		},
+		{
+			name: "invalid_400",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().CreateSubscription(SubscriptionRequest{URL: "https://example.com"}).Return(nil, posts.ErrorBadRequest)
+				return &httpAdapter{Service: service}
+			},
+			body:       `{"url":"https://example.com"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\"message\":\"unexpected error creating subscription\",\"cause\":\"bad request\"}",
+		},
+		{
+			name: "malformed_body_500",
+			setup: func() *httpAdapter {
+				return &httpAdapter{Service: NewMocksService(t)}
+			},
+			body:       `{`,
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "{\"message\":\"unexpected error reading subscription creation body\",\"cause\":\"unexpected EOF\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/admin/webhooks", strings.NewReader(tt.body))
+
+			a.CreateSubscription(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_DeleteSubscription(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_204",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().DeleteSubscription("1").Return(nil)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().DeleteSubscription("1").Return(ErrSubscriptionNotFound)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error deleting subscription with ID (1)\",\"cause\":\"subscription not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodDelete, "/admin/webhooks/1", nil)
+
+			a.DeleteSubscription(recorder, withURLParams(request, map[string]string{"id": "1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_GetDeliveries(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_200",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetDeliveries("1", DeliveryFailed, 10, 0).Return([]Delivery{{
+					ID: "d1", SubscriptionID: "1", EventID: "e1", EventType: posts.EventPostCreated,
+					Payload: json.RawMessage(`{"id":"e1"}`), Status: DeliveryFailed, Attempts: 3, LastStatusCode: 500,
+				}}, nil)
+				return &httpAdapter{Service: service}
+			},
+			query:      "?status=failed",
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"deliveries\":[{\"id\":\"d1\",\"subscription_id\":\"1\",\"event_id\":\"e1\",\"event_type\":\"post.created\",\"payload\":{\"id\":\"e1\"},\"status\":\"failed\",\"attempts\":3,\"last_status_code\":500}],\"pagination\":{\"limit\":10,\"offset\":0,\"page\":1}}",
+		},
+		{
+			name: "not_found_404",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().GetDeliveries("1", DeliveryStatus(""), 10, 0).Return(nil, ErrSubscriptionNotFound)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\"message\":\"unexpected error getting deliveries of subscription with ID (1)\",\"cause\":\"subscription not found\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/admin/webhooks/1/deliveries"+tt.query, nil)
+
+			a.GetDeliveries(recorder, withURLParams(request, map[string]string{"id": "1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_httpAdapter_Redeliver(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func() *httpAdapter
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success_202",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().Redeliver("1", "d1").Return(&Delivery{ID: "d2", SubscriptionID: "1", EventID: "e1", EventType: posts.EventPostCreated, Payload: json.RawMessage(`{}`), Status: DeliveryPending}, nil)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusAccepted,
+			wantBody:   "{\"id\":\"d2\",\"subscription_id\":\"1\",\"event_id\":\"e1\",\"event_type\":\"post.created\",\"payload\":{},\"status\":\"pending\",\"attempts\":0}",
+		},
+		{
+			name: "inactive_409",
+			setup: func() *httpAdapter {
+				service := NewMocksService(t)
+				service.EXPECT().Redeliver("1", "d1").Return(nil, ErrSubscriptionInactive)
+				return &httpAdapter{Service: service}
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   "{\"message\":\"unexpected error redelivering delivery with ID (d1)\",\"cause\":\"subscription is inactive\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.setup()
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/admin/webhooks/1/deliveries/d1/redeliver", nil)
+
+			a.Redeliver(recorder, withURLParams(request, map[string]string{"id": "1", "deliveryId": "d1"}))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", string(body), tt.wantBody)
+			}
+		})
+	}
+}
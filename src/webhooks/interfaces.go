@@ -0,0 +1,96 @@
+package webhooks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/httputil"
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+// HTTPAdapter Webhooks http adapter interface.
+type HTTPAdapter interface {
+	// CreateSubscription Creates new webhook subscription.
+	CreateSubscription(http.ResponseWriter, *http.Request)
+	// GetSubscriptions Returns all webhook subscriptions.
+	GetSubscriptions(http.ResponseWriter, *http.Request)
+	// GetSubscription Returns single specific webhook subscription.
+	GetSubscription(http.ResponseWriter, *http.Request)
+	// UpdateSubscription Updates specific webhook subscription.
+	UpdateSubscription(http.ResponseWriter, *http.Request)
+	// DeleteSubscription Deletes specific webhook subscription along with its delivery log.
+	DeleteSubscription(http.ResponseWriter, *http.Request)
+	// GetDeliveries Returns the delivery log of specific webhook subscription.
+	GetDeliveries(http.ResponseWriter, *http.Request)
+	// Redeliver Sends specific delivery again.
+	Redeliver(http.ResponseWriter, *http.Request)
+}
+
+// Service Webhooks services interface, also a posts.EventPublisher.
+type Service interface {
+	// Publish Queues a delivery of event for every active subscription to its type.
+	Publish(event posts.Event) error
+	// CreateSubscription Creates a new subscription, generating its secret when none is provided.
+	CreateSubscription(request SubscriptionRequest) (*Subscription, error)
+	// GetSubscriptions Returns all subscriptions paginated, without their secrets.
+	GetSubscriptions(limit, offset int) ([]Subscription, error)
+	// GetSubscription Returns single subscription, without its secret.
+	GetSubscription(id string) (*Subscription, error)
+	// UpdateSubscription Replaces URL, events and active flag of a subscription, rotating its secret when one is provided.
+	UpdateSubscription(id string, request SubscriptionRequest) (*Subscription, error)
+	// DeleteSubscription Deletes a subscription along with its delivery log.
+	DeleteSubscription(id string) error
+	// GetDeliveries Returns the delivery log of a subscription paginated, newest first, optionally by status.
+	GetDeliveries(subscriptionID string, status DeliveryStatus, limit, offset int) ([]Delivery, error)
+	// Redeliver Queues a new delivery of the same payload as a delivery of a subscription and returns it.
+	Redeliver(subscriptionID, deliveryID string) (*Delivery, error)
+	// DeliverDue Attempts deliveries due at provided time, scheduling retries of failed ones with exponential backoff,
+	// and returns how many were attempted.
+	DeliverDue(now time.Time) (int, error)
+}
+
+// Repository Webhooks repository interface.
+type Repository interface {
+	// CreateSubscription Creates a new subscription and returns its generated ID.
+	CreateSubscription(subscription Subscription) (string, error)
+	// GetSubscriptions Returns all subscriptions paginated, oldest first.
+	GetSubscriptions(limit, offset int) ([]Subscription, error)
+	// GetSubscription Returns single subscription, secret included.
+	GetSubscription(id string) (*Subscription, error)
+	// GetSubscriptionsByEvent Returns active subscriptions to an event type, secrets included.
+	GetSubscriptionsByEvent(eventType posts.EventType) ([]Subscription, error)
+	// UpdateSubscription Updates URL, events, active flag and secret of a subscription.
+	UpdateSubscription(subscription Subscription) error
+	// DeleteSubscription Deletes a subscription along with its delivery log.
+	DeleteSubscription(id string) error
+	// CreateDeliveries Creates many deliveries in a single transaction and returns their generated IDs.
+	CreateDeliveries(deliveries []Delivery) ([]string, error)
+	// GetDeliveries Returns the delivery log of a subscription paginated, newest first, optionally by status.
+	GetDeliveries(subscriptionID string, status DeliveryStatus, limit, offset int) ([]Delivery, error)
+	// GetDelivery Returns single delivery of a subscription.
+	GetDelivery(subscriptionID, deliveryID string) (*Delivery, error)
+	// GetDueDeliveries Returns up to limit pending deliveries of active subscriptions due at provided time, oldest first.
+	GetDueDeliveries(now time.Time, limit int) ([]DueDelivery, error)
+	// UpdateDelivery Updates status, attempts and outcome of the latest attempt of a delivery.
+	UpdateDelivery(delivery Delivery) error
+}
+
+// SubscriptionRequest Structure used in subscription creation and update requests.
+type SubscriptionRequest struct {
+	URL    string            `json:"url"`
+	Events []posts.EventType `json:"events"`
+	Secret string            `json:"secret"`
+	Active *bool             `json:"active"`
+}
+
+// GetSubscriptionsResponse Structure used in subscriptions listing responses.
+type GetSubscriptionsResponse struct {
+	Subscriptions []Subscription      `json:"subscriptions"`
+	Pagination    httputil.Pagination `json:"pagination"`
+}
+
+// GetDeliveriesResponse Structure used in delivery log responses.
+type GetDeliveriesResponse struct {
+	Deliveries []Delivery          `json:"deliveries"`
+	Pagination httputil.Pagination `json:"pagination"`
+}
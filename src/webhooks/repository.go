@@ -0,0 +1,417 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSubscriptionNotFound Webhook subscription not found error.
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	// ErrDeliveryNotFound Webhook delivery not found error.
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	// ErrSubscriptionInactive Webhook subscription is not active, so nothing is delivered to it.
+	ErrSubscriptionInactive = errors.New("subscription is inactive")
+)
+
+// repository Simple productive repository pointing to sqlite db.
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository Returns new productive repository implementation.
+func NewRepository(db *sql.DB) (Repository, error) {
+	return &repository{db: db}, nil
+}
+
+// subscriptionColumns Columns read into subscriptions by scanSubscription.
+const subscriptionColumns = `public_id, url, secret, events, active, created_at`
+
+// scanSubscription Scans a row of subscriptionColumns.
+func scanSubscription(row interface{ Scan(...any) error }) (Subscription, error) {
+	var (
+		subscription Subscription
+		events       string
+	)
+	err := row.Scan(&subscription.ID, &subscription.URL, &subscription.Secret, &events, &subscription.Active, &subscription.CreatedAt)
+	if err != nil {
+		return Subscription{}, err
+	}
+	subscription.Events = splitEvents(events)
+	return subscription, nil
+}
+
+// CreateSubscription Creates a new subscription and returns its generated ID.
+func (r *repository) CreateSubscription(subscription Subscription) (string, error) {
+	publicID, err := newPublicID()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO webhook_subscriptions (public_id, url, secret, events, active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		publicID, subscription.URL, subscription.Secret, joinEvents(subscription.Events), subscription.Active, time.Now().UTC())
+	if err != nil {
+		return "", fmt.Errorf("failed to insert subscription: %w", err)
+	}
+
+	return publicID, nil
+}
+
+// GetSubscriptions Returns all subscriptions paginated, oldest first.
+func (r *repository) GetSubscriptions(limit, offset int) ([]Subscription, error) {
+	rows, err := r.db.Query(`
+		SELECT `+subscriptionColumns+`
+		FROM webhook_subscriptions
+		ORDER BY id
+		LIMIT ? OFFSET ?`,
+		limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubscriptions(rows)
+}
+
+// GetSubscription Returns single subscription, secret included.
+func (r *repository) GetSubscription(id string) (*Subscription, error) {
+	row := r.db.QueryRow(`
+		SELECT `+subscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE public_id = ?`,
+		id)
+
+	subscription, err := scanSubscription(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// GetSubscriptionsByEvent Returns active subscriptions to an event type, secrets included.
+func (r *repository) GetSubscriptionsByEvent(eventType posts.EventType) ([]Subscription, error) {
+	// Events are stored comma separated, wrapping them in commas matches whole event types only.
+	rows, err := r.db.Query(`
+		SELECT `+subscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE active = 1 AND ',' || events || ',' LIKE '%,' || ? || ',%'
+		ORDER BY id`,
+		eventType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubscriptions(rows)
+}
+
+// scanSubscriptions Scans every row of subscriptionColumns.
+func scanSubscriptions(rows *sql.Rows) ([]Subscription, error) {
+	subscriptions := []Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateSubscription Updates URL, events, active flag and secret of a subscription.
+func (r *repository) UpdateSubscription(subscription Subscription) error {
+	res, err := r.db.Exec(`
+		UPDATE webhook_subscriptions
+		SET url = ?, events = ?, active = ?, secret = ?
+		WHERE public_id = ?`,
+		subscription.URL, joinEvents(subscription.Events), subscription.Active, subscription.Secret, subscription.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated subscriptions: %w", err)
+	}
+	if affected == 0 {
+		return ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// DeleteSubscription Deletes a subscription along with its delivery log.
+func (r *repository) DeleteSubscription(id string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	key, err := subscriptionKey(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM webhook_deliveries WHERE subscription_id = ?`, key); err != nil {
+		return fmt.Errorf("failed to delete deliveries: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM webhook_subscriptions WHERE id = ?`, key); err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDeliveries Creates many deliveries in a single transaction and returns their generated IDs.
+func (r *repository) CreateDeliveries(deliveries []Delivery) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	insert, err := tx.Prepare(`
+		INSERT INTO webhook_deliveries
+			(public_id, subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare delivery insert: %w", err)
+	}
+	defer insert.Close()
+
+	now := time.Now().UTC()
+	ids := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		key, err := subscriptionKey(tx, delivery.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		publicID, err := newPublicID()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = insert.Exec(publicID, key, delivery.EventID, delivery.EventType, string(delivery.Payload),
+			delivery.Status, delivery.NextAttemptAt, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert delivery: %w", err)
+		}
+		ids = append(ids, publicID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return ids, nil
+}
+
+// deliveryColumns Columns read into deliveries by scanDelivery, from deliveries `d` joined with subscriptions `s`.
+const deliveryColumns = `
+	d.public_id, s.public_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at`
+
+// scanDelivery Scans a row of deliveryColumns followed by dest.
+func scanDelivery(row interface{ Scan(...any) error }, dest ...any) (Delivery, error) {
+	var (
+		delivery       Delivery
+		payload        string
+		nextAttemptAt  sql.NullTime
+		lastStatusCode sql.NullInt64
+		lastError      sql.NullString
+		deliveredAt    sql.NullTime
+	)
+	err := row.Scan(append([]any{
+		&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &payload,
+		&delivery.Status, &delivery.Attempts, &nextAttemptAt, &lastStatusCode, &lastError,
+		&delivery.CreatedAt, &deliveredAt,
+	}, dest...)...)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	delivery.Payload = []byte(payload)
+	delivery.LastStatusCode = int(lastStatusCode.Int64)
+	delivery.LastError = lastError.String
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	return delivery, nil
+}
+
+// GetDeliveries Returns the delivery log of a subscription paginated, newest first, optionally by status.
+func (r *repository) GetDeliveries(subscriptionID string, status DeliveryStatus, limit, offset int) ([]Delivery, error) {
+	if _, err := r.GetSubscription(subscriptionID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE s.public_id = ? AND (? = '' OR d.status = ?)
+		ORDER BY d.id DESC
+		LIMIT ? OFFSET ?`,
+		subscriptionID, status, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetDelivery Returns single delivery of a subscription.
+func (r *repository) GetDelivery(subscriptionID, deliveryID string) (*Delivery, error) {
+	row := r.db.QueryRow(`
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE s.public_id = ? AND d.public_id = ?`,
+		subscriptionID, deliveryID)
+
+	delivery, err := scanDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// GetDueDeliveries Returns up to limit pending deliveries of active subscriptions due at provided time, oldest first.
+func (r *repository) GetDueDeliveries(now time.Time, limit int) ([]DueDelivery, error) {
+	rows, err := r.db.Query(`
+		SELECT `+deliveryColumns+`, s.url, s.secret
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.status = ? AND d.next_attempt_at <= ? AND s.active = 1
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT ?`,
+		DeliveryPending, now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	due := []DueDelivery{}
+	for rows.Next() {
+		var item DueDelivery
+		item.Delivery, err = scanDelivery(rows, &item.URL, &item.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan due delivery: %w", err)
+		}
+		due = append(due, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read due deliveries: %w", err)
+	}
+
+	return due, nil
+}
+
+// UpdateDelivery Updates status, attempts and outcome of the latest attempt of a delivery.
+func (r *repository) UpdateDelivery(delivery Delivery) error {
+	res, err := r.db.Exec(`
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, delivered_at = ?
+		WHERE public_id = ?`,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		sql.NullInt64{Int64: int64(delivery.LastStatusCode), Valid: delivery.LastStatusCode != 0},
+		sql.NullString{String: delivery.LastError, Valid: delivery.LastError != ""},
+		delivery.DeliveredAt, delivery.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update delivery: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated deliveries: %w", err)
+	}
+	if affected == 0 {
+		return ErrDeliveryNotFound
+	}
+
+	return nil
+}
+
+// subscriptionKey Returns the internal key of the subscription with provided public ID.
+func subscriptionKey(tx *sql.Tx, publicID string) (int64, error) {
+	var id int64
+	err := tx.QueryRow(`SELECT id FROM webhook_subscriptions WHERE public_id = ?`, publicID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query subscription: %w", err)
+	}
+	return id, nil
+}
+
+// newPublicID Returns a new opaque and time ordered public ID.
+func newPublicID() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate public ID: %w", err)
+	}
+	return id.String(), nil
+}
+
+// joinEvents Returns event types as stored, comma separated.
+func joinEvents(events []posts.EventType) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ",")
+}
+
+// splitEvents Returns stored event types.
+func splitEvents(events string) []posts.EventType {
+	result := []posts.EventType{}
+	for name := range strings.SplitSeq(events, ",") {
+		if name != "" {
+			result = append(result, posts.EventType(name))
+		}
+	}
+	return result
+}
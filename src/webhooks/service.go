@@ -0,0 +1,382 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+)
+
+const (
+	// DeliveryBatchSize Maximum deliveries attempted by a single DeliverDue call.
+	DeliveryBatchSize = 50
+	// DeliveryConcurrency Maximum deliveries attempted at once by a single DeliverDue call.
+	DeliveryConcurrency = 10
+	// MinSecretLength Minimum length of secrets provided by subscribers.
+	MinSecretLength = 16
+	// maxErrorBody Maximum bytes of a failed response body kept as the delivery error.
+	maxErrorBody = 512
+)
+
+// ServiceConfig Webhooks service configuration.
+type ServiceConfig struct {
+	// Timeout How long a delivery attempt waits for its receiver to respond.
+	Timeout time.Duration
+	// MaxAttempts Attempts of a delivery before it is given up as failed.
+	MaxAttempts int
+	// Backoff Wait before the first retry of a delivery, doubled on every following retry.
+	Backoff time.Duration
+	// MaxBackoff Longest wait between retries of a delivery.
+	MaxBackoff time.Duration
+	// AllowPrivateTargets Whether subscriptions may point to loopback, link-local and private addresses, which are
+	// otherwise rejected so receivers cannot be used to reach internal services.
+	AllowPrivateTargets bool
+}
+
+type service struct {
+	Repository Repository
+	Config     ServiceConfig
+	Client     *http.Client
+}
+
+// NewService Returns new productive webhooks service implementation.
+func NewService(repository Repository, cfg ServiceConfig) (Service, error) {
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("invalid delivery timeout (%s)", cfg.Timeout)
+	}
+	if cfg.MaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid delivery max attempts (%d)", cfg.MaxAttempts)
+	}
+	if cfg.Backoff <= 0 || cfg.MaxBackoff < cfg.Backoff {
+		return nil, fmt.Errorf("invalid delivery backoff (%s up to %s)", cfg.Backoff, cfg.MaxBackoff)
+	}
+
+	return &service{
+		Repository: repository,
+		Config:     cfg,
+		Client:     newClient(cfg.Timeout, cfg.AllowPrivateTargets),
+	}, nil
+}
+
+// CreateSubscription Creates a new subscription, generating its secret when none is provided.
+// Subscriptions are active unless requested otherwise.
+func (s *service) CreateSubscription(request SubscriptionRequest) (*Subscription, error) {
+	subscription, err := newSubscription(request, s.Config.AllowPrivateTargets)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Secret == "" {
+		subscription.Secret, err = newSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subscription.ID, err = s.Repository.CreateSubscription(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// GetSubscriptions Returns all subscriptions paginated, without their secrets.
+func (s *service) GetSubscriptions(limit, offset int) ([]Subscription, error) {
+	subscriptions, err := s.Repository.GetSubscriptions(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, nil
+}
+
+// GetSubscription Returns single subscription, without its secret.
+func (s *service) GetSubscription(id string) (*Subscription, error) {
+	subscription, err := s.Repository.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// UpdateSubscription Replaces URL, events and active flag of a subscription, rotating its secret when one is provided.
+func (s *service) UpdateSubscription(id string, request SubscriptionRequest) (*Subscription, error) {
+	subscription, err := newSubscription(request, s.Config.AllowPrivateTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.Repository.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.ID = current.ID
+	subscription.CreatedAt = current.CreatedAt
+	if subscription.Secret == "" {
+		subscription.Secret = current.Secret
+	}
+	if err := s.Repository.UpdateSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = ""
+	return &subscription, nil
+}
+
+// DeleteSubscription Deletes a subscription along with its delivery log.
+func (s *service) DeleteSubscription(id string) error {
+	return s.Repository.DeleteSubscription(id)
+}
+
+// newSubscription Returns the subscription described by a request, validated, with its events sorted and deduplicated.
+// URLs pointing to private hosts are rejected unless allowed.
+func newSubscription(request SubscriptionRequest, allowPrivateTargets bool) (Subscription, error) {
+	target, err := url.Parse(request.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return Subscription{}, fmt.Errorf("%w: url must be an absolute http or https URL", posts.ErrorBadRequest)
+	}
+	if !allowPrivateTargets && privateHost(target.Hostname()) {
+		return Subscription{}, fmt.Errorf("%w: url must not point to a loopback, link-local or private address", posts.ErrorBadRequest)
+	}
+	if len(request.Events) == 0 {
+		return Subscription{}, fmt.Errorf("%w: missing events", posts.ErrorBadRequest)
+	}
+	for _, event := range request.Events {
+		if !event.Valid() {
+			return Subscription{}, fmt.Errorf("%w: unknown event (%s)", posts.ErrorBadRequest, event)
+		}
+	}
+	if request.Secret != "" && len(request.Secret) < MinSecretLength {
+		return Subscription{}, fmt.Errorf("%w: secret must be at least %d characters long", posts.ErrorBadRequest, MinSecretLength)
+	}
+
+	events := slices.Clone(request.Events)
+	slices.Sort(events)
+
+	return Subscription{
+		URL:    request.URL,
+		Events: slices.Compact(events),
+		Active: request.Active == nil || *request.Active,
+		Secret: request.Secret,
+	}, nil
+}
+
+// newSecret Returns a new random subscription secret.
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// GetDeliveries Returns the delivery log of a subscription paginated, newest first, optionally by status.
+func (s *service) GetDeliveries(subscriptionID string, status DeliveryStatus, limit, offset int) ([]Delivery, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("%w: invalid status (%s)", posts.ErrorBadRequest, status)
+	}
+
+	return s.Repository.GetDeliveries(subscriptionID, status, limit, offset)
+}
+
+// Redeliver Queues a new delivery of the same payload as a delivery of a subscription and returns it.
+// The original delivery is kept as is in the delivery log.
+func (s *service) Redeliver(subscriptionID, deliveryID string) (*Delivery, error) {
+	subscription, err := s.Repository.GetSubscription(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.Active {
+		return nil, ErrSubscriptionInactive
+	}
+
+	original, err := s.Repository.GetDelivery(subscriptionID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	delivery := Delivery{
+		SubscriptionID: subscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+	}
+
+	ids, err := s.Repository.CreateDeliveries([]Delivery{delivery})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.ID = ids[0]
+	return &delivery, nil
+}
+
+// Publish Queues a delivery of event for every active subscription to its type, due right away.
+// Payloads carry the outbox event ID, so receivers can tell apart events published more than once.
+// Events about content not visible to the public, such as drafts or comments awaiting moderation, are skipped.
+func (s *service) Publish(event posts.Event) error {
+	if !event.Public() {
+		return nil
+	}
+
+	subscriptions, err := s.Repository.GetSubscriptionsByEvent(event.Type)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload := Payload{ID: event.ID, Type: event.Type, CreatedAt: event.OccurredAt}
+	switch {
+	case event.BlogPost != nil:
+		payload.Data = event.BlogPost.PublicView()
+	case event.Comment != nil:
+		payload.Data = event.Comment
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]Delivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        payload.ID,
+			EventType:      event.Type,
+			Payload:        body,
+			Status:         DeliveryPending,
+			NextAttemptAt:  &now,
+		}
+	}
+
+	_, err = s.Repository.CreateDeliveries(deliveries)
+	return err
+}
+
+// DeliverDue Attempts deliveries due at provided time, scheduling retries of failed ones with exponential backoff,
+// and returns how many were attempted. Up to DeliveryConcurrency deliveries are attempted at once, so slow receivers
+// do not hold back the others. Deliveries still due are left for the next call.
+func (s *service) DeliverDue(now time.Time) (int, error) {
+	due, err := s.Repository.GetDueDeliveries(now, DeliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg         sync.WaitGroup
+		slots      = make(chan struct{}, DeliveryConcurrency)
+		deliveries = make([]Delivery, len(due))
+	)
+	for i, item := range due {
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			deliveries[i] = s.attempt(item, now.UTC())
+		})
+	}
+	wg.Wait()
+
+	for i, delivery := range deliveries {
+		if err := s.Repository.UpdateDelivery(delivery); err != nil {
+			return i, err
+		}
+	}
+
+	return len(due), nil
+}
+
+// attempt Sends a delivery to its receiver and returns it updated with the outcome.
+func (s *service) attempt(item DueDelivery, now time.Time) Delivery {
+	delivery := item.Delivery
+	delivery.Attempts++
+
+	statusCode, err := s.send(item, now)
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	if err == nil {
+		delivery.Status = DeliveryDelivered
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		return delivery
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= s.Config.MaxAttempts {
+		delivery.Status = DeliveryFailed
+		delivery.NextAttemptAt = nil
+		return delivery
+	}
+
+	next := now.Add(s.backoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	return delivery
+}
+
+// send Posts a delivery payload signed with its subscription secret, and returns the response status.
+// Responses other than 2xx are errors.
+func (s *service) send(item DueDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, item.URL, bytes.NewReader(item.Delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(item.Delivery.EventType))
+	req.Header.Set(HeaderDelivery, item.Delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(item.Secret, timestamp, item.Delivery.Payload))
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		if len(body) == 0 {
+			return res.StatusCode, fmt.Errorf("unexpected response status %d", res.StatusCode)
+		}
+		return res.StatusCode, fmt.Errorf("unexpected response status %d: %s", res.StatusCode, body)
+	}
+
+	// Draining the body lets the connection be reused.
+	io.Copy(io.Discard, io.LimitReader(res.Body, maxErrorBody))
+	return res.StatusCode, nil
+}
+
+// backoff Returns the wait before retrying a delivery after its attempts, doubling from Backoff up to MaxBackoff.
+func (s *service) backoff(attempts int) time.Duration {
+	wait := s.Config.Backoff
+	for range attempts - 1 {
+		wait *= 2
+		if wait >= s.Config.MaxBackoff {
+			return s.Config.MaxBackoff
+		}
+	}
+	return wait
+}
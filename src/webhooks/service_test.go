@@ -0,0 +1,651 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MatiasKopp/prosig-code-challenge/posts"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = ServiceConfig{
+	Timeout:     time.Second,
+	MaxAttempts: 3,
+	Backoff:     time.Minute,
+	MaxBackoff:  time.Hour,
+}
+
+// receiverConfig Test configuration allowing deliveries to test receivers, which listen on loopback.
+var receiverConfig = ServiceConfig{
+	Timeout:             time.Second,
+	MaxAttempts:         3,
+	Backoff:             time.Minute,
+	MaxBackoff:          time.Hour,
+	AllowPrivateTargets: true,
+}
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ServiceConfig
+		wantErr bool
+	}{
+		{name: "valid", cfg: testConfig},
+		{name: "missing_timeout", cfg: ServiceConfig{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}, wantErr: true},
+		{name: "missing_attempts", cfg: ServiceConfig{Timeout: time.Second, Backoff: time.Minute, MaxBackoff: time.Hour}, wantErr: true},
+		{name: "max_backoff_below_backoff", cfg: ServiceConfig{Timeout: time.Second, MaxAttempts: 3, Backoff: time.Hour, MaxBackoff: time.Minute}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewService(NewMocksRepository(t), tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewService() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_CreateSubscription(t *testing.T) {
+	inactive := false
+	tests := []struct {
+		name    string
+		request SubscriptionRequest
+		setup   func(m *MocksRepository)
+		want    *Subscription
+		wantErr error
+	}{
+		{
+			name:    "success_events_deduplicated",
+			request: SubscriptionRequest{URL: "https://example.com/hook", Events: []posts.EventType{posts.EventPostCreated, posts.EventCommentCreated, posts.EventPostCreated}, Secret: "0123456789abcdef"},
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateSubscription(Subscription{
+					URL:    "https://example.com/hook",
+					Events: []posts.EventType{posts.EventCommentCreated, posts.EventPostCreated},
+					Active: true,
+					Secret: "0123456789abcdef",
+				}).Return("1", nil)
+			},
+			want: &Subscription{
+				ID:     "1",
+				URL:    "https://example.com/hook",
+				Events: []posts.EventType{posts.EventCommentCreated, posts.EventPostCreated},
+				Active: true,
+				Secret: "0123456789abcdef",
+			},
+		},
+		{
+			name:    "success_inactive",
+			request: SubscriptionRequest{URL: "http://example.com", Events: []posts.EventType{posts.EventPostCreated}, Secret: "0123456789abcdef", Active: &inactive},
+			setup: func(m *MocksRepository) {
+				m.EXPECT().CreateSubscription(Subscription{
+					URL:    "http://example.com",
+					Events: []posts.EventType{posts.EventPostCreated},
+					Secret: "0123456789abcdef",
+				}).Return("1", nil)
+			},
+			want: &Subscription{
+				ID:     "1",
+				URL:    "http://example.com",
+				Events: []posts.EventType{posts.EventPostCreated},
+				Secret: "0123456789abcdef",
+			},
+		},
+		{
+			name:    "relative_url",
+			request: SubscriptionRequest{URL: "/hook", Events: []posts.EventType{posts.EventPostCreated}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "unsupported_scheme",
+			request: SubscriptionRequest{URL: "ftp://example.com", Events: []posts.EventType{posts.EventPostCreated}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "loopback_url",
+			request: SubscriptionRequest{URL: "http://127.0.0.1:8080/hook", Events: []posts.EventType{posts.EventPostCreated}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "ipv6_loopback_url",
+			request: SubscriptionRequest{URL: "http://[::1]/hook", Events: []posts.EventType{posts.EventPostCreated}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "localhost_url",
+			request: SubscriptionRequest{URL: "http://LocalHost./hook", Events: []posts.EventType{posts.EventPostCreated}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "link_local_url",
+			request: SubscriptionRequest{URL: "http://169.254.169.254/latest/meta-data", Events: []posts.EventType{posts.EventPostCreated}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "private_url",
+			request: SubscriptionRequest{URL: "https://10.0.0.5/hook", Events: []posts.EventType{posts.EventPostCreated}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "missing_events",
+			request: SubscriptionRequest{URL: "https://example.com"},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "unknown_event",
+			request: SubscriptionRequest{URL: "https://example.com", Events: []posts.EventType{"post.deleted"}},
+			wantErr: posts.ErrorBadRequest,
+		},
+		{
+			name:    "short_secret",
+			request: SubscriptionRequest{URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}, Secret: "short"},
+			wantErr: posts.ErrorBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo, Config: testConfig}
+			got, err := s.CreateSubscription(tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_CreateSubscription_privateTargetsAllowed(t *testing.T) {
+	repo := NewMocksRepository(t)
+	repo.EXPECT().CreateSubscription(mock.Anything).Return("1", nil)
+
+	s := &service{Repository: repo, Config: receiverConfig}
+	if _, err := s.CreateSubscription(SubscriptionRequest{URL: "http://127.0.0.1:8080/hook", Events: []posts.EventType{posts.EventPostCreated}}); err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+}
+
+func Test_service_CreateSubscription_generatesSecret(t *testing.T) {
+	repo := NewMocksRepository(t)
+	repo.EXPECT().CreateSubscription(mock.MatchedBy(func(s Subscription) bool {
+		return len(s.Secret) == 64
+	})).Return("1", nil)
+
+	s := &service{Repository: repo, Config: testConfig}
+	got, err := s.CreateSubscription(SubscriptionRequest{URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	if len(got.Secret) != 64 {
+		t.Errorf("CreateSubscription() secret = %q, want 64 hex characters", got.Secret)
+	}
+}
+
+func Test_service_UpdateSubscription(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := &Subscription{ID: "1", URL: "https://old.example.com", Events: []posts.EventType{posts.EventPostCreated}, Active: true, Secret: "0123456789abcdef", CreatedAt: created}
+	tests := []struct {
+		name    string
+		request SubscriptionRequest
+		setup   func(m *MocksRepository)
+		want    *Subscription
+		wantErr error
+	}{
+		{
+			name:    "keeps_secret",
+			request: SubscriptionRequest{URL: "https://example.com", Events: []posts.EventType{posts.EventCommentCreated}},
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscription("1").Return(current, nil)
+				m.EXPECT().UpdateSubscription(Subscription{ID: "1", URL: "https://example.com", Events: []posts.EventType{posts.EventCommentCreated}, Active: true, Secret: "0123456789abcdef", CreatedAt: created}).Return(nil)
+			},
+			want: &Subscription{ID: "1", URL: "https://example.com", Events: []posts.EventType{posts.EventCommentCreated}, Active: true, CreatedAt: created},
+		},
+		{
+			name:    "rotates_secret",
+			request: SubscriptionRequest{URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}, Secret: "fedcba9876543210"},
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscription("1").Return(current, nil)
+				m.EXPECT().UpdateSubscription(Subscription{ID: "1", URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}, Active: true, Secret: "fedcba9876543210", CreatedAt: created}).Return(nil)
+			},
+			want: &Subscription{ID: "1", URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}, Active: true, CreatedAt: created},
+		},
+		{
+			name:    "not_found",
+			request: SubscriptionRequest{URL: "https://example.com", Events: []posts.EventType{posts.EventPostCreated}},
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscription("1").Return(nil, ErrSubscriptionNotFound)
+			},
+			wantErr: ErrSubscriptionNotFound,
+		},
+		{
+			name:    "invalid_request",
+			request: SubscriptionRequest{URL: "https://example.com"},
+			wantErr: posts.ErrorBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			s := &service{Repository: repo, Config: testConfig}
+			got, err := s.UpdateSubscription("1", tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpdateSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetSubscriptions_hidesSecrets(t *testing.T) {
+	repo := NewMocksRepository(t)
+	repo.EXPECT().GetSubscriptions(10, 0).Return([]Subscription{{ID: "1", Secret: "0123456789abcdef"}}, nil)
+
+	s := &service{Repository: repo, Config: testConfig}
+	got, err := s.GetSubscriptions(10, 0)
+	if err != nil {
+		t.Fatalf("GetSubscriptions() error = %v", err)
+	}
+	if want := []Subscription{{ID: "1"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSubscriptions() = %v, want %v", got, want)
+	}
+}
+
+func Test_service_Publish(t *testing.T) {
+	occurred := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := posts.Event{ID: "e1", Type: posts.EventCommentCreated, AggregateID: "p1", PostStatus: posts.StatusPublished, OccurredAt: occurred, Comment: &posts.Comment{ID: "c1", BlogPostID: "p1", CommentText: "hi", ModerationStatus: posts.ModerationApproved}}
+	pending := posts.Event{ID: "e2", Type: posts.EventCommentCreated, AggregateID: "p1", PostStatus: posts.StatusPublished, OccurredAt: occurred, Comment: &posts.Comment{ID: "c2", BlogPostID: "p1", CommentText: "hi", ModerationStatus: posts.ModerationPending}}
+	tests := []struct {
+		name    string
+		event   *posts.Event
+		setup   func(m *MocksRepository)
+		wantErr bool
+	}{
+		{
+			name: "delivery_per_subscription",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscriptionsByEvent(posts.EventCommentCreated).Return([]Subscription{{ID: "s1"}, {ID: "s2"}}, nil)
+				m.EXPECT().CreateDeliveries(mock.MatchedBy(func(deliveries []Delivery) bool {
+					if len(deliveries) != 2 || deliveries[0].SubscriptionID != "s1" || deliveries[1].SubscriptionID != "s2" {
+						return false
+					}
+
+					var payload struct {
+						ID        string          `json:"id"`
+						Type      posts.EventType `json:"type"`
+						CreatedAt time.Time       `json:"created_at"`
+						Data      posts.Comment   `json:"data"`
+					}
+					if err := json.Unmarshal(deliveries[0].Payload, &payload); err != nil {
+						return false
+					}
+					return payload.ID == "e1" &&
+						deliveries[0].EventID == "e1" &&
+						payload.ID == deliveries[1].EventID &&
+						payload.Type == posts.EventCommentCreated &&
+						payload.CreatedAt.Equal(occurred) &&
+						payload.Data.ID == "c1" &&
+						deliveries[0].Status == DeliveryPending &&
+						deliveries[0].NextAttemptAt != nil
+				})).Return([]string{"d1", "d2"}, nil)
+			},
+		},
+		{
+			name:  "not_public_skipped",
+			event: &pending,
+			setup: func(*MocksRepository) {},
+		},
+		{
+			name: "no_subscriptions",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscriptionsByEvent(posts.EventCommentCreated).Return([]Subscription{}, nil)
+			},
+		},
+		{
+			name: "repo_error",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscriptionsByEvent(posts.EventCommentCreated).Return(nil, errors.New("fail"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo, Config: testConfig}
+			published := event
+			if tt.event != nil {
+				published = *tt.event
+			}
+			if err := s.Publish(published); (err != nil) != tt.wantErr {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// receivedRequest Request captured by a test receiver.
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver Returns a test webhook receiver responding status and body, capturing the requests it gets.
+func newReceiver(t *testing.T, status int, body string) (*httptest.Server, *[]receivedRequest) {
+	var (
+		mu       sync.Mutex
+		received []receivedRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedRequest{header: r.Header.Clone(), body: requestBody})
+		mu.Unlock()
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func Test_service_DeliverDue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	secret := "0123456789abcdef"
+	payload := json.RawMessage(`{"id":"e1","type":"post.created"}`)
+	pending := Delivery{ID: "d1", SubscriptionID: "s1", EventID: "e1", EventType: posts.EventPostCreated, Payload: payload, Status: DeliveryPending, NextAttemptAt: &now}
+
+	// closed Receiver that is no longer listening.
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		url          string
+		attempts     int
+		want         func(d Delivery) Delivery
+		wantReceived int
+	}{
+		{
+			name:   "delivered",
+			status: http.StatusNoContent,
+			want: func(d Delivery) Delivery {
+				d.Status = DeliveryDelivered
+				d.Attempts = 1
+				d.LastStatusCode = http.StatusNoContent
+				d.NextAttemptAt = nil
+				d.DeliveredAt = &now
+				return d
+			},
+			wantReceived: 1,
+		},
+		{
+			name:     "retried_with_backoff",
+			status:   http.StatusInternalServerError,
+			body:     "boom",
+			attempts: 1,
+			want: func(d Delivery) Delivery {
+				next := now.Add(2 * time.Minute)
+				d.Attempts = 2
+				d.LastStatusCode = http.StatusInternalServerError
+				d.LastError = "unexpected response status 500: boom"
+				d.NextAttemptAt = &next
+				return d
+			},
+			wantReceived: 1,
+		},
+		{
+			name:     "failed_after_max_attempts",
+			status:   http.StatusGone,
+			attempts: 2,
+			want: func(d Delivery) Delivery {
+				d.Status = DeliveryFailed
+				d.Attempts = 3
+				d.LastStatusCode = http.StatusGone
+				d.LastError = "unexpected response status 410"
+				d.NextAttemptAt = nil
+				return d
+			},
+			wantReceived: 1,
+		},
+		{
+			name: "unreachable_receiver",
+			url:  closed.URL,
+			want: func(d Delivery) Delivery {
+				next := now.Add(time.Minute)
+				d.Attempts = 1
+				d.NextAttemptAt = &next
+				return d
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newReceiver(t, tt.status, tt.body)
+			url := server.URL
+			if tt.url != "" {
+				url = tt.url
+			}
+
+			delivery := pending
+			delivery.Attempts = tt.attempts
+			want := tt.want(delivery)
+
+			repo := NewMocksRepository(t)
+			repo.EXPECT().GetDueDeliveries(now, DeliveryBatchSize).Return([]DueDelivery{{Delivery: delivery, URL: url, Secret: secret}}, nil)
+			repo.EXPECT().UpdateDelivery(mock.Anything).RunAndReturn(func(got Delivery) error {
+				// Connection errors vary by platform, only their presence is checked.
+				if tt.url != "" {
+					if got.LastError == "" {
+						t.Error("UpdateDelivery() missing connection error")
+					}
+					got.LastError = ""
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("UpdateDelivery() = %+v, want %+v", got, want)
+				}
+				return nil
+			})
+
+			s, err := NewService(repo, receiverConfig)
+			if err != nil {
+				t.Fatalf("NewService() error = %v", err)
+			}
+			attempted, err := s.DeliverDue(now)
+			if err != nil {
+				t.Fatalf("DeliverDue() error = %v", err)
+			}
+			if attempted != 1 {
+				t.Errorf("DeliverDue() = %d, want 1", attempted)
+			}
+
+			if len(*received) != tt.wantReceived {
+				t.Fatalf("receiver got %d requests, want %d", len(*received), tt.wantReceived)
+			}
+			for _, r := range *received {
+				if string(r.body) != string(payload) {
+					t.Errorf("receiver body = %s, want %s", r.body, payload)
+				}
+				if r.header.Get(HeaderEvent) != string(posts.EventPostCreated) || r.header.Get(HeaderDelivery) != "d1" {
+					t.Errorf("receiver headers = %v", r.header)
+				}
+				timestamp, err := strconv.ParseInt(r.header.Get(HeaderTimestamp), 10, 64)
+				if err != nil || timestamp != now.Unix() {
+					t.Errorf("receiver timestamp = %q, want %d", r.header.Get(HeaderTimestamp), now.Unix())
+				}
+				if !Verify(secret, timestamp, r.body, r.header.Get(HeaderSignature)) {
+					t.Errorf("receiver signature %q does not verify", r.header.Get(HeaderSignature))
+				}
+			}
+		})
+	}
+}
+
+func Test_service_DeliverDue_concurrent(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Every request waits for the others, so deliveries only complete when attempted at once.
+	const count = 3
+	var arrived sync.WaitGroup
+	arrived.Add(count)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived.Done()
+		arrived.Wait()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	due := make([]DueDelivery, count)
+	for i := range due {
+		due[i] = DueDelivery{Delivery: Delivery{ID: strconv.Itoa(i), Status: DeliveryPending, NextAttemptAt: &now}, URL: server.URL, Secret: "0123456789abcdef"}
+	}
+
+	repo := NewMocksRepository(t)
+	repo.EXPECT().GetDueDeliveries(now, DeliveryBatchSize).Return(due, nil)
+	repo.EXPECT().UpdateDelivery(mock.MatchedBy(func(d Delivery) bool { return d.Status == DeliveryDelivered })).Return(nil).Times(count)
+
+	s, err := NewService(repo, receiverConfig)
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	attempted, err := s.DeliverDue(now)
+	if err != nil {
+		t.Fatalf("DeliverDue() error = %v", err)
+	}
+	if attempted != count {
+		t.Errorf("DeliverDue() = %d, want %d", attempted, count)
+	}
+}
+
+func Test_service_DeliverDue_privateTarget(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	server, received := newReceiver(t, http.StatusNoContent, "")
+
+	repo := NewMocksRepository(t)
+	repo.EXPECT().GetDueDeliveries(now, DeliveryBatchSize).Return([]DueDelivery{{Delivery: Delivery{ID: "d1", Status: DeliveryPending, NextAttemptAt: &now}, URL: server.URL}}, nil)
+	repo.EXPECT().UpdateDelivery(mock.MatchedBy(func(d Delivery) bool {
+		return d.Status == DeliveryPending && strings.Contains(d.LastError, ErrPrivateTarget.Error())
+	})).Return(nil)
+
+	s, err := NewService(repo, testConfig)
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	if _, err := s.DeliverDue(now); err != nil {
+		t.Fatalf("DeliverDue() error = %v", err)
+	}
+	if len(*received) != 0 {
+		t.Errorf("receiver got %d requests, want 0", len(*received))
+	}
+}
+
+func Test_service_backoff(t *testing.T) {
+	s := &service{Config: testConfig}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 4, want: 8 * time.Minute},
+		{attempts: 7, want: time.Hour},
+		{attempts: 100, want: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempts), func(t *testing.T) {
+			if got := s.backoff(tt.attempts); got != tt.want {
+				t.Errorf("backoff() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_Redeliver(t *testing.T) {
+	payload := json.RawMessage(`{"id":"e1"}`)
+	tests := []struct {
+		name    string
+		setup   func(m *MocksRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscription("s1").Return(&Subscription{ID: "s1", Active: true}, nil)
+				m.EXPECT().GetDelivery("s1", "d1").Return(&Delivery{ID: "d1", SubscriptionID: "s1", EventID: "e1", EventType: posts.EventPostCreated, Payload: payload, Status: DeliveryFailed, Attempts: 3}, nil)
+				m.EXPECT().CreateDeliveries(mock.MatchedBy(func(deliveries []Delivery) bool {
+					d := deliveries[0]
+					return len(deliveries) == 1 && d.SubscriptionID == "s1" && d.EventID == "e1" &&
+						string(d.Payload) == string(payload) && d.Status == DeliveryPending && d.Attempts == 0 && d.NextAttemptAt != nil
+				})).Return([]string{"d2"}, nil)
+			},
+		},
+		{
+			name: "inactive_subscription",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscription("s1").Return(&Subscription{ID: "s1"}, nil)
+			},
+			wantErr: ErrSubscriptionInactive,
+		},
+		{
+			name: "delivery_not_found",
+			setup: func(m *MocksRepository) {
+				m.EXPECT().GetSubscription("s1").Return(&Subscription{ID: "s1", Active: true}, nil)
+				m.EXPECT().GetDelivery("s1", "d1").Return(nil, ErrDeliveryNotFound)
+			},
+			wantErr: ErrDeliveryNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMocksRepository(t)
+			tt.setup(repo)
+			s := &service{Repository: repo, Config: testConfig}
+			got, err := s.Redeliver("s1", "d1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Redeliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.ID != "d2" {
+				t.Errorf("Redeliver() ID = %s, want d2", got.ID)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"e1"}`)
+	signature := Sign("secret", 1700000000, body)
+
+	// Computed with: printf '1700000000.{"id":"e1"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=46fc0b60e09563a94dea2fa3b7b63d83458dd87b30fac860dcbabac0df9bdbde"
+	if signature != want {
+		t.Fatalf("Sign() = %s, want %s", signature, want)
+	}
+	if !Verify("secret", 1700000000, body, signature) {
+		t.Error("Verify() = false for its own signature")
+	}
+	if Verify("other", 1700000000, body, signature) {
+		t.Error("Verify() = true with another secret")
+	}
+	if Verify("secret", 1700000001, body, signature) {
+		t.Error("Verify() = true with another timestamp")
+	}
+}
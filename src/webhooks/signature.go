@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	// HeaderEvent Header carrying the event type of a payload.
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderDelivery Header carrying the delivery ID, which changes on redeliveries unlike the payload event ID.
+	HeaderDelivery = "X-Webhook-Delivery"
+	// HeaderTimestamp Header carrying when a payload was sent, as Unix seconds.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature Header carrying the payload signature, as `sha256=` followed by the hex encoded HMAC.
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign Returns the HMAC-SHA256 signature of a payload sent at timestamp, as sent in HeaderSignature.
+// The timestamp is signed along with the body, as `<timestamp>.<body>`, so receivers can reject replayed payloads.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify Reports whether signature is the one of a payload sent at timestamp, comparing in constant time.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
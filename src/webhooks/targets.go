@@ -0,0 +1,62 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateTarget Receiver address is loopback, link-local or private, which subscriptions are not allowed to reach.
+var ErrPrivateTarget = errors.New("webhook target is not a public address")
+
+// privateAddr Reports whether an address is one receivers must not be reached at: loopback, link-local, private,
+// unspecified or multicast.
+func privateAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified()
+}
+
+// privateHost Reports whether a URL host name is known to be private without resolving it: a private IP literal or
+// localhost. Names resolving to private addresses are only caught when dialed.
+func privateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	return err == nil && privateAddr(addr)
+}
+
+// newClient Returns the HTTP client deliveries are sent with. Unless private targets are allowed, connections to
+// private addresses are refused once names are resolved, which also covers redirects, and proxies are ignored so
+// they cannot reach those addresses on its behalf.
+func newClient(timeout time.Duration, allowPrivateTargets bool) *http.Client {
+	if allowPrivateTargets {
+		return &http.Client{Timeout: timeout}
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrPrivateTarget, address)
+			}
+			if privateAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrPrivateTarget, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}